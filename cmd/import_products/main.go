@@ -0,0 +1,102 @@
+// Command import_products creates products from a CSV file, as the
+// POST /products/import endpoint does, and writes the report of accepted and
+// rejected lines. It exits with status 1 when any line was rejected.
+//
+// Usage:
+//
+//	go run ./cmd/import_products -file products.csv [-dry-run] [-report report.csv] [-format csv|json]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/Akiles94/go-test-api/config"
+	"github.com/Akiles94/go-test-api/contexts/product/application/dto"
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/product/infra/adapters"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/outbox"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_adapters"
+	"github.com/Akiles94/go-test-api/db"
+)
+
+func main() {
+	filePath := flag.String("file", "", "CSV file to import")
+	dryRun := flag.Bool("dry-run", false, "validate the file without creating any product")
+	reportPath := flag.String("report", "", "where to write the report (default: standard output)")
+	format := flag.String("format", "csv", "report format: csv or json")
+	tenant := flag.String("tenant", request_context.DefaultTenant, "tenant whose catalogue receives the products")
+	flag.Parse()
+
+	if *filePath == "" || (*format != "csv" && *format != "json") || !request_context.IsValidTenant(*tenant) {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	config.LoadEnv()
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		log.Fatalf("❌ Cannot open import file: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := dto.ParseProductImportCSV(file, config.Env.ImportMaxRows)
+	if err != nil {
+		log.Fatalf("❌ Cannot read import file: %v", err)
+	}
+
+	database := db.Connect()
+	repo := adapters.NewProductRepository(database)
+	createProductUseCase := use_cases.NewCreateProductUseCase(
+		repo,
+		adapters.NewProductAuditRepository(database),
+		outbox.NewGormEventOutbox(database),
+		shared_adapters.NewGormTransactionManager(database))
+	importProductsUseCase := use_cases.NewImportProductsUseCase(repo, createProductUseCase)
+
+	// The command is run by operators, who may write to the catalogue.
+	ctx := request_context.WithPrincipal(context.Background(), request_context.Principal{
+		Subject: "import_products",
+		Roles:   []string{string(shared_models.RoleEditor)},
+	})
+	ctx = request_context.WithTenant(ctx, *tenant)
+	report, err := importProductsUseCase.Execute(ctx, rows, *dryRun)
+	if err != nil {
+		log.Fatalf("❌ Import failed: %v", err)
+	}
+
+	var out io.Writer = os.Stdout
+	if *reportPath != "" {
+		reportFile, err := os.Create(*reportPath)
+		if err != nil {
+			log.Fatalf("❌ Cannot create report file: %v", err)
+		}
+		defer reportFile.Close()
+		out = reportFile
+	}
+	if err := writeReport(out, report, *format); err != nil {
+		log.Fatalf("❌ Cannot write report: %v", err)
+	}
+
+	log.Printf("📦 %d accepted, %d rejected (dry run: %t)", report.Accepted(), report.Rejected(), report.DryRun)
+	if report.Rejected() > 0 {
+		os.Exit(1)
+	}
+}
+
+func writeReport(out io.Writer, report models.ProductImportReport, format string) error {
+	if format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(dto.NewProductImportReportResponse(report))
+	}
+	return dto.WriteProductImportReportCSV(out, report)
+}
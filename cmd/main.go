@@ -1,13 +1,35 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/Akiles94/go-test-api/config"
+	api_key_adapters "github.com/Akiles94/go-test-api/contexts/api_keys/infra/adapters"
+	api_key_modules "github.com/Akiles94/go-test-api/contexts/api_keys/infra/modules"
 	"github.com/Akiles94/go-test-api/contexts/product/infra/adapters"
+	"github.com/Akiles94/go-test-api/contexts/product/infra/handlers"
 	"github.com/Akiles94/go-test-api/contexts/product/infra/modules"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/events"
 	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/pagination"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/cache"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/idempotency"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/jwt"
 	"github.com/Akiles94/go-test-api/contexts/shared/infra/middlewares"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/outbox"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/ratelimit"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_adapters"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	user_adapters "github.com/Akiles94/go-test-api/contexts/users/infra/adapters"
+	user_modules "github.com/Akiles94/go-test-api/contexts/users/infra/modules"
+	webhook_adapters "github.com/Akiles94/go-test-api/contexts/webhooks/infra/adapters"
+	webhook_modules "github.com/Akiles94/go-test-api/contexts/webhooks/infra/modules"
 	"github.com/Akiles94/go-test-api/db"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -31,12 +53,36 @@ import (
 // @host localhost:8080
 // @BasePath /api/v1
 // @schemes http https
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT bearer token, sent as "Bearer <token>"
+
+// @securityDefinitions.apikey APIKeyAuth
+// @in header
+// @name X-API-Key
+// @description API key issued through /api-keys, for server-to-server clients
 func main() {
 	config.LoadEnv()
 
 	database := db.Connect()
 
-	if err := database.AutoMigrate(&adapters.ProductEntity{}); err != nil {
+	if err := adapters.MigrateProductEntities(database); err != nil {
+		log.Fatalf("❌ DB migration failed: %v", err)
+	}
+	if err := idempotency.MigrateIdempotencyKeys(database); err != nil {
+		log.Fatalf("❌ DB migration failed: %v", err)
+	}
+	if err := database.AutoMigrate(
+		&outbox.OutboxMessageEntity{},
+		&webhook_adapters.SubscriptionEntity{},
+		&webhook_adapters.DeliveryEntity{},
+		&webhook_adapters.DeliveryAttemptEntity{},
+		&api_key_adapters.APIKeyEntity{},
+		&user_adapters.UserEntity{},
+		&ratelimit.RateLimitCounterEntity{},
+	); err != nil {
 		log.Fatalf("❌ DB migration failed: %v", err)
 	}
 
@@ -44,7 +90,55 @@ func main() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	idempotencyStore := idempotency.NewGormIdempotencyStore(database)
+
+	jwtKeys, err := jwt.LoadKeys(config.Env.JWTSecret, config.Env.JWTPublicKeyFile, config.Env.JWTJWKSFile)
+	if err != nil {
+		log.Fatalf("❌ Loading JWT keys failed: %v", err)
+	}
+	tokenVerifier := jwt.NewVerifier(jwtKeys, jwt.Config{
+		Issuer:   config.Env.JWTIssuer,
+		Audience: config.Env.JWTAudience,
+		Leeway:   config.Env.JWTLeeway,
+	})
+
+	if config.Env.JWTSecret == "" {
+		log.Println("⚠️  No JWT_SECRET set, users cannot log in")
+	}
+	tokenSigner := jwt.NewSigner([]byte(config.Env.JWTSecret))
+
+	rateLimitConfig, err := loadRateLimitConfig()
+	if err != nil {
+		log.Fatalf("❌ Rate limit configuration is invalid: %v", err)
+	}
+	var rateLimitStore interfaces.RateLimitStore
+	var runRateLimitPurge func(ctx context.Context, interval time.Duration)
+	switch config.Env.RateLimitStore {
+	case "memory":
+		store := ratelimit.NewMemoryRateLimitStore()
+		rateLimitStore, runRateLimitPurge = store, store.RunEviction
+	case "postgres":
+		store := ratelimit.NewGormRateLimitStore(database)
+		rateLimitStore, runRateLimitPurge = store, store.RunPurge
+	default:
+		log.Fatalf("❌ RATE_LIMIT_STORE must be memory or postgres, got %q", config.Env.RateLimitStore)
+	}
+
+	var productCacheStore interfaces.CacheStore
+	switch config.Env.ProductCacheStore {
+	case "none":
+	case "memory":
+		productCacheStore = cache.NewMemoryCacheStore(config.Env.ProductCacheSize)
+	default:
+		log.Fatalf("❌ PRODUCT_CACHE_STORE must be none or memory, got %q", config.Env.ProductCacheStore)
+	}
+
+	apiKeysModule := api_key_modules.NewAPIKeysModule(database)
+
 	router := gin.New()
+	if err := router.SetTrustedProxies(config.Env.TrustedProxies); err != nil {
+		log.Fatalf("❌ TRUSTED_PROXIES is invalid: %v", err)
+	}
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
@@ -53,7 +147,12 @@ func main() {
 	router.Use(middlewares.StructuredLogger())
 	router.Use(middlewares.SecurityHeadersMiddleware())
 	router.Use(middlewares.CORSMiddleware())
-	router.Use(middlewares.RateLimitMiddleware())
+	router.Use(middlewares.IPRateLimitMiddleware(rateLimitStore, rateLimitConfig.IP))
+	router.Use(middlewares.AuthenticationMiddleware(tokenVerifier))
+	router.Use(middlewares.APIKeyAuthenticationMiddleware(apiKeysModule))
+	router.Use(middlewares.TenantMiddleware(config.Env.TenantBaseDomain))
+	router.Use(middlewares.RateLimitMiddleware(rateLimitStore, rateLimitConfig))
+	router.Use(middlewares.IdempotencyMiddleware(idempotencyStore, config.Env.IdempotencyTTL, config.Env.IdempotencyLockTimeout, "/api/v1/api-keys", "/api/v1/auth"))
 	router.Use(middlewares.ErrorHandlerMiddleware())
 
 	router.GET("/health", func(c *gin.Context) {
@@ -62,22 +161,80 @@ func main() {
 	router.GET("/swagger", func(c *gin.Context) {
 		c.Redirect(302, "/swagger/index.html")
 	})
-	api := router.Group("/api/v1")
+	router.NoRoute(func(c *gin.Context) {
+		c.Error(shared_handlers.ErrNotFound)
+	})
+	api := router.Group("/api/v1", middlewares.RequireAuthentication())
+
+	cursorCodec := pagination.NewCursorCodec([]byte(config.Env.CursorSecret))
+	txManager := shared_adapters.NewGormTransactionManager(database)
+	eventOutbox := outbox.NewGormEventOutbox(database)
+	eventDispatcher := events.NewDispatcher()
 
 	var appModules []interfaces.Module
 
-	productModule := modules.NewProductModule(database)
+	productModule := modules.NewProductModule(database, txManager, eventOutbox, cursorCodec, config.Env.BatchMaxItems, config.Env.ImportMaxRows, productCacheStore, config.Env.ProductCacheTTL, handlers.ProductCacheControl{
+		Item: config.Env.ProductHTTPCacheControl,
+		List: config.Env.ProductListHTTPCacheControl,
+	})
 	appModules = append(appModules, productModule)
+	router.GET("/health/cache", middlewares.RequireAuthentication(), middlewares.RequirePermission(shared_models.PermissionMetricsRead), func(c *gin.Context) {
+		stats, enabled := productModule.CacheStats()
+		c.JSON(200, gin.H{"enabled": enabled, "products": stats})
+	})
+
+	webhooksModule := webhook_modules.NewWebhooksModule(database, txManager, eventDispatcher, cursorCodec)
+	appModules = append(appModules, webhooksModule)
+
+	appModules = append(appModules, apiKeysModule)
+
+	usersModule := user_modules.NewUsersModule(database, txManager, tokenSigner, config.Env.JWTIssuer, config.Env.JWTAudience, config.Env.JWTTokenTTL)
+	appModules = append(appModules, usersModule)
 
 	for _, m := range appModules {
 		switch mod := m.(type) {
 		case *modules.ProductModule:
 			mod.RegisterRoutes(api.Group("/products"))
+		case *webhook_modules.WebhooksModule:
+			mod.RegisterRoutes(api.Group("/webhooks"))
+		case *api_key_modules.APIKeysModule:
+			mod.RegisterRoutes(api.Group("/api-keys"))
+		case *user_modules.UsersModule:
+			mod.RegisterRoutes(router.Group("/api/v1/auth"))
 		}
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go outbox.NewRelay(database, eventDispatcher, outbox.DefaultRelayConfig()).Run(ctx)
+	go webhooksModule.RunWorker(ctx)
+	go idempotencyStore.RunPurge(ctx, time.Hour)
+	go runRateLimitPurge(ctx, time.Minute)
+
 	log.Printf("🚀 Server starting on port %s", config.Env.ApiPort)
 	if err := router.Run(":" + config.Env.ApiPort); err != nil {
 		log.Fatalf("❌ Error starting server: %v", err)
 	}
 }
+
+// loadRateLimitConfig reads the rate limit policies of the environment.
+func loadRateLimitConfig() (middlewares.RateLimitConfig, error) {
+	routes, err := middlewares.ParseRateLimitPolicies(config.Env.RateLimitRoutes)
+	if err != nil {
+		return middlewares.RateLimitConfig{}, err
+	}
+	callers, err := middlewares.ParseRateLimitPolicies(config.Env.RateLimitCallers)
+	if err != nil {
+		return middlewares.RateLimitConfig{}, err
+	}
+	ip, err := middlewares.ParseRateLimitPolicy(config.Env.RateLimitIP)
+	if err != nil {
+		return middlewares.RateLimitConfig{}, err
+	}
+	return middlewares.RateLimitConfig{
+		Default: middlewares.RateLimitPolicy{Limit: config.Env.RateLimitCount, Window: config.Env.RateLimitWindow},
+		Routes:  routes,
+		Callers: callers,
+		IP:      ip,
+	}, nil
+}
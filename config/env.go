@@ -4,10 +4,25 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+const defaultBatchMaxItems = 100
+const defaultImportMaxRows = 10000
+const defaultIdempotencyTTL = 24 * time.Hour
+const defaultIdempotencyLockTimeout = 5 * time.Minute
+const defaultJWTLeeway = 30 * time.Second
+const defaultJWTTokenTTL = time.Hour
+const defaultRateLimitCount = 100
+const defaultRateLimitWindow = time.Minute
+const defaultRateLimitIP = "1000/1m"
+const defaultProductCacheSize = 10000
+const defaultProductCacheTTL = time.Minute
+const defaultProductHTTPCacheControl = "private, no-cache"
+
 type EnvConfig struct {
 	DBHost         string
 	DBPort         string
@@ -17,6 +32,52 @@ type EnvConfig struct {
 	ApiPort        string
 	Mode           string
 	RateLimitCount int
+	CursorSecret   string
+	BatchMaxItems  int
+	ImportMaxRows  int
+	IdempotencyTTL time.Duration
+	// IdempotencyLockTimeout is how long a request holds its idempotency key
+	// before a retry may take it over, should the request never finish.
+	IdempotencyLockTimeout time.Duration
+	// A bearer token is accepted if it is signed with JWTSecret (HS256), the
+	// PEM public key in JWTPublicKeyFile or a key of the JWKS in JWTJWKSFile.
+	JWTSecret        string
+	JWTPublicKeyFile string
+	JWTJWKSFile      string
+	JWTIssuer        string
+	JWTAudience      string
+	JWTLeeway        time.Duration
+	// JWTTokenTTL is how long the tokens users log in for stay valid. They are
+	// signed with JWTSecret, so logging in needs it.
+	JWTTokenTTL time.Duration
+	// TenantBaseDomain lets requests to <tenant>.<TenantBaseDomain> pick their
+	// tenant by subdomain. Subdomains are ignored when it is empty.
+	TenantBaseDomain string
+	// Each caller may make RateLimitCount requests per RateLimitWindow, unless
+	// RateLimitRoutes or RateLimitCallers, lists of <name>=<limit>/<window>,
+	// give its route or itself another policy. RateLimitStore is memory, or
+	// postgres for instances that share their limits. RateLimitIP limits
+	// every client IP before authentication, whoever it turns out to be.
+	RateLimitWindow  time.Duration
+	RateLimitRoutes  string
+	RateLimitCallers string
+	RateLimitStore   string
+	RateLimitIP      string
+	// TrustedProxies lists the addresses or CIDRs of the proxies whose
+	// X-Forwarded-For tells the client IP. Without any, the client IP is the
+	// address the request came from.
+	TrustedProxies []string
+	// ProductCacheStore is none, which reads every product from the database,
+	// or memory, which keeps up to ProductCacheSize products read by ID for
+	// ProductCacheTTL.
+	ProductCacheStore string
+	ProductCacheSize  int
+	ProductCacheTTL   time.Duration
+	// ProductHTTPCacheControl and ProductListHTTPCacheControl are the
+	// Cache-Control headers of GET /products/{id} and of the product
+	// listings. The default lets clients keep reads if they revalidate them.
+	ProductHTTPCacheControl     string
+	ProductListHTTPCacheControl string
 }
 
 var Env *EnvConfig
@@ -26,7 +87,76 @@ func LoadEnv() {
 	if err != nil {
 		log.Println("⚠️  No .env file found, using system env variables")
 	}
-	rateLimitCount, _ := strconv.Atoi(os.Getenv("RATE_LIMIT_COUNT"))
+	rateLimitCount, err := strconv.Atoi(os.Getenv("RATE_LIMIT_COUNT"))
+	if err != nil || rateLimitCount <= 0 {
+		rateLimitCount = defaultRateLimitCount
+	}
+	rateLimitWindow, err := time.ParseDuration(os.Getenv("RATE_LIMIT_WINDOW"))
+	if err != nil || rateLimitWindow < time.Second {
+		rateLimitWindow = defaultRateLimitWindow
+	}
+	rateLimitStore := os.Getenv("RATE_LIMIT_STORE")
+	if rateLimitStore == "" {
+		rateLimitStore = "memory"
+	}
+	rateLimitIP := os.Getenv("RATE_LIMIT_IP")
+	if rateLimitIP == "" {
+		rateLimitIP = defaultRateLimitIP
+	}
+	var trustedProxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
+	}
+	productCacheStore := os.Getenv("PRODUCT_CACHE_STORE")
+	if productCacheStore == "" {
+		productCacheStore = "none"
+	}
+	productCacheSize, err := strconv.Atoi(os.Getenv("PRODUCT_CACHE_SIZE"))
+	if err != nil || productCacheSize <= 0 {
+		productCacheSize = defaultProductCacheSize
+	}
+	productCacheTTL, err := time.ParseDuration(os.Getenv("PRODUCT_CACHE_TTL"))
+	if err != nil || productCacheTTL <= 0 {
+		productCacheTTL = defaultProductCacheTTL
+	}
+	productHTTPCacheControl, ok := os.LookupEnv("PRODUCT_HTTP_CACHE_CONTROL")
+	if !ok {
+		productHTTPCacheControl = defaultProductHTTPCacheControl
+	}
+	productListHTTPCacheControl, ok := os.LookupEnv("PRODUCT_LIST_HTTP_CACHE_CONTROL")
+	if !ok {
+		productListHTTPCacheControl = defaultProductHTTPCacheControl
+	}
+	batchMaxItems, err := strconv.Atoi(os.Getenv("BATCH_MAX_ITEMS"))
+	if err != nil || batchMaxItems <= 0 {
+		batchMaxItems = defaultBatchMaxItems
+	}
+	importMaxRows, err := strconv.Atoi(os.Getenv("IMPORT_MAX_ROWS"))
+	if err != nil || importMaxRows <= 0 {
+		importMaxRows = defaultImportMaxRows
+	}
+	idempotencyTTL, err := time.ParseDuration(os.Getenv("IDEMPOTENCY_TTL"))
+	if err != nil || idempotencyTTL <= 0 {
+		idempotencyTTL = defaultIdempotencyTTL
+	}
+	idempotencyLockTimeout, err := time.ParseDuration(os.Getenv("IDEMPOTENCY_LOCK_TIMEOUT"))
+	if err != nil || idempotencyLockTimeout <= 0 {
+		idempotencyLockTimeout = defaultIdempotencyLockTimeout
+	}
+	jwtLeeway, err := time.ParseDuration(os.Getenv("JWT_LEEWAY"))
+	if err != nil || jwtLeeway < 0 {
+		jwtLeeway = defaultJWTLeeway
+	}
+	jwtTokenTTL, err := time.ParseDuration(os.Getenv("JWT_TOKEN_TTL"))
+	if err != nil || jwtTokenTTL <= 0 {
+		jwtTokenTTL = defaultJWTTokenTTL
+	}
+	cursorSecret := os.Getenv("CURSOR_SECRET")
+	if cursorSecret == "" {
+		log.Println("⚠️  No CURSOR_SECRET set, pagination cursors will not survive a restart")
+	}
 
 	Env = &EnvConfig{
 		DBHost:         os.Getenv("DB_HOST"),
@@ -37,5 +167,35 @@ func LoadEnv() {
 		ApiPort:        os.Getenv("API_PORT"),
 		Mode:           os.Getenv("MODE"),
 		RateLimitCount: rateLimitCount,
+		CursorSecret:   cursorSecret,
+		BatchMaxItems:  batchMaxItems,
+		ImportMaxRows:  importMaxRows,
+		IdempotencyTTL: idempotencyTTL,
+
+		IdempotencyLockTimeout: idempotencyLockTimeout,
+
+		JWTSecret:        os.Getenv("JWT_SECRET"),
+		JWTPublicKeyFile: os.Getenv("JWT_PUBLIC_KEY_FILE"),
+		JWTJWKSFile:      os.Getenv("JWT_JWKS_FILE"),
+		JWTIssuer:        os.Getenv("JWT_ISSUER"),
+		JWTAudience:      os.Getenv("JWT_AUDIENCE"),
+		JWTLeeway:        jwtLeeway,
+		JWTTokenTTL:      jwtTokenTTL,
+
+		TenantBaseDomain: os.Getenv("TENANT_BASE_DOMAIN"),
+
+		RateLimitWindow:  rateLimitWindow,
+		RateLimitRoutes:  os.Getenv("RATE_LIMIT_ROUTES"),
+		RateLimitCallers: os.Getenv("RATE_LIMIT_CALLERS"),
+		RateLimitStore:   rateLimitStore,
+		RateLimitIP:      rateLimitIP,
+		TrustedProxies:   trustedProxies,
+
+		ProductCacheStore: productCacheStore,
+		ProductCacheSize:  productCacheSize,
+		ProductCacheTTL:   productCacheTTL,
+
+		ProductHTTPCacheControl:     productHTTPCacheControl,
+		ProductListHTTPCacheControl: productListHTTPCacheControl,
 	}
 }
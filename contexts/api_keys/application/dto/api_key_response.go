@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+	"github.com/google/uuid"
+)
+
+type APIKeyResponse struct {
+	ID uuid.UUID `json:"id"`
+	// Prefix is the start of the key, to tell keys apart.
+	Prefix     string     `json:"prefix"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  string     `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	// Key is only returned when the key is issued or rotated.
+	Key string `json:"key,omitempty"`
+}
+
+func NewAPIKeyResponseFromDomainModel(apiKey models.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         apiKey.ID(),
+		Prefix:     apiKey.Prefix(),
+		Name:       apiKey.Name(),
+		Scopes:     apiKey.Scopes(),
+		CreatedBy:  apiKey.CreatedBy(),
+		CreatedAt:  apiKey.CreatedAt(),
+		ExpiresAt:  apiKey.ExpiresAt(),
+		LastUsedAt: apiKey.LastUsedAt(),
+		RevokedAt:  apiKey.RevokedAt(),
+	}
+}
+
+func NewIssuedAPIKeyResponseFromDomainModel(apiKey models.APIKey, plaintext string) APIKeyResponse {
+	response := NewAPIKeyResponseFromDomainModel(apiKey)
+	response.Key = plaintext
+	return response
+}
@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+type IssueAPIKeyRequest struct {
+	Name string `json:"name" binding:"required"`
+	// Scopes are the permissions the key grants, e.g. products:read.
+	Scopes []string `json:"scopes" binding:"required,min=1"`
+	// ExpiresAt is optional; keys without it stay valid until revoked.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,11 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+)
+
+type AuthenticateAPIKeyUseCasePort interface {
+	Execute(ctx context.Context, key string) (request_context.Principal, error)
+}
@@ -0,0 +1,11 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+)
+
+type GetAllAPIKeysUseCasePort interface {
+	Execute(ctx context.Context) ([]models.APIKey, error)
+}
@@ -0,0 +1,12 @@
+package inbound
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+)
+
+type IssueAPIKeyUseCasePort interface {
+	Execute(ctx context.Context, name string, scopes []string, expiresAt *time.Time) (models.APIKey, string, error)
+}
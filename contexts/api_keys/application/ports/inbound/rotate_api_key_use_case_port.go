@@ -0,0 +1,12 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+	"github.com/google/uuid"
+)
+
+type RotateAPIKeyUseCasePort interface {
+	Execute(ctx context.Context, id uuid.UUID) (models.APIKey, string, error)
+}
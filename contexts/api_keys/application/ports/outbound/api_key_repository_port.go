@@ -0,0 +1,18 @@
+package outbound
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+	"github.com/google/uuid"
+)
+
+type APIKeyRepositoryPort interface {
+	Create(ctx context.Context, apiKey models.APIKey) error
+	GetByID(ctx context.Context, id uuid.UUID) (models.APIKey, error)
+	GetByHash(ctx context.Context, hash string) (models.APIKey, error)
+	GetAll(ctx context.Context) ([]models.APIKey, error)
+	Update(ctx context.Context, apiKey models.APIKey) error
+	RecordUse(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
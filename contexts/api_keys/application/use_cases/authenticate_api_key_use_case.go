@@ -0,0 +1,59 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+)
+
+// SubjectPrefix starts the subject of principals authenticated by an API key,
+// so they are told apart from token subjects in the product history.
+const SubjectPrefix = "api-key:"
+
+type AuthenticateAPIKeyUseCase struct {
+	repo outbound.APIKeyRepositoryPort
+}
+
+func NewAuthenticateAPIKeyUseCase(repo outbound.APIKeyRepositoryPort) *AuthenticateAPIKeyUseCase {
+	return &AuthenticateAPIKeyUseCase{
+		repo: repo,
+	}
+}
+
+// Execute returns the principal of an active key, whose permissions are the
+// scopes of the key and whose tenant is the key's, and records that the key
+// was used. Unknown, expired and
+// revoked keys all fail with ErrInvalidAPIKey.
+func (uc *AuthenticateAPIKeyUseCase) Execute(ctx context.Context, key string) (request_context.Principal, error) {
+	apiKey, err := uc.repo.GetByHash(ctx, models.HashKey(key))
+	if err != nil {
+		return request_context.Principal{}, err
+	}
+	now := time.Now().UTC()
+	if apiKey == nil || !apiKey.IsActive(now) {
+		return request_context.Principal{}, shared_handlers.ErrInvalidAPIKey
+	}
+	if apiKey.ShouldRecordUse(now) {
+		if err := uc.repo.RecordUse(ctx, apiKey.ID(), now); err != nil {
+			return request_context.Principal{}, err
+		}
+	}
+
+	principal := request_context.Principal{
+		Subject: SubjectPrefix + apiKey.ID().String(),
+		Scopes:  apiKey.Scopes(),
+		Claims: map[string]interface{}{
+			"api_key_id":                apiKey.ID().String(),
+			"api_key_name":              apiKey.Name(),
+			request_context.TenantClaim: apiKey.Tenant(),
+		},
+	}
+	if expiresAt := apiKey.ExpiresAt(); expiresAt != nil {
+		principal.ExpiresAt = *expiresAt
+	}
+	return principal, nil
+}
@@ -0,0 +1,27 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+)
+
+type GetAllAPIKeysUseCase struct {
+	repo outbound.APIKeyRepositoryPort
+}
+
+func NewGetAllAPIKeysUseCase(repo outbound.APIKeyRepositoryPort) *GetAllAPIKeysUseCase {
+	return &GetAllAPIKeysUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *GetAllAPIKeysUseCase) Execute(ctx context.Context) ([]models.APIKey, error) {
+	if err := authorization.Authorize(ctx, shared_models.PermissionAPIKeysManage); err != nil {
+		return nil, err
+	}
+	return uc.repo.GetAll(ctx)
+}
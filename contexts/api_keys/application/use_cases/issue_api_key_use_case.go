@@ -0,0 +1,54 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/google/uuid"
+)
+
+type IssueAPIKeyUseCase struct {
+	repo outbound.APIKeyRepositoryPort
+}
+
+func NewIssueAPIKeyUseCase(repo outbound.APIKeyRepositoryPort) *IssueAPIKeyUseCase {
+	return &IssueAPIKeyUseCase{
+		repo: repo,
+	}
+}
+
+// Execute issues a key on behalf of the caller, bound to the tenant of the
+// request, and returns it with its plaintext key, which is not stored. The
+// caller must hold every scope the key is to grant.
+func (uc *IssueAPIKeyUseCase) Execute(ctx context.Context, name string, scopes []string, expiresAt *time.Time) (models.APIKey, string, error) {
+	if err := authorization.Authorize(ctx, shared_models.PermissionAPIKeysManage); err != nil {
+		return nil, "", err
+	}
+	apiKey, plaintext, err := models.IssueAPIKey(uuid.New(), request_context.TenantFrom(ctx), name, scopes, expiresAt, request_context.ActorFrom(ctx))
+	if err != nil {
+		return nil, "", err
+	}
+	if err := authorizeScopes(ctx, apiKey.Scopes()); err != nil {
+		return nil, "", err
+	}
+	if err := uc.repo.Create(ctx, apiKey); err != nil {
+		return nil, "", err
+	}
+	return apiKey, plaintext, nil
+}
+
+// authorizeScopes fails unless the caller holds every one of scopes, so that
+// nobody can hand out a key allowing more than they are allowed themselves.
+func authorizeScopes(ctx context.Context, scopes []string) error {
+	for _, scope := range scopes {
+		if err := authorization.Authorize(ctx, shared_models.Permission(scope)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/google/uuid"
+)
+
+type RevokeAPIKeyUseCase struct {
+	repo outbound.APIKeyRepositoryPort
+}
+
+func NewRevokeAPIKeyUseCase(repo outbound.APIKeyRepositoryPort) *RevokeAPIKeyUseCase {
+	return &RevokeAPIKeyUseCase{
+		repo: repo,
+	}
+}
+
+// Execute disables the key. The key is kept so it is still listed with the
+// time it was revoked.
+func (uc *RevokeAPIKeyUseCase) Execute(ctx context.Context, id uuid.UUID) error {
+	if err := authorization.Authorize(ctx, shared_models.PermissionAPIKeysManage); err != nil {
+		return err
+	}
+	apiKey, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if apiKey == nil {
+		return shared_handlers.ErrNotFound
+	}
+	apiKey.Revoke(time.Now().UTC())
+	return uc.repo.Update(ctx, apiKey)
+}
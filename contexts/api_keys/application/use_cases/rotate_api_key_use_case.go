@@ -0,0 +1,48 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/google/uuid"
+)
+
+type RotateAPIKeyUseCase struct {
+	repo outbound.APIKeyRepositoryPort
+}
+
+func NewRotateAPIKeyUseCase(repo outbound.APIKeyRepositoryPort) *RotateAPIKeyUseCase {
+	return &RotateAPIKeyUseCase{
+		repo: repo,
+	}
+}
+
+// Execute replaces the key and returns the new plaintext key. The previous key
+// stops working straight away. The caller must hold every scope of the key.
+func (uc *RotateAPIKeyUseCase) Execute(ctx context.Context, id uuid.UUID) (models.APIKey, string, error) {
+	if err := authorization.Authorize(ctx, shared_models.PermissionAPIKeysManage); err != nil {
+		return nil, "", err
+	}
+	apiKey, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if apiKey == nil {
+		return nil, "", shared_handlers.ErrNotFound
+	}
+	if err := authorizeScopes(ctx, apiKey.Scopes()); err != nil {
+		return nil, "", err
+	}
+	plaintext, err := apiKey.Rotate()
+	if err != nil {
+		return nil, "", err
+	}
+	if err := uc.repo.Update(ctx, apiKey); err != nil {
+		return nil, "", err
+	}
+	return apiKey, plaintext, nil
+}
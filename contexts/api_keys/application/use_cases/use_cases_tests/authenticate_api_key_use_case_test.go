@@ -0,0 +1,80 @@
+package use_cases_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models/models_mothers"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticateAPIKeyUseCase(t *testing.T) {
+	t.Run("should return a principal holding the key's scopes and record the use", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockAPIKeyRepository()
+		apiKey := models_mothers.NewAPIKeyMother().WithTenant("acme").WithScopes("products:read", "products:write").MustBuild()
+		repo.SetupGetByHashSuccess(models_mothers.DefaultAPIKey, apiKey)
+		repo.On("RecordUse", mock.Anything, apiKey.ID(), mock.AnythingOfType("time.Time")).Return(nil).Once()
+		useCase := use_cases.NewAuthenticateAPIKeyUseCase(repo)
+
+		// Act
+		principal, err := useCase.Execute(context.Background(), models_mothers.DefaultAPIKey)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, use_cases.SubjectPrefix+apiKey.ID().String(), principal.Subject)
+		assert.Equal(t, "acme", principal.Tenant())
+		assert.True(t, principal.HasPermission(shared_models.PermissionProductsWrite))
+		assert.False(t, principal.HasPermission(shared_models.PermissionProductsDelete))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should not record a use made shortly after the last one", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockAPIKeyRepository()
+		apiKey := models_mothers.NewAPIKeyMother().WithLastUsedAt(time.Now().UTC()).MustBuild()
+		repo.SetupGetByHashSuccess(models_mothers.DefaultAPIKey, apiKey)
+		useCase := use_cases.NewAuthenticateAPIKeyUseCase(repo)
+
+		// Act
+		_, err := useCase.Execute(context.Background(), models_mothers.DefaultAPIKey)
+
+		// Assert
+		require.NoError(t, err)
+		repo.AssertNotCalled(t, "RecordUse", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject unknown, expired and revoked keys", func(t *testing.T) {
+		cases := map[string]*models_mothers.APIKeyMother{
+			"unknown": nil,
+			"expired": models_mothers.NewAPIKeyMother().WithExpiresAt(time.Now().Add(-time.Minute)),
+			"revoked": models_mothers.NewAPIKeyMother().WithRevokedAt(time.Now().Add(-time.Minute)),
+		}
+		for name, mother := range cases {
+			t.Run(name, func(t *testing.T) {
+				// Arrange
+				repo := use_cases_mocks.NewMockAPIKeyRepository()
+				if mother == nil {
+					repo.On("GetByHash", mock.Anything, mock.Anything).Return(nil, nil)
+				} else {
+					repo.SetupGetByHashSuccess(models_mothers.DefaultAPIKey, mother.MustBuild())
+				}
+				useCase := use_cases.NewAuthenticateAPIKeyUseCase(repo)
+
+				// Act
+				_, err := useCase.Execute(context.Background(), models_mothers.DefaultAPIKey)
+
+				// Assert
+				assert.ErrorIs(t, err, shared_handlers.ErrInvalidAPIKey)
+				repo.AssertNotCalled(t, "RecordUse", mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package use_cases_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func contextWithRole(role shared_models.Role) context.Context {
+	return request_context.WithPrincipal(context.Background(), request_context.Principal{
+		Subject: "admin",
+		Roles:   []string{string(role)},
+	})
+}
+
+// contextWithScopes returns the context of a caller who holds scopes only.
+func contextWithScopes(scopes ...shared_models.Permission) context.Context {
+	principal := request_context.Principal{Subject: "key-manager"}
+	for _, scope := range scopes {
+		principal.Scopes = append(principal.Scopes, string(scope))
+	}
+	return request_context.WithPrincipal(context.Background(), principal)
+}
+
+func TestIssueAPIKeyUseCase(t *testing.T) {
+	t.Run("should store the key issued by the caller", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockAPIKeyRepository()
+		repo.On("Create", mock.Anything, mock.MatchedBy(func(apiKey models.APIKey) bool {
+			return apiKey.Name() == "nightly-sync" && apiKey.CreatedBy() == "admin"
+		})).Return(nil).Once()
+		useCase := use_cases.NewIssueAPIKeyUseCase(repo)
+
+		// Act
+		apiKey, plaintext, err := useCase.Execute(contextWithRole(shared_models.RoleAdmin), "nightly-sync", []string{"products:read"}, nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, models.HashKey(plaintext), apiKey.Hash())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should only let admins issue keys", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockAPIKeyRepository()
+		useCase := use_cases.NewIssueAPIKeyUseCase(repo)
+
+		// Act
+		_, _, err := useCase.Execute(contextWithRole(shared_models.RoleEditor), "nightly-sync", []string{"products:read"}, nil)
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrForbidden)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should refuse scopes the caller does not hold", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockAPIKeyRepository()
+		useCase := use_cases.NewIssueAPIKeyUseCase(repo)
+		ctx := contextWithScopes(shared_models.PermissionAPIKeysManage, shared_models.PermissionProductsRead)
+
+		// Act
+		_, _, err := useCase.Execute(ctx, "nightly-sync", []string{"products:read", "products:delete"}, nil)
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrForbidden)
+		var infraErr shared_handlers.InfraError
+		require.ErrorAs(t, err, &infraErr)
+		assert.Equal(t, map[string]interface{}{"required_permission": shared_models.PermissionProductsDelete}, infraErr.Details)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRotateAPIKeyUseCase(t *testing.T) {
+	t.Run("should store the new hash and return the new key", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockAPIKeyRepository()
+		stored := models_mothers.NewAPIKeyMother().MustBuild()
+		repo.SetupGetByIDSuccess(stored.ID(), stored)
+		repo.On("Update", mock.Anything, stored).Return(nil).Once()
+		useCase := use_cases.NewRotateAPIKeyUseCase(repo)
+
+		// Act
+		apiKey, plaintext, err := useCase.Execute(contextWithRole(shared_models.RoleAdmin), stored.ID())
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotEqual(t, models_mothers.DefaultAPIKey, plaintext)
+		assert.Equal(t, models.HashKey(plaintext), apiKey.Hash())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should refuse to rotate a key granting scopes the caller does not hold", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockAPIKeyRepository()
+		stored := models_mothers.NewAPIKeyMother().MustBuild()
+		repo.SetupGetByIDSuccess(stored.ID(), stored)
+		useCase := use_cases.NewRotateAPIKeyUseCase(repo)
+
+		// Act
+		_, _, err := useCase.Execute(contextWithScopes(shared_models.PermissionAPIKeysManage), stored.ID())
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrForbidden)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should fail with not found for an unknown key", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockAPIKeyRepository()
+		stored := models_mothers.NewAPIKeyMother().MustBuild()
+		repo.On("GetByID", mock.Anything, stored.ID()).Return(nil, nil)
+		useCase := use_cases.NewRotateAPIKeyUseCase(repo)
+
+		// Act
+		_, _, err := useCase.Execute(contextWithRole(shared_models.RoleAdmin), stored.ID())
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrNotFound)
+	})
+
+	t.Run("should refuse to rotate a revoked key", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockAPIKeyRepository()
+		stored := models_mothers.NewAPIKeyMother().WithRevokedAt(time.Now()).MustBuild()
+		repo.SetupGetByIDSuccess(stored.ID(), stored)
+		useCase := use_cases.NewRotateAPIKeyUseCase(repo)
+
+		// Act
+		_, _, err := useCase.Execute(contextWithRole(shared_models.RoleAdmin), stored.ID())
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAPIKeyRevoked)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRevokeAPIKeyUseCase(t *testing.T) {
+	t.Run("should store the key as revoked", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockAPIKeyRepository()
+		stored := models_mothers.NewAPIKeyMother().MustBuild()
+		repo.SetupGetByIDSuccess(stored.ID(), stored)
+		repo.On("Update", mock.Anything, mock.MatchedBy(func(apiKey models.APIKey) bool {
+			return apiKey.RevokedAt() != nil
+		})).Return(nil).Once()
+		useCase := use_cases.NewRevokeAPIKeyUseCase(repo)
+
+		// Act
+		err := useCase.Execute(contextWithRole(shared_models.RoleAdmin), stored.ID())
+
+		// Assert
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,279 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/google/uuid"
+)
+
+// KeyPrefix starts every API key, so leaked keys are easy to recognise.
+const KeyPrefix = "gta_"
+
+// displayPrefixLength is how much of a key is kept in clear to tell keys
+// apart in listings.
+const displayPrefixLength = len(KeyPrefix) + 8
+
+// RecordUseInterval is how often the last use of a key is written, so a busy
+// client does not cause a write per request.
+const RecordUseInterval = time.Minute
+
+var (
+	ErrAPIKeyIdNil = shared_models.DomainError{
+		Code:    "API_KEY_ID_NIL",
+		Message: "API key ID cannot be nil",
+	}
+
+	ErrAPIKeyNameEmpty = shared_models.DomainError{
+		Code:    "API_KEY_NAME_EMPTY",
+		Message: "API key name cannot be empty",
+	}
+
+	ErrAPIKeyScopesInvalid = shared_models.DomainError{
+		Code:    "API_KEY_SCOPES_INVALID",
+		Message: "API key needs at least one scope, each a known permission",
+	}
+
+	ErrAPIKeyExpiryInPast = shared_models.DomainError{
+		Code:    "API_KEY_EXPIRY_IN_PAST",
+		Message: "API key expiry must be in the future",
+	}
+
+	ErrAPIKeyRevoked = shared_models.DomainError{
+		Code:    "API_KEY_REVOKED",
+		Message: "A revoked API key cannot be rotated",
+	}
+)
+
+// APIKey is a credential for server-to-server clients. Only a hash of the key
+// is kept; the key itself is returned once, when it is issued or rotated.
+type APIKey interface {
+	ID() uuid.UUID
+	Tenant() string
+	Name() string
+	Prefix() string
+	Hash() string
+	Scopes() []string
+	CreatedBy() string
+	CreatedAt() time.Time
+	ExpiresAt() *time.Time
+	LastUsedAt() *time.Time
+	RevokedAt() *time.Time
+	IsActive(now time.Time) bool
+	ShouldRecordUse(now time.Time) bool
+	Rotate() (string, error)
+	Revoke(now time.Time)
+}
+
+type apiKey struct {
+	id         uuid.UUID
+	tenant     string
+	name       string
+	prefix     string
+	hash       string
+	scopes     []string
+	createdBy  string
+	createdAt  time.Time
+	expiresAt  *time.Time
+	lastUsedAt *time.Time
+	revokedAt  *time.Time
+}
+
+// APIKeyMetadata holds the fields an API key only gets once it is stored.
+type APIKeyMetadata struct {
+	Tenant     string
+	CreatedBy  string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// IssueAPIKey creates a key with the given scopes in the catalogue of tenant
+// and returns it together with the plaintext key, which cannot be recovered
+// later.
+func IssueAPIKey(id uuid.UUID, tenant, name string, scopes []string, expiresAt *time.Time, createdBy string) (APIKey, string, error) {
+	now := time.Now().UTC()
+	if id == uuid.Nil {
+		return nil, "", ErrAPIKeyIdNil
+	}
+	if err := validateAPIKey(name, scopes, expiresAt, now); err != nil {
+		return nil, "", err
+	}
+	plaintext, err := generateKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &apiKey{
+		id:        id,
+		tenant:    tenant,
+		name:      name,
+		prefix:    plaintext[:displayPrefixLength],
+		hash:      HashKey(plaintext),
+		scopes:    slices.Clone(scopes),
+		createdBy: createdBy,
+		createdAt: now,
+		expiresAt: expiresAt,
+	}, plaintext, nil
+}
+
+// ReconstituteAPIKey rebuilds a stored key. Its expiry is not checked, since
+// an expired key is still listed.
+func ReconstituteAPIKey(id uuid.UUID, name, prefix, hash string, scopes []string, expiresAt *time.Time, metadata APIKeyMetadata) (APIKey, error) {
+	if id == uuid.Nil {
+		return nil, ErrAPIKeyIdNil
+	}
+	if err := validateAPIKey(name, scopes, nil, metadata.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &apiKey{
+		id:         id,
+		tenant:     metadata.Tenant,
+		name:       name,
+		prefix:     prefix,
+		hash:       hash,
+		scopes:     slices.Clone(scopes),
+		createdBy:  metadata.CreatedBy,
+		createdAt:  metadata.CreatedAt,
+		expiresAt:  expiresAt,
+		lastUsedAt: metadata.LastUsedAt,
+		revokedAt:  metadata.RevokedAt,
+	}, nil
+}
+
+// validateAPIKey checks every field and returns the error of the first
+// invalid one, with all the invalid fields in its details.
+func validateAPIKey(name string, scopes []string, expiresAt *time.Time, now time.Time) error {
+	var first *shared_models.DomainError
+	var violations []shared_models.FieldViolation
+	reject := func(err shared_models.DomainError, field, rule string, value interface{}) {
+		if first == nil {
+			first = &err
+		}
+		violations = append(violations, shared_models.FieldViolation{
+			Field:   field,
+			Rule:    rule,
+			Value:   value,
+			Message: err.Message,
+		})
+	}
+
+	if strings.TrimSpace(name) == "" {
+		reject(ErrAPIKeyNameEmpty, "name", "required", name)
+	}
+	if len(scopes) == 0 || slices.ContainsFunc(scopes, func(scope string) bool {
+		return !shared_models.Permission(scope).IsKnown()
+	}) {
+		reject(ErrAPIKeyScopesInvalid, "scopes", "oneof", scopes)
+	}
+	if expiresAt != nil && !expiresAt.After(now) {
+		reject(ErrAPIKeyExpiryInPast, "expires_at", "future", expiresAt)
+	}
+	if first == nil {
+		return nil
+	}
+	return first.WithFieldViolations(violations...)
+}
+
+// HashKey returns the hash API keys are stored and looked up by. Keys are
+// random, so a fast hash is enough.
+func HashKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateKey() (string, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return KeyPrefix + hex.EncodeToString(secret), nil
+}
+
+func (k *apiKey) ID() uuid.UUID {
+	return k.id
+}
+
+// Tenant is the only tenant whose catalogue the key gives access to.
+func (k *apiKey) Tenant() string {
+	return k.tenant
+}
+
+func (k *apiKey) Name() string {
+	return k.name
+}
+
+func (k *apiKey) Prefix() string {
+	return k.prefix
+}
+
+func (k *apiKey) Hash() string {
+	return k.hash
+}
+
+func (k *apiKey) Scopes() []string {
+	return slices.Clone(k.scopes)
+}
+
+func (k *apiKey) CreatedBy() string {
+	return k.createdBy
+}
+
+func (k *apiKey) CreatedAt() time.Time {
+	return k.createdAt
+}
+
+func (k *apiKey) ExpiresAt() *time.Time {
+	return k.expiresAt
+}
+
+func (k *apiKey) LastUsedAt() *time.Time {
+	return k.lastUsedAt
+}
+
+func (k *apiKey) RevokedAt() *time.Time {
+	return k.revokedAt
+}
+
+// IsActive reports whether the key may still authenticate requests.
+func (k *apiKey) IsActive(now time.Time) bool {
+	return k.revokedAt == nil && (k.expiresAt == nil || now.Before(*k.expiresAt))
+}
+
+// ShouldRecordUse reports whether a use at now is worth storing, and if so
+// keeps it as the last use.
+func (k *apiKey) ShouldRecordUse(now time.Time) bool {
+	if k.lastUsedAt != nil && now.Sub(*k.lastUsedAt) < RecordUseInterval {
+		return false
+	}
+	k.lastUsedAt = &now
+	return true
+}
+
+// Rotate replaces the key, which stops the previous one from working, and
+// returns the new plaintext key.
+func (k *apiKey) Rotate() (string, error) {
+	if k.revokedAt != nil {
+		return "", ErrAPIKeyRevoked
+	}
+	plaintext, err := generateKey()
+	if err != nil {
+		return "", err
+	}
+	k.prefix = plaintext[:displayPrefixLength]
+	k.hash = HashKey(plaintext)
+	return plaintext, nil
+}
+
+// Revoke disables the key for good. Revoking it again keeps the first time.
+func (k *apiKey) Revoke(now time.Time) {
+	if k.revokedAt == nil {
+		k.revokedAt = &now
+	}
+}
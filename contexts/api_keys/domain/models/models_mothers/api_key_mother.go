@@ -0,0 +1,89 @@
+package models_mothers
+
+import (
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+	"github.com/google/uuid"
+)
+
+// DefaultAPIKey is the plaintext key APIKeyMother keys are built from.
+const DefaultAPIKey = models.KeyPrefix + "0123456789abcdef0123456789abcdef0123456789abcdef"
+
+type APIKeyMother struct {
+	Id         uuid.UUID
+	Tenant     string
+	Name       string
+	Key        string
+	Scopes     []string
+	CreatedBy  string
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+func NewAPIKeyMother() *APIKeyMother {
+	return &APIKeyMother{
+		Id:        uuid.New(),
+		Tenant:    "default",
+		Name:      "nightly-sync",
+		Key:       DefaultAPIKey,
+		Scopes:    []string{"products:read"},
+		CreatedBy: "admin",
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (am *APIKeyMother) WithID(id uuid.UUID) *APIKeyMother {
+	am.Id = id
+	return am
+}
+
+func (am *APIKeyMother) WithTenant(tenant string) *APIKeyMother {
+	am.Tenant = tenant
+	return am
+}
+
+func (am *APIKeyMother) WithName(name string) *APIKeyMother {
+	am.Name = name
+	return am
+}
+
+func (am *APIKeyMother) WithScopes(scopes ...string) *APIKeyMother {
+	am.Scopes = scopes
+	return am
+}
+
+func (am *APIKeyMother) WithExpiresAt(expiresAt time.Time) *APIKeyMother {
+	am.ExpiresAt = &expiresAt
+	return am
+}
+
+func (am *APIKeyMother) WithLastUsedAt(lastUsedAt time.Time) *APIKeyMother {
+	am.LastUsedAt = &lastUsedAt
+	return am
+}
+
+func (am *APIKeyMother) WithRevokedAt(revokedAt time.Time) *APIKeyMother {
+	am.RevokedAt = &revokedAt
+	return am
+}
+
+func (am *APIKeyMother) Build() (models.APIKey, error) {
+	return models.ReconstituteAPIKey(am.Id, am.Name, am.Key[:len(models.KeyPrefix)+8], models.HashKey(am.Key), am.Scopes, am.ExpiresAt, models.APIKeyMetadata{
+		Tenant:     am.Tenant,
+		CreatedBy:  am.CreatedBy,
+		CreatedAt:  am.CreatedAt,
+		LastUsedAt: am.LastUsedAt,
+		RevokedAt:  am.RevokedAt,
+	})
+}
+
+func (am *APIKeyMother) MustBuild() models.APIKey {
+	apiKey, err := am.Build()
+	if err != nil {
+		panic("APIKeyMother.MustBuild failed: " + err.Error())
+	}
+	return apiKey
+}
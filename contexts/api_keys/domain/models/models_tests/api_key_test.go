@@ -0,0 +1,137 @@
+package models_tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models/models_mothers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueAPIKey(t *testing.T) {
+	t.Run("should issue a key and keep only its hash", func(t *testing.T) {
+		// Arrange
+		id := uuid.New()
+		expiresAt := time.Now().Add(24 * time.Hour)
+
+		// Act
+		apiKey, plaintext, err := models.IssueAPIKey(id, "acme", "nightly-sync", []string{"products:read"}, &expiresAt, "admin")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, id, apiKey.ID())
+		assert.Equal(t, "acme", apiKey.Tenant())
+		assert.True(t, strings.HasPrefix(plaintext, models.KeyPrefix))
+		assert.True(t, strings.HasPrefix(plaintext, apiKey.Prefix()))
+		assert.Equal(t, models.HashKey(plaintext), apiKey.Hash())
+		assert.NotContains(t, apiKey.Hash(), plaintext)
+		assert.Equal(t, "admin", apiKey.CreatedBy())
+		assert.True(t, apiKey.IsActive(time.Now()))
+	})
+
+	t.Run("should reject invalid data", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour)
+		cases := map[string]struct {
+			id        uuid.UUID
+			name      string
+			scopes    []string
+			expiresAt *time.Time
+			err       error
+		}{
+			"nil id":         {uuid.Nil, "job", []string{"products:read"}, nil, models.ErrAPIKeyIdNil},
+			"empty name":     {uuid.New(), " ", []string{"products:read"}, nil, models.ErrAPIKeyNameEmpty},
+			"no scopes":      {uuid.New(), "job", nil, nil, models.ErrAPIKeyScopesInvalid},
+			"unknown scope":  {uuid.New(), "job", []string{"products:everything"}, nil, models.ErrAPIKeyScopesInvalid},
+			"expiry in past": {uuid.New(), "job", []string{"products:read"}, &past, models.ErrAPIKeyExpiryInPast},
+		}
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				// Act
+				apiKey, plaintext, err := models.IssueAPIKey(tc.id, "acme", tc.name, tc.scopes, tc.expiresAt, "admin")
+
+				// Assert
+				assert.Nil(t, apiKey)
+				assert.Empty(t, plaintext)
+				assert.ErrorIs(t, err, tc.err)
+			})
+		}
+	})
+}
+
+func TestAPIKeyIsActive(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should be inactive once expired", func(t *testing.T) {
+		// Arrange
+		apiKey := models_mothers.NewAPIKeyMother().WithExpiresAt(now.Add(-time.Second)).MustBuild()
+
+		// Act & Assert
+		assert.False(t, apiKey.IsActive(now))
+	})
+
+	t.Run("should be inactive once revoked", func(t *testing.T) {
+		// Arrange
+		apiKey := models_mothers.NewAPIKeyMother().MustBuild()
+
+		// Act
+		apiKey.Revoke(now)
+
+		// Assert
+		assert.False(t, apiKey.IsActive(now))
+		assert.Equal(t, now, *apiKey.RevokedAt())
+	})
+}
+
+func TestAPIKeyRotate(t *testing.T) {
+	t.Run("should replace the key", func(t *testing.T) {
+		// Arrange
+		apiKey := models_mothers.NewAPIKeyMother().MustBuild()
+
+		// Act
+		plaintext, err := apiKey.Rotate()
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotEqual(t, models_mothers.DefaultAPIKey, plaintext)
+		assert.Equal(t, models.HashKey(plaintext), apiKey.Hash())
+		assert.True(t, strings.HasPrefix(plaintext, apiKey.Prefix()))
+	})
+
+	t.Run("should refuse to rotate a revoked key", func(t *testing.T) {
+		// Arrange
+		apiKey := models_mothers.NewAPIKeyMother().WithRevokedAt(time.Now()).MustBuild()
+
+		// Act
+		_, err := apiKey.Rotate()
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrAPIKeyRevoked)
+		assert.Equal(t, models.HashKey(models_mothers.DefaultAPIKey), apiKey.Hash())
+	})
+}
+
+func TestAPIKeyShouldRecordUse(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should record the first use", func(t *testing.T) {
+		// Arrange
+		apiKey := models_mothers.NewAPIKeyMother().MustBuild()
+
+		// Act & Assert
+		assert.True(t, apiKey.ShouldRecordUse(now))
+		assert.Equal(t, now, *apiKey.LastUsedAt())
+	})
+
+	t.Run("should skip uses within the record interval", func(t *testing.T) {
+		// Arrange
+		apiKey := models_mothers.NewAPIKeyMother().WithLastUsedAt(now.Add(-time.Second)).MustBuild()
+
+		// Act & Assert
+		assert.False(t, apiKey.ShouldRecordUse(now))
+		assert.True(t, apiKey.ShouldRecordUse(now.Add(models.RecordUseInterval)))
+	})
+}
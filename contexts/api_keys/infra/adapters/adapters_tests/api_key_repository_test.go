@@ -0,0 +1,180 @@
+package adapters_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/infra/adapters"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgres_driver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepositoryTestSuite struct {
+	suite.Suite
+	container *postgres.PostgresContainer
+	db        *gorm.DB
+	repo      *adapters.APIKeyRepository
+	ctx       context.Context
+}
+
+func (suite *APIKeyRepositoryTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	container, err := postgres.Run(suite.ctx,
+		"postgres:15-alpine",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	connStr, err := container.ConnectionString(suite.ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	db, err := gorm.Open(postgres_driver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = db.AutoMigrate(&adapters.APIKeyEntity{})
+	suite.Require().NoError(err)
+
+	suite.repo = adapters.NewAPIKeyRepository(db)
+	suite.db = db
+}
+
+func (suite *APIKeyRepositoryTestSuite) TearDownSuite() {
+	if suite.container != nil {
+		suite.container.Terminate(suite.ctx)
+	}
+}
+
+func (suite *APIKeyRepositoryTestSuite) TearDownTest() {
+	if suite.db != nil {
+		suite.db.Exec("TRUNCATE TABLE api_key_entities")
+	}
+}
+
+func (suite *APIKeyRepositoryTestSuite) TestCreateAndGetByHash() {
+	suite.Run("should find an issued key by the hash of its plaintext", func() {
+		// Arrange
+		expiresAt := time.Now().Add(time.Hour)
+		apiKey, plaintext, err := models.IssueAPIKey(uuid.New(), "acme", "nightly-sync", []string{"products:read", "products:write"}, &expiresAt, "admin")
+		suite.Require().NoError(err)
+		suite.Require().NoError(suite.repo.Create(suite.ctx, apiKey))
+
+		// Act
+		found, err := suite.repo.GetByHash(request_context.WithTenant(suite.ctx, "globex"), models.HashKey(plaintext))
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().NotNil(found)
+		suite.Equal(apiKey.ID(), found.ID())
+		suite.Equal("acme", found.Tenant())
+		suite.Equal([]string{"products:read", "products:write"}, found.Scopes())
+		suite.Equal("admin", found.CreatedBy())
+		suite.WithinDuration(expiresAt, *found.ExpiresAt(), time.Millisecond)
+	})
+
+	suite.Run("should return nil for an unknown hash", func() {
+		// Act
+		found, err := suite.repo.GetByHash(suite.ctx, models.HashKey("gta_unknown"))
+
+		// Assert
+		suite.NoError(err)
+		suite.Nil(found)
+	})
+}
+
+func (suite *APIKeyRepositoryTestSuite) TestTenantIsolation() {
+	suite.Run("should hide the keys of other tenants from management", func() {
+		// Arrange
+		acme := request_context.WithTenant(suite.ctx, "acme")
+		globex := request_context.WithTenant(suite.ctx, "globex")
+		apiKey := models_mothers.NewAPIKeyMother().WithTenant("acme").MustBuild()
+		suite.Require().NoError(suite.repo.Create(acme, apiKey))
+		apiKey.Revoke(time.Now().UTC())
+
+		// Act
+		found, getErr := suite.repo.GetByID(globex, apiKey.ID())
+		all, listErr := suite.repo.GetAll(globex)
+		updateErr := suite.repo.Update(globex, apiKey)
+
+		// Assert
+		suite.NoError(getErr)
+		suite.Nil(found)
+		suite.NoError(listErr)
+		suite.Empty(all)
+		suite.ErrorIs(updateErr, shared_handlers.ErrNotFound)
+		stored, err := suite.repo.GetByID(acme, apiKey.ID())
+		suite.Require().NoError(err)
+		suite.Nil(stored.RevokedAt())
+	})
+}
+
+func (suite *APIKeyRepositoryTestSuite) TestUpdate() {
+	suite.Run("should store the rotated hash and the revocation", func() {
+		// Arrange
+		apiKey := models_mothers.NewAPIKeyMother().MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, apiKey))
+		plaintext, err := apiKey.Rotate()
+		suite.Require().NoError(err)
+		apiKey.Revoke(time.Now().UTC())
+
+		// Act
+		err = suite.repo.Update(suite.ctx, apiKey)
+
+		// Assert
+		suite.Require().NoError(err)
+		old, err := suite.repo.GetByHash(suite.ctx, models.HashKey(models_mothers.DefaultAPIKey))
+		suite.NoError(err)
+		suite.Nil(old)
+		stored, err := suite.repo.GetByHash(suite.ctx, models.HashKey(plaintext))
+		suite.Require().NoError(err)
+		suite.NotNil(stored.RevokedAt())
+	})
+
+	suite.Run("should fail with not found for an unknown key", func() {
+		// Act
+		err := suite.repo.Update(suite.ctx, models_mothers.NewAPIKeyMother().MustBuild())
+
+		// Assert
+		suite.ErrorIs(err, shared_handlers.ErrNotFound)
+	})
+}
+
+func (suite *APIKeyRepositoryTestSuite) TestRecordUse() {
+	suite.Run("should keep the latest use", func() {
+		// Arrange
+		apiKey := models_mothers.NewAPIKeyMother().MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, apiKey))
+		later := time.Now().UTC().Truncate(time.Microsecond)
+
+		// Act
+		suite.Require().NoError(suite.repo.RecordUse(suite.ctx, apiKey.ID(), later))
+		suite.Require().NoError(suite.repo.RecordUse(suite.ctx, apiKey.ID(), later.Add(-time.Hour)))
+
+		// Assert
+		stored, err := suite.repo.GetByID(suite.ctx, apiKey.ID())
+		suite.Require().NoError(err)
+		suite.Require().NotNil(stored.LastUsedAt())
+		suite.True(later.Equal(*stored.LastUsedAt()))
+	})
+}
+
+func TestAPIKeyRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyRepositoryTestSuite))
+}
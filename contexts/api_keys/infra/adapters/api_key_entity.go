@@ -0,0 +1,68 @@
+package adapters
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+	"github.com/google/uuid"
+)
+
+// APIKeyEntity stores the hash of a key, never the key itself. The hash is
+// unique so a presented key is found with a single indexed lookup, whatever
+// its tenant.
+type APIKeyEntity struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TenantID   string    `gorm:"not null;default:default;index"`
+	Name       string    `gorm:"not null"`
+	Prefix     string    `gorm:"not null"`
+	Hash       string    `gorm:"not null;uniqueIndex"`
+	Scopes     []byte    `gorm:"type:jsonb;not null"`
+	CreatedBy  string    `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"not null"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+func NewAPIKeyEntity(apiKey models.APIKey) (APIKeyEntity, error) {
+	scopes, err := json.Marshal(apiKey.Scopes())
+	if err != nil {
+		return APIKeyEntity{}, err
+	}
+	return APIKeyEntity{
+		ID:         apiKey.ID(),
+		TenantID:   apiKey.Tenant(),
+		Name:       apiKey.Name(),
+		Prefix:     apiKey.Prefix(),
+		Hash:       apiKey.Hash(),
+		Scopes:     scopes,
+		CreatedBy:  apiKey.CreatedBy(),
+		CreatedAt:  apiKey.CreatedAt(),
+		ExpiresAt:  apiKey.ExpiresAt(),
+		LastUsedAt: apiKey.LastUsedAt(),
+		RevokedAt:  apiKey.RevokedAt(),
+	}, nil
+}
+
+func (e *APIKeyEntity) ToDomainModel() (models.APIKey, error) {
+	var scopes []string
+	if err := json.Unmarshal(e.Scopes, &scopes); err != nil {
+		return nil, err
+	}
+	return models.ReconstituteAPIKey(e.ID, e.Name, e.Prefix, e.Hash, scopes, utc(e.ExpiresAt), models.APIKeyMetadata{
+		Tenant:     e.TenantID,
+		CreatedBy:  e.CreatedBy,
+		CreatedAt:  e.CreatedAt.UTC(),
+		LastUsedAt: utc(e.LastUsedAt),
+		RevokedAt:  utc(e.RevokedAt),
+	})
+}
+
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	value := t.UTC()
+	return &value
+}
@@ -0,0 +1,95 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_adapters"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APIKeyRepository manages the keys of the tenant of the request context, but
+// finds a presented key by hash in any tenant, since the key is what names its
+// tenant.
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+func (ar *APIKeyRepository) Create(ctx context.Context, apiKey models.APIKey) error {
+	entity, err := NewAPIKeyEntity(apiKey)
+	if err != nil {
+		return err
+	}
+	return shared_adapters.TranslateError(shared_adapters.DBFromContext(ctx, ar.db).Create(&entity).Error)
+}
+
+func (ar *APIKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (models.APIKey, error) {
+	return ar.first(ctx, "id = ? AND tenant_id = ?", id, request_context.TenantFrom(ctx))
+}
+
+func (ar *APIKeyRepository) GetByHash(ctx context.Context, hash string) (models.APIKey, error) {
+	return ar.first(ctx, "hash = ?", hash)
+}
+
+func (ar *APIKeyRepository) first(ctx context.Context, query string, args ...interface{}) (models.APIKey, error) {
+	var entity APIKeyEntity
+	if err := shared_adapters.DBFromContext(ctx, ar.db).Where(query, args...).First(&entity).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return entity.ToDomainModel()
+}
+
+func (ar *APIKeyRepository) GetAll(ctx context.Context) ([]models.APIKey, error) {
+	var entities []APIKeyEntity
+	if err := shared_adapters.DBFromContext(ctx, ar.db).Where("tenant_id = ?", request_context.TenantFrom(ctx)).Order("created_at").Order("id").Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	apiKeys := make([]models.APIKey, 0, len(entities))
+	for _, entity := range entities {
+		apiKey, err := entity.ToDomainModel()
+		if err != nil {
+			return nil, err
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+	return apiKeys, nil
+}
+
+// Update saves what rotating or revoking a key changes.
+func (ar *APIKeyRepository) Update(ctx context.Context, apiKey models.APIKey) error {
+	result := shared_adapters.DBFromContext(ctx, ar.db).
+		Model(&APIKeyEntity{}).
+		Where("id = ? AND tenant_id = ?", apiKey.ID(), request_context.TenantFrom(ctx)).
+		Updates(map[string]interface{}{
+			"prefix":     apiKey.Prefix(),
+			"hash":       apiKey.Hash(),
+			"revoked_at": apiKey.RevokedAt(),
+		})
+	if result.Error != nil {
+		return shared_adapters.TranslateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return shared_handlers.ErrNotFound
+	}
+	return nil
+}
+
+// RecordUse stores usedAt as the last use of the key, unless a later use was
+// already stored by a concurrent request.
+func (ar *APIKeyRepository) RecordUse(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	return shared_adapters.DBFromContext(ctx, ar.db).
+		Model(&APIKeyEntity{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, usedAt).
+		Update("last_used_at", usedAt).Error
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/application/dto"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/application/ports/inbound"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// APIKeyHandler handles HTTP requests for API keys
+type APIKeyHandler struct {
+	issueAPIKeyUseCase   inbound.IssueAPIKeyUseCasePort
+	getAllAPIKeysUseCase inbound.GetAllAPIKeysUseCasePort
+	rotateAPIKeyUseCase  inbound.RotateAPIKeyUseCasePort
+	revokeAPIKeyUseCase  inbound.RevokeAPIKeyUseCasePort
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler
+func NewAPIKeyHandler(issueAPIKeyUseCase inbound.IssueAPIKeyUseCasePort, getAllAPIKeysUseCase inbound.GetAllAPIKeysUseCasePort, rotateAPIKeyUseCase inbound.RotateAPIKeyUseCasePort, revokeAPIKeyUseCase inbound.RevokeAPIKeyUseCasePort) *APIKeyHandler {
+	return &APIKeyHandler{
+		issueAPIKeyUseCase:   issueAPIKeyUseCase,
+		getAllAPIKeysUseCase: getAllAPIKeysUseCase,
+		rotateAPIKeyUseCase:  rotateAPIKeyUseCase,
+		revokeAPIKeyUseCase:  revokeAPIKeyUseCase,
+	}
+}
+
+// IssueAPIKey godoc
+// @Summary Issue an API key
+// @Description Issue a key for a server-to-server client, sent as the X-API-Key header. Its scopes are the permissions it grants. The key is only returned here; only its hash is stored
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param apiKey body dto.IssueAPIKeyRequest true "API key details"
+// @Success 201 {object} dto.APIKeyResponse
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /api-keys [post]
+func (ah *APIKeyHandler) IssueAPIKey(c *gin.Context) {
+	var request dto.IssueAPIKeyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(shared_handlers.BindingError(err))
+		return
+	}
+
+	apiKey, plaintext, err := ah.issueAPIKeyUseCase.Execute(c.Request.Context(), request.Name, request.Scopes, request.ExpiresAt)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewIssuedAPIKeyResponseFromDomainModel(apiKey, plaintext))
+}
+
+// GetAllAPIKeys godoc
+// @Summary List API keys
+// @Description Get every API key, oldest first, including expired and revoked ones. Keys are shown by their prefix only
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.APIKeyResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /api-keys [get]
+func (ah *APIKeyHandler) GetAllAPIKeys(c *gin.Context) {
+	apiKeys, err := ah.getAllAPIKeysUseCase.Execute(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	responses := make([]dto.APIKeyResponse, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		responses = append(responses, dto.NewAPIKeyResponseFromDomainModel(apiKey))
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// RotateAPIKey godoc
+// @Summary Rotate an API key
+// @Description Replace the key with a new one, keeping its name, scopes and expiry. The previous key stops working at once
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param id path string true "API key ID (UUID)" format(uuid)
+// @Success 200 {object} dto.APIKeyResponse
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 404 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /api-keys/{id}/rotate [post]
+func (ah *APIKeyHandler) RotateAPIKey(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(shared_handlers.ErrInvalidUUID)
+		return
+	}
+
+	apiKey, plaintext, err := ah.rotateAPIKeyUseCase.Execute(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewIssuedAPIKeyResponseFromDomainModel(apiKey, plaintext))
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key
+// @Description Disable an API key for good. It is still listed, with the time it was revoked
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param id path string true "API key ID (UUID)" format(uuid)
+// @Success 204
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 404 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /api-keys/{id} [delete]
+func (ah *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(shared_handlers.ErrInvalidUUID)
+		return
+	}
+
+	if err := ah.revokeAPIKeyUseCase.Execute(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
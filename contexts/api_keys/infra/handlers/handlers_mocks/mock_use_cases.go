@@ -0,0 +1,52 @@
+package handlers_mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIssueAPIKeyUseCase struct {
+	mock.Mock
+}
+
+func (m *MockIssueAPIKeyUseCase) Execute(ctx context.Context, name string, scopes []string, expiresAt *time.Time) (models.APIKey, string, error) {
+	args := m.Called(ctx, name, scopes, expiresAt)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(models.APIKey), args.String(1), args.Error(2)
+}
+
+type MockGetAllAPIKeysUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetAllAPIKeysUseCase) Execute(ctx context.Context) ([]models.APIKey, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.APIKey), args.Error(1)
+}
+
+type MockRotateAPIKeyUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRotateAPIKeyUseCase) Execute(ctx context.Context, id uuid.UUID) (models.APIKey, string, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(models.APIKey), args.String(1), args.Error(2)
+}
+
+type MockRevokeAPIKeyUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRevokeAPIKeyUseCase) Execute(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/application/dto"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/infra/handlers"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/infra/handlers/handlers_mocks"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/shared_dto"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/middlewares"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type APIKeyHandlerTestSuite struct {
+	suite.Suite
+	handler *handlers.APIKeyHandler
+	router  *gin.Engine
+
+	mockIssueUseCase  *handlers_mocks.MockIssueAPIKeyUseCase
+	mockGetAllUseCase *handlers_mocks.MockGetAllAPIKeysUseCase
+	mockRotateUseCase *handlers_mocks.MockRotateAPIKeyUseCase
+	mockRevokeUseCase *handlers_mocks.MockRevokeAPIKeyUseCase
+}
+
+func (suite *APIKeyHandlerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *APIKeyHandlerTestSuite) SetupTest() {
+	suite.mockIssueUseCase = new(handlers_mocks.MockIssueAPIKeyUseCase)
+	suite.mockGetAllUseCase = new(handlers_mocks.MockGetAllAPIKeysUseCase)
+	suite.mockRotateUseCase = new(handlers_mocks.MockRotateAPIKeyUseCase)
+	suite.mockRevokeUseCase = new(handlers_mocks.MockRevokeAPIKeyUseCase)
+
+	suite.handler = handlers.NewAPIKeyHandler(
+		suite.mockIssueUseCase,
+		suite.mockGetAllUseCase,
+		suite.mockRotateUseCase,
+		suite.mockRevokeUseCase,
+	)
+
+	suite.router = gin.New()
+	suite.router.Use(middlewares.ErrorHandlerMiddleware())
+
+	suite.router.POST("/api-keys", suite.handler.IssueAPIKey)
+	suite.router.GET("/api-keys", suite.handler.GetAllAPIKeys)
+	suite.router.POST("/api-keys/:id/rotate", suite.handler.RotateAPIKey)
+	suite.router.DELETE("/api-keys/:id", suite.handler.RevokeAPIKey)
+}
+
+func (suite *APIKeyHandlerTestSuite) TestIssueAPIKey() {
+	suite.Run("should return the issued key once", func() {
+		// Arrange
+		apiKey := models_mothers.NewAPIKeyMother().MustBuild()
+		suite.mockIssueUseCase.On("Execute", mock.Anything, "nightly-sync", []string{"products:read"}, (*time.Time)(nil)).
+			Return(apiKey, models_mothers.DefaultAPIKey, nil).Once()
+		body := `{"name":"nightly-sync","scopes":["products:read"]}`
+
+		// Act
+		req := httptest.NewRequest(http.MethodPost, "/api-keys", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusCreated, w.Code)
+		var response dto.APIKeyResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(apiKey.ID(), response.ID)
+		suite.Equal(models_mothers.DefaultAPIKey, response.Key)
+		suite.mockIssueUseCase.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should reject a request without scopes", func() {
+		// Act
+		req := httptest.NewRequest(http.MethodPost, "/api-keys", bytes.NewBufferString(`{"name":"nightly-sync"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	suite.Run("should return domain validation errors", func() {
+		// Arrange
+		suite.mockIssueUseCase.On("Execute", mock.Anything, "job", []string{"products:everything"}, (*time.Time)(nil)).
+			Return(nil, "", models.ErrAPIKeyScopesInvalid).Once()
+
+		// Act
+		req := httptest.NewRequest(http.MethodPost, "/api-keys", bytes.NewBufferString(`{"name":"job","scopes":["products:everything"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+		var response shared_dto.ErrorResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(models.ErrAPIKeyScopesInvalid.Code, response.Error)
+	})
+}
+
+func (suite *APIKeyHandlerTestSuite) TestGetAllAPIKeys() {
+	suite.Run("should list keys without their plaintext", func() {
+		// Arrange
+		apiKey := models_mothers.NewAPIKeyMother().MustBuild()
+		suite.mockGetAllUseCase.On("Execute", mock.Anything).Return([]models.APIKey{apiKey}, nil).Once()
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/api-keys", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		suite.NotContains(w.Body.String(), models_mothers.DefaultAPIKey)
+		var response []dto.APIKeyResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Require().Len(response, 1)
+		suite.Equal(apiKey.Prefix(), response[0].Prefix)
+		suite.Empty(response[0].Key)
+	})
+}
+
+func (suite *APIKeyHandlerTestSuite) TestRotateAPIKey() {
+	suite.Run("should return the new key", func() {
+		// Arrange
+		apiKey := models_mothers.NewAPIKeyMother().MustBuild()
+		suite.mockRotateUseCase.On("Execute", mock.Anything, apiKey.ID()).Return(apiKey, "gta_new", nil).Once()
+
+		// Act
+		req := httptest.NewRequest(http.MethodPost, "/api-keys/"+apiKey.ID().String()+"/rotate", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		var response dto.APIKeyResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal("gta_new", response.Key)
+	})
+
+	suite.Run("should return 404 for an unknown key", func() {
+		// Arrange
+		id := uuid.New()
+		suite.mockRotateUseCase.On("Execute", mock.Anything, id).Return(nil, "", shared_handlers.ErrNotFound).Once()
+
+		// Act
+		req := httptest.NewRequest(http.MethodPost, "/api-keys/"+id.String()+"/rotate", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusNotFound, w.Code)
+	})
+}
+
+func (suite *APIKeyHandlerTestSuite) TestRevokeAPIKey() {
+	suite.Run("should return no content", func() {
+		// Arrange
+		id := uuid.New()
+		suite.mockRevokeUseCase.On("Execute", mock.Anything, id).Return(nil).Once()
+
+		// Act
+		req := httptest.NewRequest(http.MethodDelete, "/api-keys/"+id.String(), nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusNoContent, w.Code)
+	})
+
+	suite.Run("should reject an invalid id", func() {
+		// Act
+		req := httptest.NewRequest(http.MethodDelete, "/api-keys/not-a-uuid", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAPIKeyHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyHandlerTestSuite))
+}
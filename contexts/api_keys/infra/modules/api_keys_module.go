@@ -0,0 +1,53 @@
+package modules
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Akiles94/go-test-api/contexts/api_keys/application/ports/inbound"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/infra/adapters"
+	"github.com/Akiles94/go-test-api/contexts/api_keys/infra/handlers"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/middlewares"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type APIKeysModule struct {
+	handler                   *handlers.APIKeyHandler
+	authenticateAPIKeyUseCase inbound.AuthenticateAPIKeyUseCasePort
+}
+
+func NewAPIKeysModule(db *gorm.DB) *APIKeysModule {
+	repo := adapters.NewAPIKeyRepository(db)
+
+	handler := handlers.NewAPIKeyHandler(
+		use_cases.NewIssueAPIKeyUseCase(repo),
+		use_cases.NewGetAllAPIKeysUseCase(repo),
+		use_cases.NewRotateAPIKeyUseCase(repo),
+		use_cases.NewRevokeAPIKeyUseCase(repo))
+
+	return &APIKeysModule{
+		handler:                   handler,
+		authenticateAPIKeyUseCase: use_cases.NewAuthenticateAPIKeyUseCase(repo),
+	}
+}
+
+func (am *APIKeysModule) RegisterRoutes(router *gin.RouterGroup) {
+	manage := shared_models.PermissionAPIKeysManage
+
+	middlewares.RegisterRoutes(router, []middlewares.Route{
+		{Method: http.MethodPost, Path: "", Permission: manage, Handler: am.handler.IssueAPIKey},
+		{Method: http.MethodGet, Path: "", Permission: manage, Handler: am.handler.GetAllAPIKeys},
+		{Method: http.MethodPost, Path: "/:id/rotate", Permission: manage, Handler: am.handler.RotateAPIKey},
+		{Method: http.MethodDelete, Path: "/:id", Permission: manage, Handler: am.handler.RevokeAPIKey},
+	})
+}
+
+// Verify lets the module authenticate X-API-Key requests through
+// middlewares.APIKeyAuthenticationMiddleware.
+func (am *APIKeysModule) Verify(ctx context.Context, key string) (request_context.Principal, error) {
+	return am.authenticateAPIKeyUseCase.Execute(ctx, key)
+}
@@ -0,0 +1,107 @@
+package dto_tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/dto"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportProducts(t *testing.T, format string, products ...models.Product) []byte {
+	var out bytes.Buffer
+	writer, err := dto.NewProductExportWriter(&out, format)
+	require.NoError(t, err)
+	for _, product := range products {
+		require.NoError(t, writer.Write(product))
+	}
+	require.NoError(t, writer.Close())
+	return out.Bytes()
+}
+
+func TestNewProductExportWriter(t *testing.T) {
+	product := models_mothers.NewProductMother().
+		WithSku("TEST-001").
+		WithName(`Cable "USB-C", 2m`).
+		WithCategory("Electronics & <Accessories>").
+		WithPrice(decimal.RequireFromString("9.99")).
+		MustBuild()
+
+	t.Run("should write CSV with a header row", func(t *testing.T) {
+		// Act
+		out := exportProducts(t, dto.ProductExportFormatCSV, product)
+
+		// Assert
+		assert.Equal(t, "id,sku,name,category,price,version\n"+
+			product.ID().String()+`,TEST-001,"Cable ""USB-C"", 2m",Electronics & <Accessories>,9.99,1`+"\n", string(out))
+	})
+
+	t.Run("should write one product response per NDJSON line", func(t *testing.T) {
+		// Act
+		out := exportProducts(t, dto.ProductExportFormatNDJSON, product, product)
+
+		// Assert
+		lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+		require.Len(t, lines, 2)
+		var response dto.ProductResponse
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &response))
+		assert.Equal(t, dto.NewProductResponseFromDomainModel(product), response)
+	})
+
+	t.Run("should write an XLSX workbook with text and numeric cells", func(t *testing.T) {
+		// Act
+		out := exportProducts(t, dto.ProductExportFormatXLSX, product)
+
+		// Assert
+		archive, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+		require.NoError(t, err)
+		names := []string{}
+		var sheet []byte
+		for _, file := range archive.File {
+			names = append(names, file.Name)
+			if file.Name == "xl/worksheets/sheet1.xml" {
+				reader, err := file.Open()
+				require.NoError(t, err)
+				sheet, err = io.ReadAll(reader)
+				require.NoError(t, err)
+			}
+		}
+		assert.ElementsMatch(t, []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels", "xl/workbook.xml", "xl/worksheets/sheet1.xml"}, names)
+
+		var worksheet struct {
+			Rows []struct {
+				Cells []struct {
+					Type   string `xml:"t,attr"`
+					Value  string `xml:"v"`
+					Inline string `xml:"is>t"`
+				} `xml:"c"`
+			} `xml:"sheetData>row"`
+		}
+		require.NoError(t, xml.Unmarshal(sheet, &worksheet))
+		require.Len(t, worksheet.Rows, 2)
+		assert.Equal(t, "sku", worksheet.Rows[0].Cells[1].Inline)
+		cells := worksheet.Rows[1].Cells
+		assert.Equal(t, `Cable "USB-C", 2m`, cells[2].Inline)
+		assert.Equal(t, "Electronics & <Accessories>", cells[3].Inline)
+		assert.Equal(t, "", cells[4].Type)
+		assert.Equal(t, "9.99", cells[4].Value)
+	})
+
+	t.Run("should refuse an unknown format", func(t *testing.T) {
+		// Act
+		_, err := dto.NewProductExportWriter(io.Discard, "xml")
+
+		// Assert
+		assert.Equal(t, shared_handlers.ErrInvalidExportFormat, err)
+	})
+}
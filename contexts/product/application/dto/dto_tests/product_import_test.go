@@ -0,0 +1,103 @@
+package dto_tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/dto"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProductImportCSV(t *testing.T) {
+	t.Run("should read rows by header name and keep their line numbers", func(t *testing.T) {
+		// Arrange
+		file := "\ufeffName,SKU,Price,Category,Notes\n" +
+			"Test Product, TEST-001 ,9.99,Electronics,ignored\n" +
+			"\n" +
+			"\"Multi\nline\",TEST-002,1,Books,\n"
+
+		// Act
+		rows, err := dto.ParseProductImportCSV(strings.NewReader(file), 10)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, 2, rows[0].Line)
+		assert.Equal(t, "TEST-001", rows[0].Sku)
+		require.NoError(t, rows[0].Err)
+		assert.Equal(t, "Test Product", rows[0].Product.Name())
+		assert.True(t, decimal.RequireFromString("9.99").Equal(rows[0].Product.Price()))
+		assert.Equal(t, 4, rows[1].Line)
+		assert.NoError(t, rows[1].Err)
+	})
+
+	t.Run("should keep the reason a row was rejected", func(t *testing.T) {
+		// Arrange
+		file := "sku,name,category,price\n" +
+			"TEST-001,Test Product,Electronics,cheap\n" +
+			"TEST-002,,Electronics,1\n" +
+			"TEST-003,Test Product\n" +
+			"TEST-004,Test Product,Electronics,-1\n"
+
+		// Act
+		rows, err := dto.ParseProductImportCSV(strings.NewReader(file), 10)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, rows, 4)
+		assert.Equal(t, shared_handlers.ErrInvalidImportPrice, rows[0].Err)
+		assert.ErrorIs(t, rows[1].Err, models.ErrProductNameEmpty)
+		assert.Equal(t, shared_handlers.ErrInvalidImportRow, rows[2].Err)
+		assert.ErrorIs(t, rows[3].Err, models.ErrProductPriceNegative)
+		assert.Nil(t, rows[3].Product)
+	})
+
+	t.Run("should refuse a file without the required columns", func(t *testing.T) {
+		// Act
+		_, err := dto.ParseProductImportCSV(strings.NewReader("sku,name,price\nTEST-001,Test Product,1\n"), 10)
+
+		// Assert
+		assert.Equal(t, shared_handlers.ErrInvalidImportFile, err)
+	})
+
+	t.Run("should refuse a file with more rows than allowed", func(t *testing.T) {
+		// Arrange
+		file := "sku,name,category,price\nTEST-001,A,B,1\nTEST-002,A,B,1\n"
+
+		// Act
+		_, err := dto.ParseProductImportCSV(strings.NewReader(file), 1)
+
+		// Assert
+		assert.Equal(t, shared_handlers.ErrImportFileTooLarge, err)
+	})
+}
+
+func TestWriteProductImportReportCSV(t *testing.T) {
+	t.Run("should write one record per line with the error code of rejected lines", func(t *testing.T) {
+		// Arrange
+		productID := uuid.New()
+		report := models.ProductImportReport{
+			Rows: []models.ProductImportRowResult{
+				{Line: 2, Sku: "TEST-001", ProductID: productID},
+				{Line: 3, Sku: "TEST-001", Err: models.ErrProductSkuDuplicatedInFile},
+			},
+		}
+		var out bytes.Buffer
+
+		// Act
+		err := dto.WriteProductImportReportCSV(&out, report)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "line,sku,status,id,error_code,error_message\n"+
+			"2,TEST-001,accepted,"+productID.String()+",,\n"+
+			"3,TEST-001,rejected,,PRODUCT_SKU_DUPLICATED_IN_FILE,Product SKU appears on an earlier line of the file\n",
+			out.String())
+	})
+}
@@ -1,8 +1,26 @@
 package dto
 
+import (
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/shopspring/decimal"
+)
+
 type PatchProductRequest struct {
 	Sku      *string  `json:"sku,omitempty"`
 	Name     *string  `json:"name,omitempty"`
 	Category *string  `json:"category,omitempty"`
 	Price    *float64 `json:"price,omitempty"`
 }
+
+func (p *PatchProductRequest) ToDomainPatch() models.ProductPatch {
+	patch := models.ProductPatch{
+		Sku:      p.Sku,
+		Name:     p.Name,
+		Category: p.Category,
+	}
+	if p.Price != nil {
+		price := decimal.NewFromFloat(*p.Price)
+		patch.Price = &price
+	}
+	return patch
+}
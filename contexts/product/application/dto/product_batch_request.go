@@ -0,0 +1,110 @@
+package dto
+
+import (
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+// Batch items are validated one by one rather than with the request, so that
+// an invalid item is reported in its own result instead of failing the whole
+// batch.
+
+type BatchCreateProductsRequest struct {
+	Mode  string                 `json:"mode" enums:"all_or_nothing,best_effort" default:"all_or_nothing"`
+	Items []CreateProductRequest `json:"items" binding:"required"`
+}
+
+type BatchUpdateProductItemRequest struct {
+	ID       string  `json:"id" binding:"required"`
+	Sku      string  `json:"sku" binding:"required"`
+	Name     string  `json:"name" binding:"required"`
+	Category string  `json:"category" binding:"required"`
+	Price    float64 `json:"price" binding:"required,min=0"`
+	Version  *int    `json:"version,omitempty"`
+}
+
+type BatchUpdateProductsRequest struct {
+	Mode  string                          `json:"mode" enums:"all_or_nothing,best_effort" default:"all_or_nothing"`
+	Items []BatchUpdateProductItemRequest `json:"items" binding:"required"`
+}
+
+type BatchDeleteProductItemRequest struct {
+	ID      string `json:"id" binding:"required"`
+	Version *int   `json:"version,omitempty"`
+}
+
+type BatchDeleteProductsRequest struct {
+	Mode  string                          `json:"mode" enums:"all_or_nothing,best_effort" default:"all_or_nothing"`
+	Items []BatchDeleteProductItemRequest `json:"items" binding:"required"`
+}
+
+// ParseProductBatchMode reads the mode of a batch request, defaulting to
+// all-or-nothing.
+func ParseProductBatchMode(mode string) (models.ProductBatchMode, error) {
+	if mode == "" {
+		return models.ProductBatchAllOrNothing, nil
+	}
+	batchMode := models.ProductBatchMode(mode)
+	if !batchMode.IsValid() {
+		return "", shared_handlers.ErrInvalidBatchMode
+	}
+	return batchMode, nil
+}
+
+func (r *BatchCreateProductsRequest) ToDomainItems() []models.ProductBatchCreateItem {
+	items := make([]models.ProductBatchCreateItem, len(r.Items))
+	for i, itemDto := range r.Items {
+		items[i] = models.ProductBatchCreateItem{Index: i}
+		if err := binding.Validator.ValidateStruct(&itemDto); err != nil {
+			items[i].Err = shared_handlers.BindingError(err)
+			continue
+		}
+		items[i].Product, items[i].Err = itemDto.ToDomainModel()
+	}
+	return items
+}
+
+func (r *BatchUpdateProductsRequest) ToDomainItems() []models.ProductBatchUpdateItem {
+	items := make([]models.ProductBatchUpdateItem, len(r.Items))
+	for i, itemDto := range r.Items {
+		items[i] = models.ProductBatchUpdateItem{Index: i, ExpectedVersion: itemDto.Version}
+		if err := binding.Validator.ValidateStruct(&itemDto); err != nil {
+			items[i].Err = shared_handlers.BindingError(err)
+			continue
+		}
+		id, err := uuid.Parse(itemDto.ID)
+		if err != nil {
+			items[i].Err = shared_handlers.ErrInvalidUUID
+			continue
+		}
+		items[i].ID = id
+		productDto := CreateProductRequest{
+			Sku:      itemDto.Sku,
+			Name:     itemDto.Name,
+			Category: itemDto.Category,
+			Price:    itemDto.Price,
+		}
+		items[i].Product, items[i].Err = productDto.ToDomainModel()
+	}
+	return items
+}
+
+func (r *BatchDeleteProductsRequest) ToDomainItems() []models.ProductBatchDeleteItem {
+	items := make([]models.ProductBatchDeleteItem, len(r.Items))
+	for i, itemDto := range r.Items {
+		items[i] = models.ProductBatchDeleteItem{Index: i, ExpectedVersion: itemDto.Version}
+		if err := binding.Validator.ValidateStruct(&itemDto); err != nil {
+			items[i].Err = shared_handlers.BindingError(err)
+			continue
+		}
+		id, err := uuid.Parse(itemDto.ID)
+		if err != nil {
+			items[i].Err = shared_handlers.ErrInvalidUUID
+			continue
+		}
+		items[i].ID = id
+	}
+	return items
+}
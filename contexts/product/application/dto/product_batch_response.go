@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/shared_dto"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/google/uuid"
+)
+
+// ProductBatchItemResponse is the outcome of one item. Status is the code the
+// item would have got from the single-item endpoint.
+type ProductBatchItemResponse struct {
+	Index  int                       `json:"index"`
+	ID     string                    `json:"id,omitempty"`
+	Status int                       `json:"status"`
+	Error  *shared_dto.ErrorResponse `json:"error,omitempty"`
+}
+
+type ProductBatchResponse struct {
+	Mode      string                     `json:"mode"`
+	Succeeded int                        `json:"succeeded"`
+	Failed    int                        `json:"failed"`
+	Results   []ProductBatchItemResponse `json:"results"`
+}
+
+func NewProductBatchResponse(mode models.ProductBatchMode, results []models.ProductBatchResult, successStatus int) ProductBatchResponse {
+	response := ProductBatchResponse{
+		Mode:    string(mode),
+		Results: make([]ProductBatchItemResponse, len(results)),
+	}
+	for i, result := range results {
+		item := ProductBatchItemResponse{Index: result.Index, Status: successStatus}
+		if result.ProductID != uuid.Nil {
+			item.ID = result.ProductID.String()
+		}
+		if result.Err != nil {
+			errorResponse := shared_dto.FromError(result.Err)
+			item.Status = shared_handlers.StatusCodeFromError(result.Err)
+			item.Error = &errorResponse
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+		response.Results[i] = item
+	}
+	return response
+}
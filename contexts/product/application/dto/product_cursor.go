@@ -0,0 +1,168 @@
+package dto
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/pagination"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	cursorKeyValue = "v"
+	cursorKeyID    = "id"
+
+	filterCategory = "category"
+	filterName     = "name"
+	filterMinPrice = "min_price"
+	filterMaxPrice = "max_price"
+	filterTrashed  = "trashed"
+
+	sortSeparator = ":"
+)
+
+var (
+	ErrInvalidProductSort   = errors.New("invalid product sort")
+	ErrInvalidProductFilter = errors.New("invalid product filter")
+)
+
+// NewProductPaginationCursor captures the listing criteria together with the
+// keyset position so the cursor alone is enough to fetch the next page.
+func NewProductPaginationCursor(filter models.ProductFilter, position models.ProductCursor) pagination.Cursor {
+	direction := pagination.DirectionNext
+	if position.Backward {
+		direction = pagination.DirectionPrev
+	}
+	return pagination.Cursor{
+		Direction: direction,
+		Sort:      FormatProductSort(position.Sort),
+		Key: map[string]string{
+			cursorKeyValue: position.Value,
+			cursorKeyID:    position.ID.String(),
+		},
+		Filters: ProductFilterToMap(filter),
+	}
+}
+
+// ProductQueryFromPaginationCursor restores filters, sort and keyset position
+// from a decoded cursor. The limit is left to the caller.
+func ProductQueryFromPaginationCursor(cursor pagination.Cursor) (models.ProductQuery, error) {
+	sort, err := ParseProductSort(cursor.Sort)
+	if err != nil {
+		return models.ProductQuery{}, pagination.ErrInvalidCursor
+	}
+	filter, err := ProductFilterFromMap(cursor.Filters)
+	if err != nil {
+		return models.ProductQuery{}, pagination.ErrInvalidCursor
+	}
+	id, err := uuid.Parse(cursor.Key[cursorKeyID])
+	if err != nil {
+		return models.ProductQuery{}, pagination.ErrInvalidCursor
+	}
+
+	return models.ProductQuery{
+		Filter: filter,
+		Sort:   sort,
+		Cursor: &models.ProductCursor{
+			Sort:     sort,
+			Value:    cursor.Key[cursorKeyValue],
+			ID:       id,
+			Backward: cursor.Direction == pagination.DirectionPrev,
+		},
+	}, nil
+}
+
+// ParseProductSort accepts "field" or "field:direction", e.g. "price:desc".
+func ParseProductSort(raw string) (models.ProductSort, error) {
+	sort := models.DefaultProductSort()
+	if raw == "" {
+		return sort, nil
+	}
+
+	field, direction, hasDirection := strings.Cut(raw, sortSeparator)
+	sort.Field = models.ProductSortField(strings.ToLower(field))
+	if !sort.Field.IsValid() {
+		return sort, ErrInvalidProductSort
+	}
+	if hasDirection {
+		sort.Direction = models.SortDirection(strings.ToLower(direction))
+		if !sort.Direction.IsValid() {
+			return sort, ErrInvalidProductSort
+		}
+	}
+	return sort, nil
+}
+
+func FormatProductSort(sort models.ProductSort) string {
+	return string(sort.Field) + sortSeparator + string(sort.Direction)
+}
+
+func ProductFilterToMap(filter models.ProductFilter) map[string]string {
+	values := make(map[string]string)
+	if filter.Category != nil {
+		values[filterCategory] = *filter.Category
+	}
+	if filter.Name != nil {
+		values[filterName] = *filter.Name
+	}
+	if filter.MinPrice != nil {
+		values[filterMinPrice] = filter.MinPrice.String()
+	}
+	if filter.MaxPrice != nil {
+		values[filterMaxPrice] = filter.MaxPrice.String()
+	}
+	if filter.Trashed {
+		values[filterTrashed] = strconv.FormatBool(filter.Trashed)
+	}
+	return values
+}
+
+// ProductFilterFromMap builds a filter from raw values keyed like the listing
+// query parameters. Empty values are ignored.
+func ProductFilterFromMap(values map[string]string) (models.ProductFilter, error) {
+	filter := models.ProductFilter{}
+
+	if category := values[filterCategory]; category != "" {
+		filter.Category = &category
+	}
+	if name := values[filterName]; name != "" {
+		filter.Name = &name
+	}
+	if trashed := values[filterTrashed]; trashed != "" {
+		parsed, err := strconv.ParseBool(trashed)
+		if err != nil {
+			return filter, ErrInvalidProductFilter
+		}
+		filter.Trashed = parsed
+	}
+
+	minPrice, err := parseOptionalPrice(values[filterMinPrice])
+	if err != nil {
+		return filter, err
+	}
+	maxPrice, err := parseOptionalPrice(values[filterMaxPrice])
+	if err != nil {
+		return filter, err
+	}
+	if minPrice != nil && maxPrice != nil && minPrice.GreaterThan(*maxPrice) {
+		return filter, ErrInvalidProductFilter
+	}
+	filter.MinPrice = minPrice
+	filter.MaxPrice = maxPrice
+
+	return filter, nil
+}
+
+func parseOptionalPrice(raw string) (*decimal.Decimal, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	price, err := decimal.NewFromString(raw)
+	if err != nil || price.IsNegative() {
+		return nil, ErrInvalidProductFilter
+	}
+	return &price, nil
+}
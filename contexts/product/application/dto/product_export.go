@@ -0,0 +1,110 @@
+package dto
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/xlsx"
+)
+
+const (
+	ProductExportFormatCSV    = "csv"
+	ProductExportFormatNDJSON = "ndjson"
+	ProductExportFormatXLSX   = "xlsx"
+)
+
+const productExportSheetName = "Products"
+
+var productExportHeader = []string{"id", "sku", "name", "category", "price", "version"}
+
+// ProductExportWriter writes products one at a time in an export format.
+// Close must be called after the last product to complete the output.
+type ProductExportWriter interface {
+	Write(product models.Product) error
+	Close() error
+}
+
+// NewProductExportWriter starts an export in the given format. The CSV and
+// XLSX formats begin with a header row; NDJSON writes one ProductResponse per
+// line.
+func NewProductExportWriter(w io.Writer, format string) (ProductExportWriter, error) {
+	switch format {
+	case ProductExportFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(productExportHeader); err != nil {
+			return nil, err
+		}
+		return &csvProductExportWriter{writer: writer}, nil
+	case ProductExportFormatNDJSON:
+		return &ndjsonProductExportWriter{encoder: json.NewEncoder(w)}, nil
+	case ProductExportFormatXLSX:
+		writer, err := xlsx.NewStreamWriter(w, productExportSheetName)
+		if err != nil {
+			return nil, err
+		}
+		header := make([]xlsx.Cell, len(productExportHeader))
+		for i, column := range productExportHeader {
+			header[i] = xlsx.String(column)
+		}
+		if err := writer.WriteRow(header...); err != nil {
+			return nil, err
+		}
+		return &xlsxProductExportWriter{writer: writer}, nil
+	}
+	return nil, shared_handlers.ErrInvalidExportFormat
+}
+
+type csvProductExportWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvProductExportWriter) Write(product models.Product) error {
+	return w.writer.Write([]string{
+		product.ID().String(),
+		product.Sku(),
+		product.Name(),
+		product.Category(),
+		product.Price().String(),
+		strconv.Itoa(product.Version()),
+	})
+}
+
+func (w *csvProductExportWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type ndjsonProductExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonProductExportWriter) Write(product models.Product) error {
+	return w.encoder.Encode(NewProductResponseFromDomainModel(product))
+}
+
+func (w *ndjsonProductExportWriter) Close() error {
+	return nil
+}
+
+type xlsxProductExportWriter struct {
+	writer *xlsx.StreamWriter
+}
+
+func (w *xlsxProductExportWriter) Write(product models.Product) error {
+	return w.writer.WriteRow(
+		xlsx.String(product.ID().String()),
+		xlsx.String(product.Sku()),
+		xlsx.String(product.Name()),
+		xlsx.String(product.Category()),
+		xlsx.Number(product.Price().String()),
+		xlsx.Number(strconv.Itoa(product.Version())),
+	)
+}
+
+func (w *xlsxProductExportWriter) Close() error {
+	return w.writer.Close()
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/pagination"
+	"github.com/google/uuid"
+)
+
+const (
+	cursorKeyOccurredAt = "at"
+	filterProductID     = "product_id"
+)
+
+// NewProductHistoryPaginationCursor ties the position to the product so a
+// cursor cannot be replayed against another product's history.
+func NewProductHistoryPaginationCursor(productID uuid.UUID, position models.ProductAuditCursor) pagination.Cursor {
+	return pagination.Cursor{
+		Direction: pagination.DirectionNext,
+		Key: map[string]string{
+			cursorKeyOccurredAt: position.OccurredAt.Format(time.RFC3339Nano),
+			cursorKeyID:         position.ID.String(),
+		},
+		Filters: map[string]string{
+			filterProductID: productID.String(),
+		},
+	}
+}
+
+func ProductAuditCursorFromPaginationCursor(productID uuid.UUID, cursor pagination.Cursor) (models.ProductAuditCursor, error) {
+	if cursor.Direction != pagination.DirectionNext || cursor.Filters[filterProductID] != productID.String() {
+		return models.ProductAuditCursor{}, pagination.ErrInvalidCursor
+	}
+	occurredAt, err := time.Parse(time.RFC3339Nano, cursor.Key[cursorKeyOccurredAt])
+	if err != nil {
+		return models.ProductAuditCursor{}, pagination.ErrInvalidCursor
+	}
+	id, err := uuid.Parse(cursor.Key[cursorKeyID])
+	if err != nil {
+		return models.ProductAuditCursor{}, pagination.ErrInvalidCursor
+	}
+	return models.ProductAuditCursor{OccurredAt: occurredAt, ID: id}, nil
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/google/uuid"
+)
+
+type ProductFieldChangeResponse struct {
+	Field string  `json:"field"`
+	From  *string `json:"from"`
+	To    *string `json:"to"`
+}
+
+type ProductHistoryEntryResponse struct {
+	ID         uuid.UUID                    `json:"id"`
+	ProductID  uuid.UUID                    `json:"product_id"`
+	Action     string                       `json:"action"`
+	Actor      string                       `json:"actor"`
+	RequestID  string                       `json:"request_id"`
+	OccurredAt time.Time                    `json:"occurred_at"`
+	Changes    []ProductFieldChangeResponse `json:"changes"`
+}
+
+func NewProductHistoryEntryResponseFromDomainModel(entry models.ProductAuditEntry) ProductHistoryEntryResponse {
+	changes := make([]ProductFieldChangeResponse, 0, len(entry.Changes))
+	for _, change := range entry.Changes {
+		changes = append(changes, ProductFieldChangeResponse{
+			Field: change.Field,
+			From:  change.From,
+			To:    change.To,
+		})
+	}
+	return ProductHistoryEntryResponse{
+		ID:         entry.ID,
+		ProductID:  entry.ProductID,
+		Action:     string(entry.Action),
+		Actor:      entry.Actor,
+		RequestID:  entry.RequestID,
+		OccurredAt: entry.OccurredAt,
+		Changes:    changes,
+	}
+}
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+)
+
+// byteOrderMark is written by spreadsheet tools at the start of UTF-8 CSVs.
+const byteOrderMark = "\ufeff"
+
+var productImportColumns = []string{"sku", "name", "category", "price"}
+
+// ParseProductImportCSV reads an import file into rows. The header names the
+// columns, in any order and case; other columns are ignored. Rows keep their
+// line number in the file, and a row that cannot be turned into a product
+// keeps the reason instead. Files with more than maxRows rows are refused.
+func ParseProductImportCSV(r io.Reader, maxRows int) ([]models.ProductImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, shared_handlers.ErrInvalidImportFile
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, byteOrderMark)
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, column := range productImportColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, shared_handlers.ErrInvalidImportFile
+		}
+	}
+
+	rows := []models.ProductImportRow{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, shared_handlers.ErrInvalidImportFile
+		}
+		if len(rows) == maxRows {
+			return nil, shared_handlers.ErrImportFileTooLarge
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, newProductImportRow(line, record, columns))
+	}
+}
+
+func newProductImportRow(line int, record []string, columns map[string]int) models.ProductImportRow {
+	values := make(map[string]string, len(productImportColumns))
+	for _, column := range productImportColumns {
+		if columns[column] >= len(record) {
+			return models.ProductImportRow{Line: line, Err: shared_handlers.ErrInvalidImportRow}
+		}
+		values[column] = strings.TrimSpace(record[columns[column]])
+	}
+	row := models.ProductImportRow{Line: line, Sku: values["sku"]}
+
+	price, err := strconv.ParseFloat(values["price"], 64)
+	if err != nil {
+		row.Err = shared_handlers.ErrInvalidImportPrice
+		return row
+	}
+	productDto := CreateProductRequest{
+		Sku:      values["sku"],
+		Name:     values["name"],
+		Category: values["category"],
+		Price:    price,
+	}
+	row.Product, row.Err = productDto.ToDomainModel()
+	return row
+}
@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/shared_dto"
+	"github.com/google/uuid"
+)
+
+const (
+	ProductImportRowAccepted = "accepted"
+	ProductImportRowRejected = "rejected"
+)
+
+var productImportReportHeader = []string{"line", "sku", "status", "id", "error_code", "error_message"}
+
+type ProductImportRowResponse struct {
+	Line   int                       `json:"line"`
+	Sku    string                    `json:"sku"`
+	Status string                    `json:"status" enums:"accepted,rejected"`
+	ID     string                    `json:"id,omitempty"`
+	Error  *shared_dto.ErrorResponse `json:"error,omitempty"`
+}
+
+type ProductImportReportResponse struct {
+	DryRun   bool                       `json:"dry_run"`
+	Accepted int                        `json:"accepted"`
+	Rejected int                        `json:"rejected"`
+	Rows     []ProductImportRowResponse `json:"rows"`
+}
+
+func NewProductImportReportResponse(report models.ProductImportReport) ProductImportReportResponse {
+	response := ProductImportReportResponse{
+		DryRun:   report.DryRun,
+		Accepted: report.Accepted(),
+		Rejected: report.Rejected(),
+		Rows:     make([]ProductImportRowResponse, len(report.Rows)),
+	}
+	for i, row := range report.Rows {
+		response.Rows[i] = newProductImportRowResponse(row)
+	}
+	return response
+}
+
+func newProductImportRowResponse(row models.ProductImportRowResult) ProductImportRowResponse {
+	response := ProductImportRowResponse{Line: row.Line, Sku: row.Sku, Status: ProductImportRowAccepted}
+	if row.ProductID != uuid.Nil {
+		response.ID = row.ProductID.String()
+	}
+	if !row.Accepted() {
+		errorResponse := shared_dto.FromError(row.Err)
+		response.Status = ProductImportRowRejected
+		response.Error = &errorResponse
+	}
+	return response
+}
+
+// WriteProductImportReportCSV writes the report as CSV, one record per line
+// of the import file.
+func WriteProductImportReportCSV(w io.Writer, report models.ProductImportReport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(productImportReportHeader); err != nil {
+		return err
+	}
+	for _, row := range report.Rows {
+		response := newProductImportRowResponse(row)
+		record := []string{strconv.Itoa(response.Line), response.Sku, response.Status, response.ID, "", ""}
+		if response.Error != nil {
+			record[4] = response.Error.Error
+			record[5] = response.Error.Message
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -1,25 +1,31 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
 type ProductResponse struct {
-	ID       uuid.UUID       `json:"id"`
-	Sku      string          `json:"sku"`
-	Name     string          `json:"name"`
-	Category string          `json:"category"`
-	Price    decimal.Decimal `json:"price"`
+	ID        uuid.UUID       `json:"id"`
+	Sku       string          `json:"sku"`
+	Name      string          `json:"name"`
+	Category  string          `json:"category"`
+	Price     decimal.Decimal `json:"price"`
+	Version   int             `json:"version"`
+	DeletedAt *time.Time      `json:"deleted_at,omitempty"`
 }
 
 func NewProductResponseFromDomainModel(product models.Product) ProductResponse {
 	return ProductResponse{
-		ID:       product.ID(),
-		Sku:      product.Sku(),
-		Name:     product.Name(),
-		Category: product.Category(),
-		Price:    product.Price(),
+		ID:        product.ID(),
+		Sku:       product.Sku(),
+		Name:      product.Name(),
+		Category:  product.Category(),
+		Price:     product.Price(),
+		Version:   product.Version(),
+		DeletedAt: product.DeletedAt(),
 	}
 }
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"maps"
+	"strconv"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/pagination"
+	"github.com/google/uuid"
+)
+
+const (
+	cursorKeyRank = "rank"
+	filterText    = "q"
+)
+
+// NewProductSearchPaginationCursor captures the search text and filters
+// together with the keyset position, like NewProductPaginationCursor does for
+// listings.
+func NewProductSearchPaginationCursor(text string, filter models.ProductFilter, position models.ProductSearchCursor) pagination.Cursor {
+	direction := pagination.DirectionNext
+	if position.Backward {
+		direction = pagination.DirectionPrev
+	}
+	filters := ProductFilterToMap(filter)
+	filters[filterText] = text
+	return pagination.Cursor{
+		Direction: direction,
+		Key: map[string]string{
+			cursorKeyRank: strconv.FormatFloat(float64(position.Rank), 'g', -1, 32),
+			cursorKeyID:   position.ID.String(),
+		},
+		Filters: filters,
+	}
+}
+
+// ProductSearchQueryFromPaginationCursor restores text, filters and keyset
+// position from a decoded cursor. The limit is left to the caller.
+func ProductSearchQueryFromPaginationCursor(cursor pagination.Cursor) (models.ProductSearchQuery, error) {
+	text := cursor.Filters[filterText]
+	if text == "" {
+		return models.ProductSearchQuery{}, pagination.ErrInvalidCursor
+	}
+	rawFilter := maps.Clone(cursor.Filters)
+	delete(rawFilter, filterText)
+	filter, err := ProductFilterFromMap(rawFilter)
+	if err != nil || filter.Trashed {
+		return models.ProductSearchQuery{}, pagination.ErrInvalidCursor
+	}
+	rank, err := strconv.ParseFloat(cursor.Key[cursorKeyRank], 32)
+	if err != nil {
+		return models.ProductSearchQuery{}, pagination.ErrInvalidCursor
+	}
+	id, err := uuid.Parse(cursor.Key[cursorKeyID])
+	if err != nil {
+		return models.ProductSearchQuery{}, pagination.ErrInvalidCursor
+	}
+
+	return models.ProductSearchQuery{
+		Text:   text,
+		Filter: filter,
+		Cursor: &models.ProductSearchCursor{
+			Rank:     float32(rank),
+			ID:       id,
+			Backward: cursor.Direction == pagination.DirectionPrev,
+		},
+	}, nil
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "github.com/Akiles94/go-test-api/contexts/product/domain/models"
+
+type ProductSearchHighlightsResponse struct {
+	Name     string `json:"name"`
+	Sku      string `json:"sku"`
+	Category string `json:"category"`
+}
+
+// ProductSearchHitResponse is a product as listed, plus its relevance and the
+// highlighted matches. Highlights are HTML-escaped, apart from their <mark>
+// tags.
+type ProductSearchHitResponse struct {
+	ProductResponse
+	Rank       float32                         `json:"rank"`
+	Highlights ProductSearchHighlightsResponse `json:"highlights"`
+}
+
+func NewProductSearchHitResponseFromDomainModel(hit models.ProductSearchHit) ProductSearchHitResponse {
+	return ProductSearchHitResponse{
+		ProductResponse: NewProductResponseFromDomainModel(hit.Product),
+		Rank:            hit.Rank,
+		Highlights: ProductSearchHighlightsResponse{
+			Name:     hit.Highlights.Name,
+			Sku:      hit.Highlights.Sku,
+			Category: hit.Highlights.Category,
+		},
+	}
+}
@@ -0,0 +1,11 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+)
+
+type BatchUpdateProductsUseCasePort interface {
+	Execute(ctx context.Context, mode models.ProductBatchMode, items []models.ProductBatchUpdateItem) ([]models.ProductBatchResult, error)
+}
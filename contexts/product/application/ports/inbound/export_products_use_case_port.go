@@ -0,0 +1,12 @@
+package inbound
+
+import (
+	"context"
+	"iter"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+)
+
+type ExportProductsUseCasePort interface {
+	Execute(ctx context.Context, query models.ProductExportQuery) iter.Seq2[models.Product, error]
+}
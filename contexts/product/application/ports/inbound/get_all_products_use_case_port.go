@@ -7,5 +7,5 @@ import (
 )
 
 type GetAllProductsUseCasePort interface {
-	Execute(ctx context.Context, cursor *string, limit *int) ([]models.Product, *string, error)
+	Execute(ctx context.Context, query models.ProductQuery) (models.ProductPage, error)
 }
@@ -0,0 +1,11 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+)
+
+type ImportProductsUseCasePort interface {
+	Execute(ctx context.Context, rows []models.ProductImportRow, dryRun bool) (models.ProductImportReport, error)
+}
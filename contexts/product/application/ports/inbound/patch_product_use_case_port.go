@@ -3,9 +3,10 @@ package inbound
 import (
 	"context"
 
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
 	"github.com/google/uuid"
 )
 
 type PatchProductUseCasePort interface {
-	Execute(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	Execute(ctx context.Context, id uuid.UUID, patch models.ProductPatch, expectedVersion *int) error
 }
@@ -0,0 +1,11 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type RestoreProductUseCasePort interface {
+	Execute(ctx context.Context, id uuid.UUID) error
+}
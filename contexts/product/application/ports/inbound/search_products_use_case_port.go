@@ -0,0 +1,11 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+)
+
+type SearchProductsUseCasePort interface {
+	Execute(ctx context.Context, query models.ProductSearchQuery) (models.ProductSearchPage, error)
+}
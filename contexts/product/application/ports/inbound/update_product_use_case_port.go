@@ -8,5 +8,5 @@ import (
 )
 
 type UpdateProductUseCasePort interface {
-	Execute(ctx context.Context, id uuid.UUID, product models.Product) error
+	Execute(ctx context.Context, id uuid.UUID, product models.Product, expectedVersion *int) error
 }
@@ -0,0 +1,12 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+)
+
+type ProductAuditRepositoryPort interface {
+	Record(ctx context.Context, entry models.ProductAuditEntry) error
+	GetByProductID(ctx context.Context, query models.ProductAuditQuery) (models.ProductAuditPage, error)
+}
@@ -2,6 +2,7 @@ package outbound
 
 import (
 	"context"
+	"iter"
 
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
 	"github.com/google/uuid"
@@ -10,8 +11,20 @@ import (
 type ProductRepositoryPort interface {
 	Create(ctx context.Context, product models.Product) error
 	GetByID(ctx context.Context, id uuid.UUID) (models.Product, error)
-	GetAll(ctx context.Context, cursor *string, limit *int) ([]models.Product, *string, error)
-	Update(ctx context.Context, id uuid.UUID, product models.Product) error
-	Delete(ctx context.Context, id uuid.UUID) error
-	Patch(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	// GetTrashedByID returns a product that is in the trash, or nil when
+	// there is none with that id.
+	GetTrashedByID(ctx context.Context, id uuid.UUID) (models.Product, error)
+	GetAll(ctx context.Context, query models.ProductQuery) (models.ProductPage, error)
+	Search(ctx context.Context, query models.ProductSearchQuery) (models.ProductSearchPage, error)
+	Update(ctx context.Context, id uuid.UUID, product models.Product, expectedVersion *int) error
+	Delete(ctx context.Context, id uuid.UUID, expectedVersion *int) error
+	// Restore takes product out of the trash, persisting its UpdatedAt.
+	Restore(ctx context.Context, product models.Product) error
+	Purge(ctx context.Context, id uuid.UUID) error
+	// ExistingSkus returns which of skus belong to products that are not in
+	// the trash.
+	ExistingSkus(ctx context.Context, skus []string) ([]string, error)
+	// Export yields every product matching query, stopping at the first
+	// error.
+	Export(ctx context.Context, query models.ProductExportQuery) iter.Seq2[models.Product, error]
 }
@@ -0,0 +1,48 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/ports/inbound"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+)
+
+// BatchCreateProductsUseCase creates each product through the single-item use
+// case, so every item is audited and raises its events like a plain create.
+type BatchCreateProductsUseCase struct {
+	createUseCase inbound.CreateProductUseCasePort
+	txManager     interfaces.TransactionManager
+}
+
+func NewBatchCreateProductsUseCase(createUseCase inbound.CreateProductUseCasePort, txManager interfaces.TransactionManager) *BatchCreateProductsUseCase {
+	return &BatchCreateProductsUseCase{
+		createUseCase: createUseCase,
+		txManager:     txManager,
+	}
+}
+
+func (uc *BatchCreateProductsUseCase) Execute(ctx context.Context, mode models.ProductBatchMode, items []models.ProductBatchCreateItem) ([]models.ProductBatchResult, error) {
+	if err := authorization.Authorize(ctx, shared_models.PermissionProductsWrite); err != nil {
+		return nil, err
+	}
+	results := make([]models.ProductBatchResult, len(items))
+	for i, item := range items {
+		results[i] = models.ProductBatchResult{Index: item.Index, Err: item.Err}
+	}
+	results, err := runProductBatch(ctx, uc.txManager, mode, results, func(ctx context.Context, i int) error {
+		return uc.createUseCase.Execute(ctx, items[i].Product)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Only products that were created have an ID worth reporting.
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].ProductID = items[i].Product.ID()
+		}
+	}
+	return results, nil
+}
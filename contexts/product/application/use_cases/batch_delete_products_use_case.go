@@ -0,0 +1,38 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/ports/inbound"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+)
+
+// BatchDeleteProductsUseCase moves each product to the trash through the
+// single-item use case.
+type BatchDeleteProductsUseCase struct {
+	deleteUseCase inbound.DeleteProductUseCasePort
+	txManager     interfaces.TransactionManager
+}
+
+func NewBatchDeleteProductsUseCase(deleteUseCase inbound.DeleteProductUseCasePort, txManager interfaces.TransactionManager) *BatchDeleteProductsUseCase {
+	return &BatchDeleteProductsUseCase{
+		deleteUseCase: deleteUseCase,
+		txManager:     txManager,
+	}
+}
+
+func (uc *BatchDeleteProductsUseCase) Execute(ctx context.Context, mode models.ProductBatchMode, items []models.ProductBatchDeleteItem) ([]models.ProductBatchResult, error) {
+	if err := authorization.Authorize(ctx, shared_models.PermissionProductsDelete); err != nil {
+		return nil, err
+	}
+	results := make([]models.ProductBatchResult, len(items))
+	for i, item := range items {
+		results[i] = models.ProductBatchResult{Index: item.Index, ProductID: item.ID, Err: item.Err}
+	}
+	return runProductBatch(ctx, uc.txManager, mode, results, func(ctx context.Context, i int) error {
+		return uc.deleteUseCase.Execute(ctx, items[i].ID, items[i].ExpectedVersion)
+	})
+}
@@ -0,0 +1,38 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/ports/inbound"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+)
+
+// BatchUpdateProductsUseCase updates each product through the single-item use
+// case, honouring the version each item expects.
+type BatchUpdateProductsUseCase struct {
+	updateUseCase inbound.UpdateProductUseCasePort
+	txManager     interfaces.TransactionManager
+}
+
+func NewBatchUpdateProductsUseCase(updateUseCase inbound.UpdateProductUseCasePort, txManager interfaces.TransactionManager) *BatchUpdateProductsUseCase {
+	return &BatchUpdateProductsUseCase{
+		updateUseCase: updateUseCase,
+		txManager:     txManager,
+	}
+}
+
+func (uc *BatchUpdateProductsUseCase) Execute(ctx context.Context, mode models.ProductBatchMode, items []models.ProductBatchUpdateItem) ([]models.ProductBatchResult, error) {
+	if err := authorization.Authorize(ctx, shared_models.PermissionProductsWrite); err != nil {
+		return nil, err
+	}
+	results := make([]models.ProductBatchResult, len(items))
+	for i, item := range items {
+		results[i] = models.ProductBatchResult{Index: item.Index, ProductID: item.ID, Err: item.Err}
+	}
+	return runProductBatch(ctx, uc.txManager, mode, results, func(ctx context.Context, i int) error {
+		return uc.updateUseCase.Execute(ctx, items[i].ID, items[i].Product, items[i].ExpectedVersion)
+	})
+}
@@ -5,18 +5,36 @@ import (
 
 	"github.com/Akiles94/go-test-api/contexts/product/application/ports/outbound"
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
 )
 
 type CreateProductUseCase struct {
-	repo outbound.ProductRepositoryPort
+	repo      outbound.ProductRepositoryPort
+	auditRepo outbound.ProductAuditRepositoryPort
+	outbox    interfaces.EventOutbox
+	txManager interfaces.TransactionManager
 }
 
-func NewCreateProductUseCase(repo outbound.ProductRepositoryPort) *CreateProductUseCase {
+func NewCreateProductUseCase(repo outbound.ProductRepositoryPort, auditRepo outbound.ProductAuditRepositoryPort, outbox interfaces.EventOutbox, txManager interfaces.TransactionManager) *CreateProductUseCase {
 	return &CreateProductUseCase{
-		repo: repo,
+		repo:      repo,
+		auditRepo: auditRepo,
+		outbox:    outbox,
+		txManager: txManager,
 	}
 }
 
 func (uc *CreateProductUseCase) Execute(ctx context.Context, product models.Product) error {
-	return uc.repo.Create(ctx, product)
+	if err := authorization.Authorize(ctx, shared_models.PermissionProductsWrite); err != nil {
+		return err
+	}
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.Create(ctx, product); err != nil {
+			return err
+		}
+		changes := models.DiffProducts(nil, product)
+		return recordProductChange(ctx, uc.auditRepo, uc.outbox, product, models.ProductAuditCreated, changes)
+	})
 }
@@ -4,19 +4,49 @@ import (
 	"context"
 
 	"github.com/Akiles94/go-test-api/contexts/product/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
 	"github.com/google/uuid"
 )
 
 type DeleteProductUseCase struct {
-	repo outbound.ProductRepositoryPort
+	repo      outbound.ProductRepositoryPort
+	auditRepo outbound.ProductAuditRepositoryPort
+	outbox    interfaces.EventOutbox
+	txManager interfaces.TransactionManager
 }
 
-func NewDeleteProductUseCase(repo outbound.ProductRepositoryPort) *DeleteProductUseCase {
+func NewDeleteProductUseCase(repo outbound.ProductRepositoryPort, auditRepo outbound.ProductAuditRepositoryPort, outbox interfaces.EventOutbox, txManager interfaces.TransactionManager) *DeleteProductUseCase {
 	return &DeleteProductUseCase{
-		repo: repo,
+		repo:      repo,
+		auditRepo: auditRepo,
+		outbox:    outbox,
+		txManager: txManager,
 	}
 }
 
-func (uc *DeleteProductUseCase) Execute(ctx context.Context, id uuid.UUID) error {
-	return uc.repo.Delete(ctx, id)
+// Execute deletes the product and audits it. Deleting a product that does not
+// exist fails with ErrNotFound.
+func (uc *DeleteProductUseCase) Execute(ctx context.Context, id uuid.UUID, expectedVersion *int) error {
+	if err := authorization.Authorize(ctx, shared_models.PermissionProductsDelete); err != nil {
+		return err
+	}
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := uc.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if stored == nil {
+			return shared_handlers.ErrNotFound
+		}
+		if err := uc.repo.Delete(ctx, id, expectedVersion); err != nil {
+			return err
+		}
+		changes := models.DiffProducts(stored, nil)
+		stored.Delete()
+		return recordProductChange(ctx, uc.auditRepo, uc.outbox, stored, models.ProductAuditDeleted, changes)
+	})
 }
@@ -0,0 +1,33 @@
+package use_cases
+
+import (
+	"context"
+	"iter"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+)
+
+// ExportProductsUseCase streams the whole catalogue, or the part of it
+// matching a filter, without paging. Products are read lazily as the caller
+// ranges over the result.
+type ExportProductsUseCase struct {
+	repo outbound.ProductRepositoryPort
+}
+
+func NewExportProductsUseCase(repo outbound.ProductRepositoryPort) *ExportProductsUseCase {
+	return &ExportProductsUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *ExportProductsUseCase) Execute(ctx context.Context, query models.ProductExportQuery) iter.Seq2[models.Product, error] {
+	if err := authorization.Authorize(ctx, shared_models.PermissionProductsRead); err != nil {
+		return func(yield func(models.Product, error) bool) {
+			yield(nil, err)
+		}
+	}
+	return uc.repo.Export(ctx, query)
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/Akiles94/go-test-api/contexts/product/application/ports/outbound"
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
 )
 
 type GetAllProductsUseCase struct {
@@ -17,11 +19,14 @@ func NewGetAllProductsUseCase(repo outbound.ProductRepositoryPort) *GetAllProduc
 	}
 }
 
-func (uc *GetAllProductsUseCase) Execute(ctx context.Context, cursor *string, limit *int) ([]models.Product, *string, error) {
-	products, nextCursor, err := uc.repo.GetAll(ctx, cursor, limit)
+func (uc *GetAllProductsUseCase) Execute(ctx context.Context, query models.ProductQuery) (models.ProductPage, error) {
+	if err := authorization.Authorize(ctx, shared_models.PermissionProductsRead); err != nil {
+		return models.ProductPage{}, err
+	}
+	page, err := uc.repo.GetAll(ctx, query)
 	if err != nil {
-		return nil, nil, err
+		return models.ProductPage{}, err
 	}
 
-	return products, nextCursor, nil
+	return page, nil
 }
@@ -5,6 +5,8 @@ import (
 
 	"github.com/Akiles94/go-test-api/contexts/product/application/ports/outbound"
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
 	"github.com/google/uuid"
 )
 
@@ -19,5 +21,8 @@ func NewGetOneProductUseCase(repo outbound.ProductRepositoryPort) *GetOneProduct
 }
 
 func (uc *GetOneProductUseCase) Execute(ctx context.Context, id uuid.UUID) (models.Product, error) {
+	if err := authorization.Authorize(ctx, shared_models.PermissionProductsRead); err != nil {
+		return nil, err
+	}
 	return uc.repo.GetByID(ctx, id)
 }
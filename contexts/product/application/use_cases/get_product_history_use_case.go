@@ -0,0 +1,27 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+)
+
+type GetProductHistoryUseCase struct {
+	auditRepo outbound.ProductAuditRepositoryPort
+}
+
+func NewGetProductHistoryUseCase(auditRepo outbound.ProductAuditRepositoryPort) *GetProductHistoryUseCase {
+	return &GetProductHistoryUseCase{
+		auditRepo: auditRepo,
+	}
+}
+
+func (uc *GetProductHistoryUseCase) Execute(ctx context.Context, query models.ProductAuditQuery) (models.ProductAuditPage, error) {
+	if err := authorization.Authorize(ctx, shared_models.PermissionProductsRead); err != nil {
+		return models.ProductAuditPage{}, err
+	}
+	return uc.auditRepo.GetByProductID(ctx, query)
+}
@@ -0,0 +1,82 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/ports/inbound"
+	"github.com/Akiles94/go-test-api/contexts/product/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+)
+
+// ImportProductsUseCase creates the products of an import file. A line is
+// rejected when it is invalid, repeats the SKU of an earlier line or uses the
+// SKU of an existing product; every other line is created through the
+// single-item use case, on its own.
+type ImportProductsUseCase struct {
+	repo          outbound.ProductRepositoryPort
+	createUseCase inbound.CreateProductUseCasePort
+}
+
+func NewImportProductsUseCase(repo outbound.ProductRepositoryPort, createUseCase inbound.CreateProductUseCasePort) *ImportProductsUseCase {
+	return &ImportProductsUseCase{
+		repo:          repo,
+		createUseCase: createUseCase,
+	}
+}
+
+func (uc *ImportProductsUseCase) Execute(ctx context.Context, rows []models.ProductImportRow, dryRun bool) (models.ProductImportReport, error) {
+	if err := authorization.Authorize(ctx, shared_models.PermissionProductsWrite); err != nil {
+		return models.ProductImportReport{}, err
+	}
+	report := models.ProductImportReport{
+		DryRun: dryRun,
+		Rows:   make([]models.ProductImportRowResult, len(rows)),
+	}
+	seen := make(map[string]bool, len(rows))
+	skus := []string{}
+	for i, row := range rows {
+		report.Rows[i] = models.ProductImportRowResult{Line: row.Line, Sku: row.Sku, Err: row.Err}
+		if row.Err != nil {
+			continue
+		}
+		if seen[row.Product.Sku()] {
+			report.Rows[i].Err = models.ErrProductSkuDuplicatedInFile
+			continue
+		}
+		seen[row.Product.Sku()] = true
+		skus = append(skus, row.Product.Sku())
+	}
+
+	if len(skus) > 0 {
+		existing, err := uc.repo.ExistingSkus(ctx, skus)
+		if err != nil {
+			return models.ProductImportReport{}, err
+		}
+		taken := make(map[string]bool, len(existing))
+		for _, sku := range existing {
+			taken[sku] = true
+		}
+		for i, row := range rows {
+			if report.Rows[i].Err == nil && taken[row.Product.Sku()] {
+				report.Rows[i].Err = models.ErrProductSkuAlreadyExists
+			}
+		}
+	}
+
+	if dryRun {
+		return report, nil
+	}
+	for i, row := range rows {
+		if report.Rows[i].Err != nil {
+			continue
+		}
+		if err := uc.createUseCase.Execute(ctx, row.Product); err != nil {
+			report.Rows[i].Err = err
+			continue
+		}
+		report.Rows[i].ProductID = row.Product.ID()
+	}
+	return report, nil
+}
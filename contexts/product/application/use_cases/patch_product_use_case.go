@@ -4,19 +4,55 @@ import (
 	"context"
 
 	"github.com/Akiles94/go-test-api/contexts/product/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
 	"github.com/google/uuid"
 )
 
 type PatchProductUseCase struct {
-	repo outbound.ProductRepositoryPort
+	repo      outbound.ProductRepositoryPort
+	auditRepo outbound.ProductAuditRepositoryPort
+	outbox    interfaces.EventOutbox
+	txManager interfaces.TransactionManager
 }
 
-func NewPatchProductUseCase(repo outbound.ProductRepositoryPort) *PatchProductUseCase {
+func NewPatchProductUseCase(repo outbound.ProductRepositoryPort, auditRepo outbound.ProductAuditRepositoryPort, outbox interfaces.EventOutbox, txManager interfaces.TransactionManager) *PatchProductUseCase {
 	return &PatchProductUseCase{
-		repo: repo,
+		repo:      repo,
+		auditRepo: auditRepo,
+		outbox:    outbox,
+		txManager: txManager,
 	}
 }
 
-func (uc *PatchProductUseCase) Execute(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
-	return uc.repo.Patch(ctx, id, updates)
+// Execute applies the patch to the stored product, which rejects it with the
+// same errors as an update when the result would be invalid, and saves it. A
+// patch that changes nothing is not saved, exactly like such an update.
+func (uc *PatchProductUseCase) Execute(ctx context.Context, id uuid.UUID, patch models.ProductPatch, expectedVersion *int) error {
+	if err := authorization.Authorize(ctx, shared_models.PermissionProductsWrite); err != nil {
+		return err
+	}
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		product, err := uc.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if product == nil {
+			return shared_handlers.ErrNotFound
+		}
+		changes, err := product.Patch(patch)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			return checkExpectedVersion(product, expectedVersion)
+		}
+		if err := uc.repo.Update(ctx, id, product, expectedVersion); err != nil {
+			return err
+		}
+		return recordProductChange(ctx, uc.auditRepo, uc.outbox, product, models.ProductAuditPatched, changes)
+	})
 }
@@ -0,0 +1,57 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+)
+
+var errProductBatchItemFailed = errors.New("product batch item failed")
+
+// runProductBatch applies the items that were not rejected up front, in
+// order, and fills in their outcome. In all-or-nothing mode the items share
+// one transaction: the first failure rolls it back and every other item is
+// reported as aborted. In best-effort mode each item commits on its own.
+func runProductBatch(ctx context.Context, txManager interfaces.TransactionManager, mode models.ProductBatchMode, results []models.ProductBatchResult, apply func(ctx context.Context, i int) error) ([]models.ProductBatchResult, error) {
+	if mode == models.ProductBatchBestEffort {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = apply(ctx, i)
+			}
+		}
+		return results, nil
+	}
+
+	if slices.ContainsFunc(results, func(result models.ProductBatchResult) bool { return result.Err != nil }) {
+		return abortProductBatch(results), nil
+	}
+	err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		for i := range results {
+			if err := apply(ctx, i); err != nil {
+				results[i].Err = err
+				return errProductBatchItemFailed
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errProductBatchItemFailed) {
+		return abortProductBatch(results), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func abortProductBatch(results []models.ProductBatchResult) []models.ProductBatchResult {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Err = shared_handlers.ErrBatchItemAborted
+		}
+	}
+	return results
+}
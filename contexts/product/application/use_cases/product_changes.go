@@ -0,0 +1,39 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+)
+
+// recordProductChange stores what accompanies every product change: the
+// events the aggregate raised, for the outbox, and an audit entry attributed
+// to the actor and request found in ctx. Call it inside the transaction of
+// the change.
+func recordProductChange(ctx context.Context, auditRepo outbound.ProductAuditRepositoryPort, outbox interfaces.EventOutbox, product models.Product, action models.ProductAuditAction, changes []models.ProductFieldChange) error {
+	if err := outbox.Save(ctx, product.PullEvents()); err != nil {
+		return err
+	}
+	entry := models.NewProductAuditEntry(
+		product.ID(),
+		action,
+		request_context.ActorFrom(ctx),
+		request_context.RequestIDFrom(ctx),
+		changes,
+	)
+	return auditRepo.Record(ctx, entry)
+}
+
+// checkExpectedVersion fails with ErrPreconditionFailed when the caller based
+// a change on another version than the stored one. The repository checks it
+// on every write, so only changes that write nothing need it.
+func checkExpectedVersion(stored models.Product, expectedVersion *int) error {
+	if expectedVersion != nil && *expectedVersion != stored.Version() {
+		return shared_handlers.ErrPreconditionFailed
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/google/uuid"
+)
+
+type PurgeProductUseCase struct {
+	repo      outbound.ProductRepositoryPort
+	auditRepo outbound.ProductAuditRepositoryPort
+	outbox    interfaces.EventOutbox
+	txManager interfaces.TransactionManager
+}
+
+func NewPurgeProductUseCase(repo outbound.ProductRepositoryPort, auditRepo outbound.ProductAuditRepositoryPort, outbox interfaces.EventOutbox, txManager interfaces.TransactionManager) *PurgeProductUseCase {
+	return &PurgeProductUseCase{
+		repo:      repo,
+		auditRepo: auditRepo,
+		outbox:    outbox,
+		txManager: txManager,
+	}
+}
+
+// Execute permanently removes a product from the trash, audits it and raises
+// ProductPurged. The history of a purged product is kept. Purging a product
+// that is not in the trash fails with ErrNotFound.
+func (uc *PurgeProductUseCase) Execute(ctx context.Context, id uuid.UUID) error {
+	if err := authorization.Authorize(ctx, shared_models.PermissionProductsDelete); err != nil {
+		return err
+	}
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := uc.repo.GetTrashedByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if stored == nil {
+			return shared_handlers.ErrNotFound
+		}
+		if err := uc.repo.Purge(ctx, id); err != nil {
+			return err
+		}
+		changes := models.DiffProducts(stored, nil)
+		stored.Purge()
+		return recordProductChange(ctx, uc.auditRepo, uc.outbox, stored, models.ProductAuditPurged, changes)
+	})
+}
@@ -0,0 +1,53 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/google/uuid"
+)
+
+type RestoreProductUseCase struct {
+	repo      outbound.ProductRepositoryPort
+	auditRepo outbound.ProductAuditRepositoryPort
+	outbox    interfaces.EventOutbox
+	txManager interfaces.TransactionManager
+}
+
+func NewRestoreProductUseCase(repo outbound.ProductRepositoryPort, auditRepo outbound.ProductAuditRepositoryPort, outbox interfaces.EventOutbox, txManager interfaces.TransactionManager) *RestoreProductUseCase {
+	return &RestoreProductUseCase{
+		repo:      repo,
+		auditRepo: auditRepo,
+		outbox:    outbox,
+		txManager: txManager,
+	}
+}
+
+// Execute takes the product out of the trash, audits it and raises
+// ProductRestored. Restoring a product that is not in the trash fails with
+// ErrNotFound.
+func (uc *RestoreProductUseCase) Execute(ctx context.Context, id uuid.UUID) error {
+	if err := authorization.Authorize(ctx, shared_models.PermissionProductsWrite); err != nil {
+		return err
+	}
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := uc.repo.GetTrashedByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if stored == nil {
+			return shared_handlers.ErrNotFound
+		}
+		changes := models.DiffProducts(nil, stored)
+		stored.Restore()
+		if err := uc.repo.Restore(ctx, stored); err != nil {
+			return err
+		}
+		return recordProductChange(ctx, uc.auditRepo, uc.outbox, stored, models.ProductAuditRestored, changes)
+	})
+}
@@ -0,0 +1,27 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+)
+
+type SearchProductsUseCase struct {
+	repo outbound.ProductRepositoryPort
+}
+
+func NewSearchProductsUseCase(repo outbound.ProductRepositoryPort) *SearchProductsUseCase {
+	return &SearchProductsUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *SearchProductsUseCase) Execute(ctx context.Context, query models.ProductSearchQuery) (models.ProductSearchPage, error) {
+	if err := authorization.Authorize(ctx, shared_models.PermissionProductsRead); err != nil {
+		return models.ProductSearchPage{}, err
+	}
+	return uc.repo.Search(ctx, query)
+}
@@ -5,19 +5,54 @@ import (
 
 	"github.com/Akiles94/go-test-api/contexts/product/application/ports/outbound"
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
 	"github.com/google/uuid"
 )
 
 type UpdateProductUseCase struct {
-	repo outbound.ProductRepositoryPort
+	repo      outbound.ProductRepositoryPort
+	auditRepo outbound.ProductAuditRepositoryPort
+	outbox    interfaces.EventOutbox
+	txManager interfaces.TransactionManager
 }
 
-func NewUpdateProductUseCase(repo outbound.ProductRepositoryPort) *UpdateProductUseCase {
+func NewUpdateProductUseCase(repo outbound.ProductRepositoryPort, auditRepo outbound.ProductAuditRepositoryPort, outbox interfaces.EventOutbox, txManager interfaces.TransactionManager) *UpdateProductUseCase {
 	return &UpdateProductUseCase{
-		repo: repo,
+		repo:      repo,
+		auditRepo: auditRepo,
+		outbox:    outbox,
+		txManager: txManager,
 	}
 }
 
-func (uc *UpdateProductUseCase) Execute(ctx context.Context, id uuid.UUID, body models.Product) error {
-	return uc.repo.Update(ctx, id, body)
+// Execute replaces the fields of the stored product, which validates them,
+// and saves it. An update that changes nothing leaves the product, its
+// version and its history as they are.
+func (uc *UpdateProductUseCase) Execute(ctx context.Context, id uuid.UUID, product models.Product, expectedVersion *int) error {
+	if err := authorization.Authorize(ctx, shared_models.PermissionProductsWrite); err != nil {
+		return err
+	}
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := uc.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if stored == nil {
+			return shared_handlers.ErrNotFound
+		}
+		changes, err := stored.Update(product.Sku(), product.Name(), product.Category(), product.Price())
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			return checkExpectedVersion(stored, expectedVersion)
+		}
+		if err := uc.repo.Update(ctx, id, stored, expectedVersion); err != nil {
+			return err
+		}
+		return recordProductChange(ctx, uc.auditRepo, uc.outbox, stored, models.ProductAuditUpdated, changes)
+	})
 }
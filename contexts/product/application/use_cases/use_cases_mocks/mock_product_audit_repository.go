@@ -0,0 +1,31 @@
+package use_cases_mocks
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProductAuditRepository struct {
+	mock.Mock
+}
+
+func NewMockProductAuditRepository() *MockProductAuditRepository {
+	return &MockProductAuditRepository{}
+}
+
+func (m *MockProductAuditRepository) Record(ctx context.Context, entry models.ProductAuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockProductAuditRepository) GetByProductID(ctx context.Context, query models.ProductAuditQuery) (models.ProductAuditPage, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(models.ProductAuditPage), args.Error(1)
+}
+
+// SetupRecordMatching expects an entry that satisfies matcher.
+func (m *MockProductAuditRepository) SetupRecordMatching(matcher func(entry models.ProductAuditEntry) bool) *mock.Call {
+	return m.On("Record", mock.Anything, mock.MatchedBy(matcher)).Return(nil)
+}
@@ -2,6 +2,7 @@ package use_cases_mocks
 
 import (
 	"context"
+	"iter"
 
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
 	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
@@ -26,29 +27,57 @@ func (m *MockProductRepository) GetByID(ctx context.Context, id uuid.UUID) (mode
 	return args.Get(0).(models.Product), args.Error(1)
 }
 
-func (m *MockProductRepository) GetAll(ctx context.Context, cursor *string, limit *int) ([]models.Product, *string, error) {
-	args := m.Called(ctx, cursor, limit)
+func (m *MockProductRepository) GetTrashedByID(ctx context.Context, id uuid.UUID) (models.Product, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
-		return nil, args.Get(1).(*string), args.Error(2)
+		return nil, args.Error(1)
 	}
-	return args.Get(0).([]models.Product), args.Get(1).(*string), args.Error(2)
+	return args.Get(0).(models.Product), args.Error(1)
 }
 
-func (m *MockProductRepository) Update(ctx context.Context, id uuid.UUID, product models.Product) error {
-	args := m.Called(ctx, id, product)
+func (m *MockProductRepository) GetAll(ctx context.Context, query models.ProductQuery) (models.ProductPage, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(models.ProductPage), args.Error(1)
+}
+
+func (m *MockProductRepository) Search(ctx context.Context, query models.ProductSearchQuery) (models.ProductSearchPage, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(models.ProductSearchPage), args.Error(1)
+}
+
+func (m *MockProductRepository) Update(ctx context.Context, id uuid.UUID, product models.Product, expectedVersion *int) error {
+	args := m.Called(ctx, id, product, expectedVersion)
 	return args.Error(0)
 }
 
-func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	args := m.Called(ctx, id)
+func (m *MockProductRepository) Delete(ctx context.Context, id uuid.UUID, expectedVersion *int) error {
+	args := m.Called(ctx, id, expectedVersion)
 	return args.Error(0)
 }
 
-func (m *MockProductRepository) Patch(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
-	args := m.Called(ctx, id, updates)
+func (m *MockProductRepository) Restore(ctx context.Context, product models.Product) error {
+	args := m.Called(ctx, product)
 	return args.Error(0)
 }
 
+func (m *MockProductRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) ExistingSkus(ctx context.Context, skus []string) ([]string, error) {
+	args := m.Called(ctx, skus)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockProductRepository) Export(ctx context.Context, query models.ProductExportQuery) iter.Seq2[models.Product, error] {
+	args := m.Called(ctx, query)
+	return args.Get(0).(iter.Seq2[models.Product, error])
+}
+
 func NewMockProductRepository() *MockProductRepository {
 	return &MockProductRepository{}
 }
@@ -61,6 +90,10 @@ func (m *MockProductRepository) SetupCreateError(product models.Product, err err
 	return m.On("Create", mock.Anything, product).Return(err)
 }
 
+func (m *MockProductRepository) SetupGetByIDSuccess(id uuid.UUID, product models.Product) *mock.Call {
+	return m.On("GetByID", mock.Anything, id).Return(product, nil)
+}
+
 func (m *MockProductRepository) SetupGetByIDNotFound(id uuid.UUID) *mock.Call {
 	return m.On("GetByID", mock.Anything, id).Return(nil, shared_handlers.ErrorCodeNotFound)
 }
@@ -69,22 +102,66 @@ func (m *MockProductRepository) SetupGetByIDError(err error) *mock.Call {
 	return m.On("GetByID", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(nil, err)
 }
 
-func (m *MockProductRepository) SetupDeleteSuccess(id uuid.UUID) *mock.Call {
-	return m.On("Delete", mock.Anything, id).Return(nil)
+func (m *MockProductRepository) SetupGetTrashedByIDSuccess(id uuid.UUID, product models.Product) *mock.Call {
+	return m.On("GetTrashedByID", mock.Anything, id).Return(product, nil)
 }
 
-func (m *MockProductRepository) SetupDeleteError(id uuid.UUID, err error) *mock.Call {
-	return m.On("Delete", mock.Anything, id).Return(err)
+func (m *MockProductRepository) SetupGetTrashedByIDNotFound(id uuid.UUID) *mock.Call {
+	return m.On("GetTrashedByID", mock.Anything, id).Return(nil, nil)
 }
 
-func (m *MockProductRepository) SetupPatchError(id uuid.UUID, err error) *mock.Call {
-	return m.On("Patch", mock.Anything, id, mock.AnythingOfType("map[string]interface {}")).Return(err)
+func (m *MockProductRepository) SetupDeleteSuccess(id uuid.UUID) *mock.Call {
+	return m.On("Delete", mock.Anything, id, mock.Anything).Return(nil)
+}
+
+func (m *MockProductRepository) SetupDeleteError(id uuid.UUID, err error) *mock.Call {
+	return m.On("Delete", mock.Anything, id, mock.Anything).Return(err)
 }
 
 func (m *MockProductRepository) SetupUpdateSuccess(id uuid.UUID, product models.Product) *mock.Call {
-	return m.On("Update", mock.Anything, id, product).Return(nil)
+	return m.On("Update", mock.Anything, id, product, mock.Anything).Return(nil)
 }
 
 func (m *MockProductRepository) SetupUpdateError(id uuid.UUID, product models.Product, err error) *mock.Call {
-	return m.On("Update", mock.Anything, id, product).Return(err)
+	return m.On("Update", mock.Anything, id, product, mock.Anything).Return(err)
+}
+
+func (m *MockProductRepository) SetupRestoreSuccess(id uuid.UUID) *mock.Call {
+	return m.On("Restore", mock.Anything, productWithID(id)).Return(nil)
+}
+
+func (m *MockProductRepository) SetupRestoreError(id uuid.UUID, err error) *mock.Call {
+	return m.On("Restore", mock.Anything, productWithID(id)).Return(err)
+}
+
+func productWithID(id uuid.UUID) interface{} {
+	return mock.MatchedBy(func(product models.Product) bool {
+		return product.ID() == id
+	})
+}
+
+func (m *MockProductRepository) SetupPurgeSuccess(id uuid.UUID) *mock.Call {
+	return m.On("Purge", mock.Anything, id).Return(nil)
+}
+
+func (m *MockProductRepository) SetupPurgeError(id uuid.UUID, err error) *mock.Call {
+	return m.On("Purge", mock.Anything, id).Return(err)
+}
+
+func (m *MockProductRepository) SetupExistingSkus(skus []string, existing []string) *mock.Call {
+	return m.On("ExistingSkus", mock.Anything, skus).Return(existing, nil)
+}
+
+// SetupExport makes Export yield products and then err, if not nil.
+func (m *MockProductRepository) SetupExport(query models.ProductExportQuery, products []models.Product, err error) *mock.Call {
+	return m.On("Export", mock.Anything, query).Return(iter.Seq2[models.Product, error](func(yield func(models.Product, error) bool) {
+		for _, product := range products {
+			if !yield(product, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(nil, err)
+		}
+	}))
 }
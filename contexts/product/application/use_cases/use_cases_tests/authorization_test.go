@@ -0,0 +1,111 @@
+package use_cases_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// authorizedContext is the context of a caller allowed to do anything with
+// products.
+func authorizedContext() context.Context {
+	return contextWithRole(shared_models.RoleAdmin)
+}
+
+func contextWithRole(role shared_models.Role) context.Context {
+	return request_context.WithPrincipal(context.Background(), request_context.Principal{
+		Subject: string(role),
+		Roles:   []string{string(role)},
+	})
+}
+
+func TestProductUseCaseAuthorization(t *testing.T) {
+	t.Run("should let viewers read products", func(t *testing.T) {
+		// Arrange
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := use_cases.NewGetOneProductUseCase(mockRepo)
+		product := models_mothers.NewProductMother().MustBuild()
+		mockRepo.SetupGetByIDSuccess(product.ID(), product)
+
+		// Act
+		found, err := useCase.Execute(contextWithRole(shared_models.RoleViewer), product.ID())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, product, found)
+	})
+
+	t.Run("should forbid viewers to create products", func(t *testing.T) {
+		// Arrange
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := use_cases.NewCreateProductUseCase(mockRepo, use_cases_mocks.NewMockProductAuditRepository(), interfaces_mocks.NewMockEventOutbox(), interfaces_mocks.NewMockTransactionManager())
+
+		// Act
+		err := useCase.Execute(contextWithRole(shared_models.RoleViewer), models_mothers.NewProductMother().MustBuild())
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should forbid editors to delete products", func(t *testing.T) {
+		// Arrange
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := use_cases.NewDeleteProductUseCase(mockRepo, use_cases_mocks.NewMockProductAuditRepository(), interfaces_mocks.NewMockEventOutbox(), interfaces_mocks.NewMockTransactionManager())
+
+		// Act
+		err := useCase.Execute(contextWithRole(shared_models.RoleEditor), uuid.New(), nil)
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should accept a scope in place of a role", func(t *testing.T) {
+		// Arrange
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		mockOutbox := interfaces_mocks.NewMockEventOutbox()
+		useCase := use_cases.NewPurgeProductUseCase(mockRepo, mockAuditRepo, mockOutbox, interfaces_mocks.NewMockTransactionManager())
+		id := uuid.New()
+		mockRepo.SetupGetTrashedByIDSuccess(id, models_mothers.NewProductMother().WithID(id).WithDeletedAt(time.Now().UTC()).MustBuild())
+		mockRepo.On("Purge", mock.Anything, id).Return(nil)
+		mockOutbox.SetupSaveEventNames(models.ProductPurgedEventName)
+		mockAuditRepo.SetupRecordMatching(func(entry models.ProductAuditEntry) bool {
+			return entry.Actor == "cleanup-job"
+		})
+		ctx := request_context.WithPrincipal(context.Background(), request_context.Principal{
+			Subject: "cleanup-job",
+			Scopes:  []string{string(shared_models.PermissionProductsDelete)},
+		})
+
+		// Act
+		err := useCase.Execute(ctx, id)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("should refuse callers that were not identified", func(t *testing.T) {
+		// Arrange
+		useCase := use_cases.NewGetOneProductUseCase(use_cases_mocks.NewMockProductRepository())
+
+		// Act
+		_, err := useCase.Execute(context.Background(), uuid.New())
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrUnauthenticated)
+	})
+}
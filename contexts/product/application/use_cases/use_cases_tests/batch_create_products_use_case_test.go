@@ -0,0 +1,128 @@
+package use_cases_tests
+
+import (
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newBatchCreateProductsUseCase(mockRepo *use_cases_mocks.MockProductRepository, mockTxManager *interfaces_mocks.MockTransactionManager) *use_cases.BatchCreateProductsUseCase {
+	mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+	mockAuditRepo.On("Record", mock.Anything, mock.Anything).Return(nil)
+	mockOutbox := interfaces_mocks.NewMockEventOutbox()
+	mockOutbox.SetupSaveSuccess()
+	createUseCase := use_cases.NewCreateProductUseCase(mockRepo, mockAuditRepo, mockOutbox, mockTxManager)
+	return use_cases.NewBatchCreateProductsUseCase(createUseCase, mockTxManager)
+}
+
+func TestBatchCreateProductsUseCase_Execute(t *testing.T) {
+	repoErr := shared_models.DomainError{Code: "DATABASE_ERROR", Message: "Database connection failed"}
+
+	t.Run("should create every item in all-or-nothing mode", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := newBatchCreateProductsUseCase(mockRepo, interfaces_mocks.NewMockTransactionManager())
+
+		first := models_mothers.NewProductMother().WithID(uuid.New()).MustBuild()
+		second := models_mothers.NewProductMother().WithID(uuid.New()).MustBuild()
+		mockRepo.SetupCreateSuccess(first)
+		mockRepo.SetupCreateSuccess(second)
+		items := []models.ProductBatchCreateItem{{Index: 0, Product: first}, {Index: 1, Product: second}}
+
+		// Act
+		results, err := useCase.Execute(ctx, models.ProductBatchAllOrNothing, items)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ProductBatchResult{
+			{Index: 0, ProductID: first.ID()},
+			{Index: 1, ProductID: second.ID()},
+		}, results)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not create anything when an item was rejected in all-or-nothing mode", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := newBatchCreateProductsUseCase(mockRepo, interfaces_mocks.NewMockTransactionManager())
+
+		items := []models.ProductBatchCreateItem{
+			{Index: 0, Product: models_mothers.NewProductMother().MustBuild()},
+			{Index: 1, Err: shared_handlers.ErrInvalidPayload},
+		}
+
+		// Act
+		results, err := useCase.Execute(ctx, models.ProductBatchAllOrNothing, items)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, shared_handlers.ErrBatchItemAborted, results[0].Err)
+		assert.Equal(t, uuid.Nil, results[0].ProductID)
+		assert.Equal(t, shared_handlers.ErrInvalidPayload, results[1].Err)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should stop at the first failure and abort the rest in all-or-nothing mode", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := newBatchCreateProductsUseCase(mockRepo, interfaces_mocks.NewMockTransactionManager())
+
+		first := models_mothers.NewProductMother().WithID(uuid.New()).MustBuild()
+		second := models_mothers.NewProductMother().WithID(uuid.New()).MustBuild()
+		third := models_mothers.NewProductMother().WithID(uuid.New()).MustBuild()
+		mockRepo.SetupCreateSuccess(first)
+		mockRepo.SetupCreateError(second, repoErr)
+		items := []models.ProductBatchCreateItem{{Index: 0, Product: first}, {Index: 1, Product: second}, {Index: 2, Product: third}}
+
+		// Act
+		results, err := useCase.Execute(ctx, models.ProductBatchAllOrNothing, items)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, shared_handlers.ErrBatchItemAborted, results[0].Err)
+		assert.Equal(t, repoErr, results[1].Err)
+		assert.Equal(t, shared_handlers.ErrBatchItemAborted, results[2].Err)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, third)
+	})
+
+	t.Run("should create the items that can be created in best-effort mode", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := newBatchCreateProductsUseCase(mockRepo, interfaces_mocks.NewMockTransactionManager())
+
+		first := models_mothers.NewProductMother().WithID(uuid.New()).MustBuild()
+		second := models_mothers.NewProductMother().WithID(uuid.New()).MustBuild()
+		mockRepo.SetupCreateError(first, repoErr)
+		mockRepo.SetupCreateSuccess(second)
+		items := []models.ProductBatchCreateItem{
+			{Index: 0, Product: first},
+			{Index: 1, Err: shared_handlers.ErrInvalidPayload},
+			{Index: 2, Product: second},
+		}
+
+		// Act
+		results, err := useCase.Execute(ctx, models.ProductBatchBestEffort, items)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ProductBatchResult{
+			{Index: 0, Err: repoErr},
+			{Index: 1, Err: shared_handlers.ErrInvalidPayload},
+			{Index: 2, ProductID: second.ID()},
+		}, results)
+		mockRepo.AssertExpectations(t)
+	})
+}
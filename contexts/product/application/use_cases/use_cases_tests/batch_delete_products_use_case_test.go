@@ -0,0 +1,52 @@
+package use_cases_tests
+
+import (
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBatchDeleteProductsUseCase_Execute(t *testing.T) {
+	t.Run("should report each item in best-effort mode", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		mockTxManager := interfaces_mocks.NewMockTransactionManager()
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		mockOutbox := interfaces_mocks.NewMockEventOutbox()
+		deleteUseCase := use_cases.NewDeleteProductUseCase(mockRepo, mockAuditRepo, mockOutbox, mockTxManager)
+		useCase := use_cases.NewBatchDeleteProductsUseCase(deleteUseCase, mockTxManager)
+
+		deletedID, staleID := uuid.New(), uuid.New()
+		staleVersion := 1
+		mockRepo.SetupGetByIDSuccess(deletedID, models_mothers.NewProductMother().WithID(deletedID).MustBuild())
+		mockRepo.SetupGetByIDSuccess(staleID, models_mothers.NewProductMother().WithID(staleID).MustBuild())
+		mockRepo.SetupDeleteSuccess(deletedID)
+		mockAuditRepo.SetupRecordMatching(func(entry models.ProductAuditEntry) bool { return entry.ProductID == deletedID })
+		mockOutbox.SetupSaveSuccess()
+		mockRepo.SetupDeleteError(staleID, shared_handlers.ErrPreconditionFailed)
+		items := []models.ProductBatchDeleteItem{
+			{Index: 0, ID: deletedID},
+			{Index: 1, ID: staleID, ExpectedVersion: &staleVersion},
+		}
+
+		// Act
+		results, err := useCase.Execute(ctx, models.ProductBatchBestEffort, items)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ProductBatchResult{
+			{Index: 0, ProductID: deletedID},
+			{Index: 1, ProductID: staleID, Err: shared_handlers.ErrPreconditionFailed},
+		}, results)
+		mockRepo.AssertCalled(t, "Delete", mock.Anything, staleID, &staleVersion)
+	})
+}
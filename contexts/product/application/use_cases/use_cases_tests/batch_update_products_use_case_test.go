@@ -0,0 +1,147 @@
+package use_cases_tests
+
+import (
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newBatchUpdateProductsUseCase(mockRepo *use_cases_mocks.MockProductRepository, mockTxManager *interfaces_mocks.MockTransactionManager) *use_cases.BatchUpdateProductsUseCase {
+	mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+	mockAuditRepo.On("Record", mock.Anything, mock.Anything).Return(nil)
+	mockOutbox := interfaces_mocks.NewMockEventOutbox()
+	mockOutbox.SetupSaveSuccess()
+	updateUseCase := use_cases.NewUpdateProductUseCase(mockRepo, mockAuditRepo, mockOutbox, mockTxManager)
+	return use_cases.NewBatchUpdateProductsUseCase(updateUseCase, mockTxManager)
+}
+
+// storedForBatchUpdate makes the repository return a product with id, which
+// the batch then renames.
+func storedForBatchUpdate(mockRepo *use_cases_mocks.MockProductRepository, id uuid.UUID) models.Product {
+	stored := models_mothers.NewProductMother().WithID(id).MustBuild()
+	mockRepo.SetupGetByIDSuccess(id, stored)
+	return stored
+}
+
+func renamedProduct(id uuid.UUID) models.Product {
+	return models_mothers.NewProductMother().WithID(id).WithName("Renamed Product").MustBuild()
+}
+
+func TestBatchUpdateProductsUseCase_Execute(t *testing.T) {
+	repoErr := shared_models.DomainError{Code: "DATABASE_ERROR", Message: "Database connection failed"}
+
+	t.Run("should update every item in all-or-nothing mode", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := newBatchUpdateProductsUseCase(mockRepo, interfaces_mocks.NewMockTransactionManager())
+
+		firstID, secondID := uuid.New(), uuid.New()
+		mockRepo.SetupUpdateSuccess(firstID, storedForBatchUpdate(mockRepo, firstID))
+		mockRepo.SetupUpdateSuccess(secondID, storedForBatchUpdate(mockRepo, secondID))
+		items := []models.ProductBatchUpdateItem{
+			{Index: 0, ID: firstID, Product: renamedProduct(firstID)},
+			{Index: 1, ID: secondID, Product: renamedProduct(secondID)},
+		}
+
+		// Act
+		results, err := useCase.Execute(ctx, models.ProductBatchAllOrNothing, items)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ProductBatchResult{
+			{Index: 0, ProductID: firstID},
+			{Index: 1, ProductID: secondID},
+		}, results)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not update anything when an item was rejected in all-or-nothing mode", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := newBatchUpdateProductsUseCase(mockRepo, interfaces_mocks.NewMockTransactionManager())
+
+		id, rejectedID := uuid.New(), uuid.New()
+		items := []models.ProductBatchUpdateItem{
+			{Index: 0, ID: id, Product: renamedProduct(id)},
+			{Index: 1, ID: rejectedID, Err: shared_handlers.ErrInvalidPayload},
+		}
+
+		// Act
+		results, err := useCase.Execute(ctx, models.ProductBatchAllOrNothing, items)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ProductBatchResult{
+			{Index: 0, ProductID: id, Err: shared_handlers.ErrBatchItemAborted},
+			{Index: 1, ProductID: rejectedID, Err: shared_handlers.ErrInvalidPayload},
+		}, results)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should stop at the first failure and abort the rest in all-or-nothing mode", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := newBatchUpdateProductsUseCase(mockRepo, interfaces_mocks.NewMockTransactionManager())
+
+		firstID, secondID, thirdID := uuid.New(), uuid.New(), uuid.New()
+		mockRepo.SetupUpdateSuccess(firstID, storedForBatchUpdate(mockRepo, firstID))
+		mockRepo.SetupUpdateError(secondID, storedForBatchUpdate(mockRepo, secondID), repoErr)
+		items := []models.ProductBatchUpdateItem{
+			{Index: 0, ID: firstID, Product: renamedProduct(firstID)},
+			{Index: 1, ID: secondID, Product: renamedProduct(secondID)},
+			{Index: 2, ID: thirdID, Product: renamedProduct(thirdID)},
+		}
+
+		// Act
+		results, err := useCase.Execute(ctx, models.ProductBatchAllOrNothing, items)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, shared_handlers.ErrBatchItemAborted, results[0].Err)
+		assert.Equal(t, repoErr, results[1].Err)
+		assert.Equal(t, shared_handlers.ErrBatchItemAborted, results[2].Err)
+		mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, thirdID)
+	})
+
+	t.Run("should report each item in best-effort mode", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := newBatchUpdateProductsUseCase(mockRepo, interfaces_mocks.NewMockTransactionManager())
+
+		updatedID, staleID, missingID := uuid.New(), uuid.New(), uuid.New()
+		staleVersion := 1
+		mockRepo.SetupUpdateSuccess(updatedID, storedForBatchUpdate(mockRepo, updatedID))
+		mockRepo.SetupUpdateError(staleID, storedForBatchUpdate(mockRepo, staleID), shared_handlers.ErrPreconditionFailed)
+		mockRepo.On("GetByID", mock.Anything, missingID).Return(nil, nil)
+		items := []models.ProductBatchUpdateItem{
+			{Index: 0, ID: updatedID, Product: renamedProduct(updatedID)},
+			{Index: 1, ID: staleID, Product: renamedProduct(staleID), ExpectedVersion: &staleVersion},
+			{Index: 2, ID: missingID, Product: renamedProduct(missingID)},
+		}
+
+		// Act
+		results, err := useCase.Execute(ctx, models.ProductBatchBestEffort, items)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ProductBatchResult{
+			{Index: 0, ProductID: updatedID},
+			{Index: 1, ProductID: staleID, Err: shared_handlers.ErrPreconditionFailed},
+			{Index: 2, ProductID: missingID, Err: shared_handlers.ErrNotFound},
+		}, results)
+		mockRepo.AssertCalled(t, "Update", mock.Anything, staleID, mock.Anything, &staleVersion)
+	})
+}
@@ -1,39 +1,63 @@
 package use_cases_tests
 
 import (
-	"context"
 	"testing"
 
 	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
 	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
 	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateProductUseCase_Execute(t *testing.T) {
 	t.Run("should create product successfully", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := request_context.WithRequestID(authorizedContext(), "req-1")
+		ctx = request_context.WithActor(ctx, "alice")
 		mockRepo := use_cases_mocks.NewMockProductRepository()
-		product := models_mothers.NewProductMother().MustBuild()
-		useCase := use_cases.NewCreateProductUseCase(mockRepo)
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		mockOutbox := interfaces_mocks.NewMockEventOutbox()
+		mockTxManager := interfaces_mocks.NewMockTransactionManager()
+		product, err := models.NewProduct(uuid.New(), "SKU-001", "Keyboard", "Electronics", decimal.NewFromInt(30))
+		require.NoError(t, err)
+		useCase := use_cases.NewCreateProductUseCase(mockRepo, mockAuditRepo, mockOutbox, mockTxManager)
 
 		mockRepo.SetupCreateSuccess(product)
+		mockOutbox.SetupSaveEventNames(models.ProductCreatedEventName)
+		mockAuditRepo.SetupRecordMatching(func(entry models.ProductAuditEntry) bool {
+			return entry.ProductID == product.ID() &&
+				entry.Action == models.ProductAuditCreated &&
+				entry.Actor == "alice" &&
+				entry.RequestID == "req-1" &&
+				len(entry.Changes) == 4
+		})
 
 		// Act
-		err := useCase.Execute(ctx, product)
+		err = useCase.Execute(ctx, product)
 
 		// Assert
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
+		mockAuditRepo.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+		mockTxManager.AssertCalled(t, "WithinTransaction", ctx, mock.Anything)
 	})
 
 	t.Run("should return error when repository fails", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorizedContext()
 		mockRepo := use_cases_mocks.NewMockProductRepository()
-		useCase := use_cases.NewCreateProductUseCase(mockRepo)
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		mockOutbox := interfaces_mocks.NewMockEventOutbox()
+		useCase := use_cases.NewCreateProductUseCase(mockRepo, mockAuditRepo, mockOutbox, interfaces_mocks.NewMockTransactionManager())
 
 		product := models_mothers.NewProductMother().MustBuild()
 		expectedError := shared_models.DomainError{
@@ -51,5 +75,6 @@ func TestCreateProductUseCase_Execute(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, expectedError, err)
 		mockRepo.AssertExpectations(t)
+		mockAuditRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
 	})
 }
@@ -1,38 +1,77 @@
 package use_cases_tests
 
 import (
-	"context"
 	"testing"
 
 	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
 	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
 	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestDeleteProductUseCase_Execute(t *testing.T) {
 	t.Run("should delete product successfully", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorizedContext()
 		mockRepo := use_cases_mocks.NewMockProductRepository()
-		useCase := use_cases.NewDeleteProductUseCase(mockRepo)
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		mockOutbox := interfaces_mocks.NewMockEventOutbox()
+		useCase := use_cases.NewDeleteProductUseCase(mockRepo, mockAuditRepo, mockOutbox, interfaces_mocks.NewMockTransactionManager())
 
 		productID := uuid.New()
+		mockRepo.SetupGetByIDSuccess(productID, models_mothers.NewProductMother().WithID(productID).MustBuild())
 		mockRepo.SetupDeleteSuccess(productID)
+		mockOutbox.SetupSaveEventNames(models.ProductDeletedEventName)
+		mockAuditRepo.SetupRecordMatching(func(entry models.ProductAuditEntry) bool {
+			return entry.ProductID == productID &&
+				entry.Action == models.ProductAuditDeleted &&
+				entry.Actor == "admin" &&
+				len(entry.Changes) == 4 &&
+				entry.Changes[0].To == nil
+		})
 		// Act
-		err := useCase.Execute(ctx, productID)
+		err := useCase.Execute(ctx, productID, nil)
 
 		// Assert
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
+		mockAuditRepo.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("should return not found when the product does not exist", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		mockOutbox := interfaces_mocks.NewMockEventOutbox()
+		useCase := use_cases.NewDeleteProductUseCase(mockRepo, mockAuditRepo, mockOutbox, interfaces_mocks.NewMockTransactionManager())
+
+		productID := uuid.New()
+		mockRepo.On("GetByID", mock.Anything, productID).Return(nil, nil)
+
+		// Act
+		err := useCase.Execute(ctx, productID, nil)
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrNotFound)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+		mockAuditRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when repository fails", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorizedContext()
 		mockRepo := use_cases_mocks.NewMockProductRepository()
-		useCase := use_cases.NewDeleteProductUseCase(mockRepo)
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		mockOutbox := interfaces_mocks.NewMockEventOutbox()
+		useCase := use_cases.NewDeleteProductUseCase(mockRepo, mockAuditRepo, mockOutbox, interfaces_mocks.NewMockTransactionManager())
 
 		productID := uuid.New()
 		expectedError := shared_models.DomainError{
@@ -40,14 +79,16 @@ func TestDeleteProductUseCase_Execute(t *testing.T) {
 			Message: "Database connection failed",
 		}
 
+		mockRepo.SetupGetByIDSuccess(productID, models_mothers.NewProductMother().WithID(productID).MustBuild())
 		mockRepo.SetupDeleteError(productID, expectedError)
 
 		// Act
-		err := useCase.Execute(ctx, productID)
+		err := useCase.Execute(ctx, productID, nil)
 
 		// Assert
 		assert.Error(t, err)
 		assert.Equal(t, expectedError, err)
 		mockRepo.AssertExpectations(t)
+		mockAuditRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
 	})
 }
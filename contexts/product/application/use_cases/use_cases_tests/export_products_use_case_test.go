@@ -0,0 +1,43 @@
+package use_cases_tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportProductsUseCase_Execute(t *testing.T) {
+	t.Run("should yield the products of the repository until it fails", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := use_cases.NewExportProductsUseCase(mockRepo)
+
+		category := "Electronics"
+		query := models.ProductExportQuery{Filter: models.ProductFilter{Category: &category}, Sort: models.DefaultProductSort()}
+		products := []models.Product{models_mothers.NewProductMother().MustBuild(), models_mothers.NewProductMother().MustBuild()}
+		expectedError := errors.New("connection reset")
+		mockRepo.SetupExport(query, products, expectedError)
+
+		// Act
+		var exported []models.Product
+		var exportErr error
+		for product, err := range useCase.Execute(ctx, query) {
+			if err != nil {
+				exportErr = err
+				break
+			}
+			exported = append(exported, product)
+		}
+
+		// Assert
+		assert.Equal(t, products, exported)
+		assert.Equal(t, expectedError, exportErr)
+		mockRepo.AssertExpectations(t)
+	})
+}
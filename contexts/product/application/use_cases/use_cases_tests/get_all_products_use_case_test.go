@@ -1,7 +1,6 @@
 package use_cases_tests
 
 import (
-	"context"
 	"errors"
 	"testing"
 
@@ -15,50 +14,50 @@ import (
 func GetAllProductsUseCase_Execute(t *testing.T) {
 	t.Run("should get all products successfully", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorizedContext()
 		mockRepo := use_cases_mocks.NewMockProductRepository()
 		useCase := use_cases.NewGetAllProductsUseCase(mockRepo)
 
-		cursor := "someCursor"
 		limit := 10
+		query := models.ProductQuery{Sort: models.DefaultProductSort(), Limit: &limit}
 
 		expectedProducts := []models.Product{
 			models_mothers.NewProductMother().MustBuild(),
 			models_mothers.NewProductMother().MustBuild(),
 		}
-		nextCursor := "nextCursor"
+		nextCursor := models.NewProductCursor(expectedProducts[1], query.Sort, false)
+		expectedPage := models.ProductPage{Items: expectedProducts, Next: &nextCursor}
 
-		mockRepo.On("GetAll", ctx, &cursor, &limit).Return(expectedProducts, &nextCursor, nil)
+		mockRepo.On("GetAll", ctx, query).Return(expectedPage, nil)
 
 		// Act
-		products, next, err := useCase.Execute(ctx, &cursor, &limit)
+		page, err := useCase.Execute(ctx, query)
 
 		// Assert
 		assert.NoError(t, err)
-		assert.Equal(t, expectedProducts, products)
-		assert.Equal(t, &nextCursor, next)
+		assert.Equal(t, expectedPage, page)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("should return error when repository fails", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorizedContext()
 		mockRepo := use_cases_mocks.NewMockProductRepository()
 		useCase := use_cases.NewGetAllProductsUseCase(mockRepo)
 
-		cursor := "someCursor"
 		limit := 10
+		query := models.ProductQuery{Sort: models.DefaultProductSort(), Limit: &limit}
 		expectedError := errors.New("Database connection failed")
 
-		mockRepo.On("GetAll", ctx, &cursor, &limit).Return(nil, nil, expectedError)
+		mockRepo.On("GetAll", ctx, query).Return(models.ProductPage{}, expectedError)
 
 		// Act
-		products, next, err := useCase.Execute(ctx, &cursor, &limit)
+		page, err := useCase.Execute(ctx, query)
 
 		// Assert
 		assert.Error(t, err)
-		assert.Nil(t, products)
-		assert.Nil(t, next)
+		assert.Empty(t, page.Items)
+		assert.Nil(t, page.Next)
 		assert.Equal(t, expectedError, err)
 		mockRepo.AssertExpectations(t)
 	})
@@ -1,7 +1,6 @@
 package use_cases_tests
 
 import (
-	"context"
 	"testing"
 
 	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
@@ -15,7 +14,7 @@ import (
 func GetOneProductUseCase_Execute(t *testing.T) {
 	t.Run("should return product by ID successfully", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorizedContext()
 		mockRepo := use_cases_mocks.NewMockProductRepository()
 		useCase := use_cases.NewGetOneProductUseCase(mockRepo)
 
@@ -35,7 +34,7 @@ func GetOneProductUseCase_Execute(t *testing.T) {
 
 	t.Run("should return error when product not found", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorizedContext()
 		mockRepo := use_cases_mocks.NewMockProductRepository()
 		useCase := use_cases.NewGetOneProductUseCase(mockRepo)
 
@@ -0,0 +1,32 @@
+package use_cases_tests
+
+import (
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProductHistoryUseCase_Execute(t *testing.T) {
+	t.Run("should return the product history page", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		useCase := use_cases.NewGetProductHistoryUseCase(mockAuditRepo)
+
+		query := models.ProductAuditQuery{ProductID: uuid.New()}
+		expectedPage := models.ProductAuditPage{Items: []models.ProductAuditEntry{{ID: uuid.New(), ProductID: query.ProductID}}}
+		mockAuditRepo.On("GetByProductID", ctx, query).Return(expectedPage, nil)
+
+		// Act
+		page, err := useCase.Execute(ctx, query)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedPage, page)
+		mockAuditRepo.AssertExpectations(t)
+	})
+}
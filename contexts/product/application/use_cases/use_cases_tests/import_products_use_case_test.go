@@ -0,0 +1,119 @@
+package use_cases_tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newImportProductsUseCase(mockRepo *use_cases_mocks.MockProductRepository) *use_cases.ImportProductsUseCase {
+	mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+	mockAuditRepo.On("Record", mock.Anything, mock.Anything).Return(nil)
+	mockOutbox := interfaces_mocks.NewMockEventOutbox()
+	mockOutbox.SetupSaveSuccess()
+	createUseCase := use_cases.NewCreateProductUseCase(mockRepo, mockAuditRepo, mockOutbox, interfaces_mocks.NewMockTransactionManager())
+	return use_cases.NewImportProductsUseCase(mockRepo, createUseCase)
+}
+
+func newProductImportRow(line int, sku string) models.ProductImportRow {
+	product := models_mothers.NewProductMother().WithID(uuid.New()).WithSku(sku).MustBuild()
+	return models.ProductImportRow{Line: line, Sku: sku, Product: product}
+}
+
+func TestImportProductsUseCase_Execute(t *testing.T) {
+	t.Run("should reject invalid lines, repeated SKUs and existing SKUs and create the rest", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := newImportProductsUseCase(mockRepo)
+
+		rows := []models.ProductImportRow{
+			newProductImportRow(2, "NEW-001"),
+			{Line: 3, Sku: "BAD-001", Err: shared_handlers.ErrInvalidImportPrice},
+			newProductImportRow(4, "NEW-001"),
+			newProductImportRow(5, "OLD-001"),
+		}
+		mockRepo.SetupExistingSkus([]string{"NEW-001", "OLD-001"}, []string{"OLD-001"})
+		mockRepo.SetupCreateSuccess(rows[0].Product)
+
+		// Act
+		report, err := useCase.Execute(ctx, rows, false)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ProductImportRowResult{
+			{Line: 2, Sku: "NEW-001", ProductID: rows[0].Product.ID()},
+			{Line: 3, Sku: "BAD-001", Err: shared_handlers.ErrInvalidImportPrice},
+			{Line: 4, Sku: "NEW-001", Err: models.ErrProductSkuDuplicatedInFile},
+			{Line: 5, Sku: "OLD-001", Err: models.ErrProductSkuAlreadyExists},
+		}, report.Rows)
+		assert.Equal(t, 1, report.Accepted())
+		assert.Equal(t, 3, report.Rejected())
+		mockRepo.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("should not create anything on a dry run", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := newImportProductsUseCase(mockRepo)
+
+		rows := []models.ProductImportRow{newProductImportRow(2, "NEW-001")}
+		mockRepo.SetupExistingSkus([]string{"NEW-001"}, []string{})
+
+		// Act
+		report, err := useCase.Execute(ctx, rows, true)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, []models.ProductImportRowResult{{Line: 2, Sku: "NEW-001"}}, report.Rows)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should report a line whose creation fails and go on", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := newImportProductsUseCase(mockRepo)
+
+		createErr := errors.New("duplicate key value violates unique constraint")
+		rows := []models.ProductImportRow{newProductImportRow(2, "NEW-001"), newProductImportRow(3, "NEW-002")}
+		mockRepo.SetupExistingSkus([]string{"NEW-001", "NEW-002"}, []string{})
+		mockRepo.SetupCreateError(rows[0].Product, createErr)
+		mockRepo.SetupCreateSuccess(rows[1].Product)
+
+		// Act
+		report, err := useCase.Execute(ctx, rows, false)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, createErr, report.Rows[0].Err)
+		assert.Equal(t, rows[1].Product.ID(), report.Rows[1].ProductID)
+	})
+
+	t.Run("should return error when existing SKUs cannot be read", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := newImportProductsUseCase(mockRepo)
+
+		expectedError := errors.New("database is down")
+		mockRepo.On("ExistingSkus", mock.Anything, mock.Anything).Return(nil, expectedError)
+
+		// Act
+		_, err := useCase.Execute(ctx, []models.ProductImportRow{newProductImportRow(2, "NEW-001")}, false)
+
+		// Assert
+		assert.Equal(t, expectedError, err)
+	})
+}
@@ -1,48 +1,125 @@
 package use_cases_tests
 
 import (
-	"context"
 	"errors"
 	"testing"
 
 	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
 	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
-func PatchProductUseCase_Execute(t *testing.T) {
+func TestPatchProductUseCase_Execute(t *testing.T) {
 	t.Run("should patch product successfully", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorizedContext()
 		mockRepo := use_cases_mocks.NewMockProductRepository()
-		useCase := use_cases.NewPatchProductUseCase(mockRepo)
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		mockOutbox := interfaces_mocks.NewMockEventOutbox()
+		useCase := use_cases.NewPatchProductUseCase(mockRepo, mockAuditRepo, mockOutbox, interfaces_mocks.NewMockTransactionManager())
 
 		productID := uuid.New()
-		patchData := map[string]interface{}{"name": "Updated Product Name"}
+		name := "Updated Product Name"
+		stored := models_mothers.NewProductMother().WithID(productID).MustBuild()
+		mockRepo.SetupGetByIDSuccess(productID, stored)
+		mockRepo.On("Update", mock.Anything, productID, mock.MatchedBy(func(product models.Product) bool {
+			return product.Name() == name && product.Sku() == "DEFAULT-001"
+		}), mock.Anything).Return(nil)
+		mockOutbox.SetupSaveEventNames(models.ProductUpdatedEventName)
+		mockAuditRepo.SetupRecordMatching(func(entry models.ProductAuditEntry) bool {
+			return entry.Action == models.ProductAuditPatched && len(entry.Changes) == 1
+		})
 
 		// Act
-		err := useCase.Execute(ctx, productID, patchData)
+		err := useCase.Execute(ctx, productID, models.ProductPatch{Name: &name}, nil)
 
 		// Assert
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("should reject a patch that breaks product invariants", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := use_cases.NewPatchProductUseCase(mockRepo, use_cases_mocks.NewMockProductAuditRepository(), interfaces_mocks.NewMockEventOutbox(), interfaces_mocks.NewMockTransactionManager())
+
+		productID := uuid.New()
+		empty := ""
+		mockRepo.SetupGetByIDSuccess(productID, models_mothers.NewProductMother().WithID(productID).MustBuild())
+
+		// Act
+		err := useCase.Execute(ctx, productID, models.ProductPatch{Name: &empty}, nil)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrProductNameEmpty)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should leave the product alone when the patch changes nothing", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		mockOutbox := interfaces_mocks.NewMockEventOutbox()
+		useCase := use_cases.NewPatchProductUseCase(mockRepo, mockAuditRepo, mockOutbox, interfaces_mocks.NewMockTransactionManager())
+
+		productID := uuid.New()
+		stored := models_mothers.NewProductMother().WithID(productID).MustBuild()
+		name := stored.Name()
+		mockRepo.SetupGetByIDSuccess(productID, stored)
+
+		// Act
+		err := useCase.Execute(ctx, productID, models.ProductPatch{Name: &name}, nil)
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockAuditRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+		mockOutbox.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return not found when product does not exist", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := use_cases.NewPatchProductUseCase(mockRepo, use_cases_mocks.NewMockProductAuditRepository(), interfaces_mocks.NewMockEventOutbox(), interfaces_mocks.NewMockTransactionManager())
+
+		productID := uuid.New()
+		name := "Updated Product Name"
+		mockRepo.On("GetByID", mock.Anything, productID).Return(nil, nil)
+
+		// Act
+		err := useCase.Execute(ctx, productID, models.ProductPatch{Name: &name}, nil)
+
+		// Assert
+		assert.Equal(t, shared_handlers.ErrNotFound, err)
+	})
+
 	t.Run("should return error when repository fails", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorizedContext()
 		mockRepo := use_cases_mocks.NewMockProductRepository()
-		useCase := use_cases.NewPatchProductUseCase(mockRepo)
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		mockOutbox := interfaces_mocks.NewMockEventOutbox()
+		useCase := use_cases.NewPatchProductUseCase(mockRepo, mockAuditRepo, mockOutbox, interfaces_mocks.NewMockTransactionManager())
 
 		productID := uuid.New()
-		patchData := map[string]interface{}{"name": "Updated Product Name"}
+		name := "Updated Product Name"
+		stored := models_mothers.NewProductMother().WithID(productID).MustBuild()
 		expectedError := errors.New("Database connection failed")
 
-		mockRepo.SetupPatchError(productID, expectedError)
+		mockRepo.SetupGetByIDSuccess(productID, stored)
+		mockRepo.SetupUpdateError(productID, stored, expectedError)
 
 		// Act
-		err := useCase.Execute(ctx, productID, patchData)
+		err := useCase.Execute(ctx, productID, models.ProductPatch{Name: &name}, nil)
 
 		// Assert
 		assert.Error(t, err)
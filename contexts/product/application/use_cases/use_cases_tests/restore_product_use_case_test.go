@@ -0,0 +1,95 @@
+package use_cases_tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRestoreProductUseCase_Execute(t *testing.T) {
+	t.Run("should restore product from trash successfully", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		mockOutbox := interfaces_mocks.NewMockEventOutbox()
+		useCase := use_cases.NewRestoreProductUseCase(mockRepo, mockAuditRepo, mockOutbox, interfaces_mocks.NewMockTransactionManager())
+
+		productID := uuid.New()
+		deletedAt := time.Now().UTC().Add(-time.Hour)
+		trashed := models_mothers.NewProductMother().WithID(productID).WithDeletedAt(deletedAt).MustBuild()
+		mockRepo.SetupGetTrashedByIDSuccess(productID, trashed)
+		mockRepo.On("Restore", mock.Anything, mock.MatchedBy(func(product models.Product) bool {
+			return product.ID() == productID && !product.IsDeleted() && product.UpdatedAt().After(deletedAt)
+		})).Return(nil)
+		mockOutbox.SetupSaveEventNames(models.ProductRestoredEventName)
+		mockAuditRepo.SetupRecordMatching(func(entry models.ProductAuditEntry) bool {
+			return entry.ProductID == productID &&
+				entry.Action == models.ProductAuditRestored &&
+				entry.Actor == "admin" &&
+				len(entry.Changes) == 4 &&
+				entry.Changes[0].From == nil
+		})
+
+		// Act
+		err := useCase.Execute(ctx, productID)
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockAuditRepo.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("should return not found when product is not in trash", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		mockOutbox := interfaces_mocks.NewMockEventOutbox()
+		useCase := use_cases.NewRestoreProductUseCase(mockRepo, mockAuditRepo, mockOutbox, interfaces_mocks.NewMockTransactionManager())
+
+		productID := uuid.New()
+		mockRepo.SetupGetTrashedByIDNotFound(productID)
+
+		// Act
+		err := useCase.Execute(ctx, productID)
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrNotFound)
+		mockRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+		mockAuditRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+		mockOutbox.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not audit when the restore fails", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		mockOutbox := interfaces_mocks.NewMockEventOutbox()
+		useCase := use_cases.NewRestoreProductUseCase(mockRepo, mockAuditRepo, mockOutbox, interfaces_mocks.NewMockTransactionManager())
+
+		productID := uuid.New()
+		trashed := models_mothers.NewProductMother().WithID(productID).WithDeletedAt(time.Now().UTC()).MustBuild()
+		mockRepo.SetupGetTrashedByIDSuccess(productID, trashed)
+		mockRepo.SetupRestoreError(productID, shared_handlers.ErrNotFound)
+
+		// Act
+		err := useCase.Execute(ctx, productID)
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrNotFound)
+		mockAuditRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+		mockOutbox.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,57 @@
+package use_cases_tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchProductsUseCase_Execute(t *testing.T) {
+	t.Run("should return the hits found by the repository", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := use_cases.NewSearchProductsUseCase(mockRepo)
+
+		query := models.ProductSearchQuery{Text: "laptop"}
+		hit := models.ProductSearchHit{
+			Product:    models_mothers.NewProductMother().WithName("Gaming Laptop").MustBuild(),
+			Rank:       0.6,
+			Highlights: models.ProductSearchHighlights{Name: "Gaming <mark>Laptop</mark>"},
+		}
+		next := models.NewProductSearchCursor(hit, false)
+		expectedPage := models.ProductSearchPage{Items: []models.ProductSearchHit{hit}, Next: &next}
+		mockRepo.On("Search", ctx, query).Return(expectedPage, nil)
+
+		// Act
+		page, err := useCase.Execute(ctx, query)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedPage, page)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return error when repository fails", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := use_cases.NewSearchProductsUseCase(mockRepo)
+
+		query := models.ProductSearchQuery{Text: "laptop"}
+		expectedError := errors.New("Database connection failed")
+		mockRepo.On("Search", ctx, query).Return(models.ProductSearchPage{}, expectedError)
+
+		// Act
+		page, err := useCase.Execute(ctx, query)
+
+		// Assert
+		assert.ErrorIs(t, err, expectedError)
+		assert.Empty(t, page.Items)
+	})
+}
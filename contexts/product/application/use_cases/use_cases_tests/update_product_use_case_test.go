@@ -1,53 +1,118 @@
 package use_cases_tests
 
 import (
-	"context"
 	"errors"
 	"testing"
 
 	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
 	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
-func UpdateProductUseCase_Execute(t *testing.T) {
+func TestUpdateProductUseCase_Execute(t *testing.T) {
 	t.Run("should update product successfully", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorizedContext()
 		mockRepo := use_cases_mocks.NewMockProductRepository()
-		useCase := use_cases.NewUpdateProductUseCase(mockRepo)
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		mockOutbox := interfaces_mocks.NewMockEventOutbox()
+		useCase := use_cases.NewUpdateProductUseCase(mockRepo, mockAuditRepo, mockOutbox, interfaces_mocks.NewMockTransactionManager())
 
 		productID := uuid.New()
-		product := models_mothers.NewProductMother().MustBuild()
-		mockRepo.SetupUpdateSuccess(productID, product)
+		stored := models_mothers.NewProductMother().WithID(productID).WithPrice(decimal.NewFromInt(10)).MustBuild()
+		product := models_mothers.NewProductMother().WithID(productID).WithPrice(decimal.NewFromInt(12)).MustBuild()
+		mockRepo.SetupGetByIDSuccess(productID, stored)
+		mockRepo.On("Update", mock.Anything, productID, mock.MatchedBy(func(saved models.Product) bool {
+			return saved.Price().Equal(decimal.NewFromInt(12))
+		}), mock.Anything).Return(nil)
+		mockOutbox.SetupSaveEventNames(models.ProductUpdatedEventName, models.ProductPriceChangedEventName)
+		mockAuditRepo.SetupRecordMatching(func(entry models.ProductAuditEntry) bool {
+			return entry.Action == models.ProductAuditUpdated && len(entry.Changes) == 1
+		})
 		// Act
-		err := useCase.Execute(ctx, productID, product)
+		err := useCase.Execute(ctx, productID, product, nil)
 
 		// Assert
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
+		mockAuditRepo.AssertExpectations(t)
+		mockOutbox.AssertExpectations(t)
+	})
+
+	t.Run("should leave the product alone when nothing changes", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		mockOutbox := interfaces_mocks.NewMockEventOutbox()
+		useCase := use_cases.NewUpdateProductUseCase(mockRepo, mockAuditRepo, mockOutbox, interfaces_mocks.NewMockTransactionManager())
+
+		productID := uuid.New()
+		stored := models_mothers.NewProductMother().WithID(productID).WithVersion(3).MustBuild()
+		product := models_mothers.NewProductMother().WithID(productID).MustBuild()
+		mockRepo.SetupGetByIDSuccess(productID, stored)
+		version := 3
+
+		// Act
+		err := useCase.Execute(ctx, productID, product, &version)
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockAuditRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+		mockOutbox.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should still check the expected version when nothing changes", func(t *testing.T) {
+		// Arrange
+		ctx := authorizedContext()
+		mockRepo := use_cases_mocks.NewMockProductRepository()
+		useCase := use_cases.NewUpdateProductUseCase(mockRepo, use_cases_mocks.NewMockProductAuditRepository(), interfaces_mocks.NewMockEventOutbox(), interfaces_mocks.NewMockTransactionManager())
+
+		productID := uuid.New()
+		stored := models_mothers.NewProductMother().WithID(productID).WithVersion(3).MustBuild()
+		product := models_mothers.NewProductMother().WithID(productID).MustBuild()
+		mockRepo.SetupGetByIDSuccess(productID, stored)
+		version := 2
+
+		// Act
+		err := useCase.Execute(ctx, productID, product, &version)
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrPreconditionFailed)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when repository fails", func(t *testing.T) {
 		// Arrange
-		ctx := context.Background()
+		ctx := authorizedContext()
 		mockRepo := use_cases_mocks.NewMockProductRepository()
-		useCase := use_cases.NewUpdateProductUseCase(mockRepo)
+		mockAuditRepo := use_cases_mocks.NewMockProductAuditRepository()
+		mockOutbox := interfaces_mocks.NewMockEventOutbox()
+		useCase := use_cases.NewUpdateProductUseCase(mockRepo, mockAuditRepo, mockOutbox, interfaces_mocks.NewMockTransactionManager())
 
 		productID := uuid.New()
-		product := models_mothers.NewProductMother().MustBuild()
+		stored := models_mothers.NewProductMother().WithID(productID).MustBuild()
+		product := models_mothers.NewProductMother().WithID(productID).WithName("Renamed Product").MustBuild()
 		expectedError := errors.New("Database connection failed")
 
-		mockRepo.SetupUpdateError(productID, product, expectedError)
+		mockRepo.SetupGetByIDSuccess(productID, stored)
+		mockRepo.SetupUpdateError(productID, stored, expectedError)
 
 		// Act
-		err := useCase.Execute(ctx, productID, product)
+		err := useCase.Execute(ctx, productID, product, nil)
 
 		// Assert
 		assert.Error(t, err)
 		assert.Equal(t, expectedError, err)
 		mockRepo.AssertExpectations(t)
+		mockAuditRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
 	})
 }
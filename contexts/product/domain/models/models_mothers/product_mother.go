@@ -1,26 +1,33 @@
 package models_mothers
 
 import (
+	"time"
+
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
 type ProductMother struct {
-	Id       uuid.UUID
-	Sku      string
-	Name     string
-	Category string
-	Price    decimal.Decimal
+	Id        uuid.UUID
+	Sku       string
+	Name      string
+	Category  string
+	Price     decimal.Decimal
+	Version   int
+	UpdatedAt time.Time
+	DeletedAt *time.Time
 }
 
 func NewProductMother() *ProductMother {
 	return &ProductMother{
-		Id:       uuid.New(),
-		Sku:      "DEFAULT-001",
-		Name:     "Default Product",
-		Category: "Electronics",
-		Price:    decimal.NewFromFloat(99.99),
+		Id:        uuid.New(),
+		Sku:       "DEFAULT-001",
+		Name:      "Default Product",
+		Category:  "Electronics",
+		Price:     decimal.NewFromFloat(99.99),
+		Version:   1,
+		UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 	}
 }
 
@@ -54,8 +61,27 @@ func (pm *ProductMother) WithPriceFloat(price float64) *ProductMother {
 	return pm
 }
 
+func (pm *ProductMother) WithVersion(version int) *ProductMother {
+	pm.Version = version
+	return pm
+}
+
+func (pm *ProductMother) WithUpdatedAt(updatedAt time.Time) *ProductMother {
+	pm.UpdatedAt = updatedAt
+	return pm
+}
+
+func (pm *ProductMother) WithDeletedAt(deletedAt time.Time) *ProductMother {
+	pm.DeletedAt = &deletedAt
+	return pm
+}
+
 func (pm *ProductMother) Build() (models.Product, error) {
-	return models.NewProduct(pm.Id, pm.Sku, pm.Name, pm.Category, pm.Price)
+	return models.ReconstituteProduct(pm.Id, pm.Sku, pm.Name, pm.Category, pm.Price, models.ProductMetadata{
+		Version:   pm.Version,
+		UpdatedAt: pm.UpdatedAt,
+		DeletedAt: pm.DeletedAt,
+	})
 }
 
 func (pm *ProductMother) MustBuild() models.Product {
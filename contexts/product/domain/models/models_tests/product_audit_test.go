@@ -0,0 +1,76 @@
+package models_tests
+
+import (
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffProducts(t *testing.T) {
+	t.Run("should list only changed fields", func(t *testing.T) {
+		// Arrange
+		before := models_mothers.NewProductMother().WithPrice(decimal.NewFromInt(10)).MustBuild()
+		after := models_mothers.NewProductMother().WithID(before.ID()).WithPrice(decimal.NewFromInt(12)).MustBuild()
+
+		// Act
+		changes := models.DiffProducts(before, after)
+
+		// Assert
+		require.Len(t, changes, 1)
+		assert.Equal(t, "price", changes[0].Field)
+		assert.Equal(t, "10", *changes[0].From)
+		assert.Equal(t, "12", *changes[0].To)
+	})
+
+	t.Run("should list every field of a created product", func(t *testing.T) {
+		// Arrange
+		after := models_mothers.NewProductMother().MustBuild()
+
+		// Act
+		changes := models.DiffProducts(nil, after)
+
+		// Assert
+		require.Len(t, changes, 4)
+		for _, change := range changes {
+			assert.Nil(t, change.From)
+			assert.NotNil(t, change.To)
+		}
+	})
+
+	t.Run("should list every field of a deleted product", func(t *testing.T) {
+		// Arrange
+		before := models_mothers.NewProductMother().MustBuild()
+
+		// Act
+		changes := models.DiffProducts(before, nil)
+
+		// Assert
+		require.Len(t, changes, 4)
+		for _, change := range changes {
+			assert.NotNil(t, change.From)
+			assert.Nil(t, change.To)
+		}
+	})
+}
+
+func TestNewProductAuditEntry(t *testing.T) {
+	t.Run("should record actor, request and diff", func(t *testing.T) {
+		// Arrange
+		product := models_mothers.NewProductMother().MustBuild()
+
+		// Act
+		entry := models.NewProductAuditEntry(product.ID(), models.ProductAuditCreated, "alice", "req-1", models.DiffProducts(nil, product))
+
+		// Assert
+		assert.Equal(t, product.ID(), entry.ProductID)
+		assert.Equal(t, models.ProductAuditCreated, entry.Action)
+		assert.Equal(t, "alice", entry.Actor)
+		assert.Equal(t, "req-1", entry.RequestID)
+		assert.False(t, entry.OccurredAt.IsZero())
+		assert.Len(t, entry.Changes, 4)
+	})
+}
@@ -0,0 +1,131 @@
+package models_tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductEvents(t *testing.T) {
+	t.Run("should raise ProductCreated for a new product", func(t *testing.T) {
+		// Arrange
+		id := uuid.New()
+
+		// Act
+		product, err := models.NewProduct(id, "SKU", "Name", "Category", decimal.NewFromInt(5))
+
+		// Assert
+		require.NoError(t, err)
+		events := product.PullEvents()
+		require.Len(t, events, 1)
+		created, ok := events[0].(models.ProductCreated)
+		require.True(t, ok)
+		assert.Equal(t, id, created.AggregateID())
+		assert.Equal(t, "SKU", created.Sku)
+		assert.Empty(t, product.PullEvents())
+	})
+
+	t.Run("should not raise events for a reconstituted product", func(t *testing.T) {
+		// Arrange & Act
+		product := models_mothers.NewProductMother().MustBuild()
+
+		// Assert
+		assert.Empty(t, product.PullEvents())
+	})
+
+	t.Run("should raise ProductUpdated and ProductPriceChanged on a price change", func(t *testing.T) {
+		// Arrange
+		product := models_mothers.NewProductMother().WithPrice(decimal.NewFromInt(10)).MustBuild()
+
+		// Act
+		changes, err := product.Update(product.Sku(), product.Name(), product.Category(), decimal.NewFromInt(12))
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		events := product.PullEvents()
+		require.Len(t, events, 2)
+		assert.Equal(t, models.ProductUpdatedEventName, events[0].EventName())
+		priceChanged, ok := events[1].(models.ProductPriceChanged)
+		require.True(t, ok)
+		assert.True(t, decimal.NewFromInt(10).Equal(priceChanged.OldPrice))
+		assert.True(t, decimal.NewFromInt(12).Equal(priceChanged.NewPrice))
+	})
+
+	t.Run("should raise nothing when an update changes nothing", func(t *testing.T) {
+		// Arrange
+		product := models_mothers.NewProductMother().MustBuild()
+
+		// Act
+		changes, err := product.Update(product.Sku(), product.Name(), product.Category(), product.Price())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+		assert.Empty(t, product.PullEvents())
+	})
+
+	t.Run("should enforce creation invariants on update", func(t *testing.T) {
+		// Arrange
+		product := models_mothers.NewProductMother().MustBuild()
+
+		// Act
+		_, err := product.Update(product.Sku(), "", product.Category(), product.Price())
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrProductNameEmpty)
+		assert.Equal(t, "Default Product", product.Name())
+		assert.Empty(t, product.PullEvents())
+	})
+
+	t.Run("should raise ProductDeleted and mark the product deleted", func(t *testing.T) {
+		// Arrange
+		product := models_mothers.NewProductMother().MustBuild()
+
+		// Act
+		product.Delete()
+
+		// Assert
+		assert.True(t, product.IsDeleted())
+		events := product.PullEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, models.ProductDeletedEventName, events[0].EventName())
+	})
+
+	t.Run("should raise ProductRestored and take the product out of the trash", func(t *testing.T) {
+		// Arrange
+		deletedAt := time.Now().UTC().Add(-time.Hour)
+		product := models_mothers.NewProductMother().WithDeletedAt(deletedAt).MustBuild()
+
+		// Act
+		product.Restore()
+
+		// Assert
+		assert.False(t, product.IsDeleted())
+		assert.True(t, product.UpdatedAt().After(deletedAt))
+		events := product.PullEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, models.ProductRestoredEventName, events[0].EventName())
+		assert.Equal(t, product.UpdatedAt(), events[0].OccurredAt())
+	})
+
+	t.Run("should raise ProductPurged", func(t *testing.T) {
+		// Arrange
+		product := models_mothers.NewProductMother().MustBuild()
+
+		// Act
+		product.Purge()
+
+		// Assert
+		events := product.PullEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, models.ProductPurgedEventName, events[0].EventName())
+		assert.Equal(t, product.ID(), events[0].AggregateID())
+	})
+}
@@ -2,9 +2,11 @@ package models_tests
 
 import (
 	"testing"
+	"time"
 
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -27,6 +29,7 @@ func TestNewProduct(t *testing.T) {
 		assert.Equal(t, mother.Name, product.Name())
 		assert.Equal(t, mother.Category, product.Category())
 		assert.True(t, mother.Price.Equal(product.Price()))
+		assert.Equal(t, 1, product.Version())
 	})
 
 	t.Run("should return error when price is negative", func(t *testing.T) {
@@ -36,7 +39,7 @@ func TestNewProduct(t *testing.T) {
 		// Assert
 		assert.Error(t, err)
 		assert.Nil(t, product)
-		assert.Equal(t, models.ErrProductPriceNegative, err)
+		assert.ErrorIs(t, err, models.ErrProductPriceNegative)
 	})
 
 	t.Run("should return error when SKU is empty", func(t *testing.T) {
@@ -46,7 +49,7 @@ func TestNewProduct(t *testing.T) {
 		// Assert
 		assert.Error(t, err)
 		assert.Nil(t, product)
-		assert.Equal(t, models.ErrProductSkuEmpty, err)
+		assert.ErrorIs(t, err, models.ErrProductSkuEmpty)
 	})
 
 	t.Run("should return error when name is empty", func(t *testing.T) {
@@ -56,7 +59,7 @@ func TestNewProduct(t *testing.T) {
 		// Assert
 		assert.Error(t, err)
 		assert.Nil(t, product)
-		assert.Equal(t, models.ErrProductNameEmpty, err)
+		assert.ErrorIs(t, err, models.ErrProductNameEmpty)
 	})
 
 	t.Run("should return error when category is empty", func(t *testing.T) {
@@ -66,7 +69,7 @@ func TestNewProduct(t *testing.T) {
 		// Assert
 		assert.Error(t, err)
 		assert.Nil(t, product)
-		assert.Equal(t, models.ErrProductCategoryEmpty, err)
+		assert.ErrorIs(t, err, models.ErrProductCategoryEmpty)
 	})
 
 	t.Run("should return error when ID is nil", func(t *testing.T) {
@@ -76,7 +79,7 @@ func TestNewProduct(t *testing.T) {
 		// Assert
 		assert.Error(t, err)
 		assert.Nil(t, product)
-		assert.Equal(t, models.ErrProductIdNil, err)
+		assert.ErrorIs(t, err, models.ErrProductIdNil)
 	})
 }
 
@@ -105,3 +108,124 @@ func TestProduct_Getters(t *testing.T) {
 		assert.True(t, price.Equal(product.Price()))
 	})
 }
+
+func TestReconstituteProduct(t *testing.T) {
+	t.Run("should keep the stored version", func(t *testing.T) {
+		// Arrange & Act
+		product, err := models_mothers.NewProductMother().WithVersion(7).Build()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 7, product.Version())
+		assert.False(t, product.IsDeleted())
+	})
+
+	t.Run("should keep the deletion time", func(t *testing.T) {
+		// Arrange
+		deletedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		// Act
+		product, err := models_mothers.NewProductMother().WithDeletedAt(deletedAt).Build()
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, product.IsDeleted())
+		assert.Equal(t, deletedAt, *product.DeletedAt())
+	})
+
+	t.Run("should validate like a new product", func(t *testing.T) {
+		// Arrange & Act
+		product, err := models.ReconstituteProduct(uuid.New(), "SKU", "", "Category", decimal.NewFromInt(1), models.ProductMetadata{Version: 2})
+
+		// Assert
+		assert.Nil(t, product)
+		assert.ErrorIs(t, err, models.ErrProductNameEmpty)
+	})
+}
+
+func TestProduct_Patch(t *testing.T) {
+	t.Run("should set only the fields present in the patch", func(t *testing.T) {
+		// Arrange
+		product := models_mothers.NewProductMother().WithName("Mouse").WithPriceFloat(10).MustBuild()
+		price := decimal.NewFromInt(12)
+
+		// Act
+		changes, err := product.Patch(models.ProductPatch{Price: &price})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, "price", changes[0].Field)
+		assert.Equal(t, "Mouse", product.Name())
+		assert.True(t, price.Equal(product.Price()))
+	})
+
+	t.Run("should enforce creation invariants", func(t *testing.T) {
+		// Arrange
+		product := models_mothers.NewProductMother().WithName("Mouse").MustBuild()
+		empty := ""
+		negative := decimal.NewFromInt(-1)
+
+		// Act
+		_, nameErr := product.Patch(models.ProductPatch{Name: &empty})
+		_, priceErr := product.Patch(models.ProductPatch{Price: &negative})
+
+		// Assert
+		assert.ErrorIs(t, nameErr, models.ErrProductNameEmpty)
+		assert.ErrorIs(t, priceErr, models.ErrProductPriceNegative)
+		assert.Equal(t, "Mouse", product.Name())
+	})
+}
+
+func TestProduct_UpdatedAt(t *testing.T) {
+	t.Run("should be the creation time of a new product", func(t *testing.T) {
+		// Arrange
+		before := time.Now().UTC()
+
+		// Act
+		product, err := models.NewProduct(uuid.New(), "SKU-1", "Mouse", "Electronics", decimal.NewFromInt(10))
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, product.UpdatedAt().Before(before))
+	})
+
+	t.Run("should move forward only when something changed", func(t *testing.T) {
+		// Arrange
+		updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		product := models_mothers.NewProductMother().WithName("Mouse").WithUpdatedAt(updatedAt).MustBuild()
+		name := "Mouse"
+		renamed := "Trackball"
+
+		// Act
+		_, err := product.Patch(models.ProductPatch{Name: &name})
+		require.NoError(t, err)
+		unchanged := product.UpdatedAt()
+		_, err = product.Patch(models.ProductPatch{Name: &renamed})
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, updatedAt, unchanged)
+		assert.True(t, product.UpdatedAt().After(updatedAt))
+	})
+}
+
+func TestProductValidation(t *testing.T) {
+	t.Run("should report every invalid field at once", func(t *testing.T) {
+		// Arrange & Act
+		_, err := models.NewProduct(uuid.New(), "", "", "Electronics", decimal.NewFromInt(-1))
+
+		// Assert
+		require.ErrorIs(t, err, models.ErrProductPriceNegative)
+		details := err.(shared_models.DomainError).Details
+		assert.Len(t, details, 3)
+		assert.Equal(t, shared_models.FieldViolation{
+			Field:   "name",
+			Rule:    "required",
+			Value:   "",
+			Message: models.ErrProductNameEmpty.Message,
+		}, details["name"])
+		assert.Contains(t, details, "sku")
+		assert.Contains(t, details, "price")
+	})
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
 
 	"github.com/google/uuid"
@@ -40,6 +42,37 @@ type Product interface {
 	Name() string
 	Category() string
 	Price() decimal.Decimal
+	Version() int
+	UpdatedAt() time.Time
+	DeletedAt() *time.Time
+	IsDeleted() bool
+	Update(sku, name, category string, price decimal.Decimal) ([]ProductFieldChange, error)
+	Patch(patch ProductPatch) ([]ProductFieldChange, error)
+	Delete()
+	Restore()
+	Purge()
+	PullEvents() []shared_models.DomainEvent
+}
+
+// ProductPatch holds the fields a partial update sets. Nil fields keep their
+// current value.
+type ProductPatch struct {
+	Sku      *string
+	Name     *string
+	Category *string
+	Price    *decimal.Decimal
+}
+
+// initialVersion is the version of a product that has never been modified.
+const initialVersion = 1
+
+// ProductMetadata is the bookkeeping a stored product carries besides its
+// business fields.
+type ProductMetadata struct {
+	Version int
+	// UpdatedAt is when the product was created or last changed.
+	UpdatedAt time.Time
+	DeletedAt *time.Time
 }
 
 type product struct {
@@ -48,32 +81,84 @@ type product struct {
 	name     string
 	category string
 	price    decimal.Decimal
+	metadata ProductMetadata
+	events   []shared_models.DomainEvent
 }
 
+// NewProduct creates a brand-new product and raises ProductCreated.
 func NewProduct(id uuid.UUID, sku, name, category string, price decimal.Decimal) (Product, error) {
-	if price.IsNegative() {
-		return nil, ErrProductPriceNegative
-	}
-	if sku == "" {
-		return nil, ErrProductSkuEmpty
-	}
-	if name == "" {
-		return nil, ErrProductNameEmpty
-	}
-	if category == "" {
-		return nil, ErrProductCategoryEmpty
+	if err := validateProduct(sku, name, category, price); err != nil {
+		return nil, err
 	}
 	if id == uuid.Nil {
 		return nil, ErrProductIdNil
 	}
 
-	return &product{
+	created := &product{
 		id:       id,
 		sku:      sku,
 		name:     name,
 		category: category,
 		price:    price,
-	}, nil
+	}
+	event := newProductEvent(id)
+	created.metadata = ProductMetadata{Version: initialVersion, UpdatedAt: event.Time}
+	created.record(ProductCreated{
+		ProductEvent: event,
+		Sku:          sku,
+		Name:         name,
+		Category:     category,
+		Price:        price,
+	})
+	return created, nil
+}
+
+// validateProduct checks every field and returns the error of the first
+// invalid one, with all the invalid fields in its details.
+func validateProduct(sku, name, category string, price decimal.Decimal) error {
+	var first *shared_models.DomainError
+	var violations []shared_models.FieldViolation
+	reject := func(err shared_models.DomainError, field, rule string, value interface{}) {
+		if first == nil {
+			first = &err
+		}
+		violations = append(violations, shared_models.FieldViolation{
+			Field:   field,
+			Rule:    rule,
+			Value:   value,
+			Message: err.Message,
+		})
+	}
+
+	if price.IsNegative() {
+		reject(ErrProductPriceNegative, "price", "min", price)
+	}
+	if sku == "" {
+		reject(ErrProductSkuEmpty, "sku", "required", sku)
+	}
+	if name == "" {
+		reject(ErrProductNameEmpty, "name", "required", name)
+	}
+	if category == "" {
+		reject(ErrProductCategoryEmpty, "category", "required", category)
+	}
+	if first == nil {
+		return nil
+	}
+	return first.WithFieldViolations(violations...)
+}
+
+// ReconstituteProduct rebuilds a stored product, keeping the metadata it was
+// persisted with, e.g. the version used to detect concurrent modifications.
+// Nothing happened to a reconstituted product yet, so it has no events.
+func ReconstituteProduct(id uuid.UUID, sku, name, category string, price decimal.Decimal, metadata ProductMetadata) (Product, error) {
+	reconstituted, err := NewProduct(id, sku, name, category, price)
+	if err != nil {
+		return nil, err
+	}
+	reconstituted.(*product).metadata = metadata
+	reconstituted.(*product).events = nil
+	return reconstituted, nil
 }
 
 func (p *product) ID() uuid.UUID {
@@ -95,3 +180,98 @@ func (p *product) Category() string {
 func (p *product) Price() decimal.Decimal {
 	return p.price
 }
+
+func (p *product) Version() int {
+	return p.metadata.Version
+}
+
+func (p *product) UpdatedAt() time.Time {
+	return p.metadata.UpdatedAt
+}
+
+func (p *product) DeletedAt() *time.Time {
+	return p.metadata.DeletedAt
+}
+
+func (p *product) IsDeleted() bool {
+	return p.metadata.DeletedAt != nil
+}
+
+// Update replaces the business fields with the same invariants as creation.
+// It raises ProductUpdated when anything changed, plus ProductPriceChanged
+// when the price did, and returns the changed fields.
+func (p *product) Update(sku, name, category string, price decimal.Decimal) ([]ProductFieldChange, error) {
+	if err := validateProduct(sku, name, category, price); err != nil {
+		return nil, err
+	}
+
+	previous := *p
+	p.sku, p.name, p.category, p.price = sku, name, category, price
+	changes := DiffProducts(&previous, p)
+	if len(changes) == 0 {
+		return changes, nil
+	}
+
+	event := newProductEvent(p.id)
+	p.metadata.UpdatedAt = event.Time
+	p.record(ProductUpdated{ProductEvent: event, Changes: changes})
+	if !previous.price.Equal(price) {
+		p.record(ProductPriceChanged{
+			ProductEvent: newProductEvent(p.id),
+			OldPrice:     previous.price,
+			NewPrice:     price,
+		})
+	}
+	return changes, nil
+}
+
+// Patch sets the fields present in patch and keeps the others, with the same
+// invariants and events as Update.
+func (p *product) Patch(patch ProductPatch) ([]ProductFieldChange, error) {
+	sku, name, category, price := p.sku, p.name, p.category, p.price
+	if patch.Sku != nil {
+		sku = *patch.Sku
+	}
+	if patch.Name != nil {
+		name = *patch.Name
+	}
+	if patch.Category != nil {
+		category = *patch.Category
+	}
+	if patch.Price != nil {
+		price = *patch.Price
+	}
+	return p.Update(sku, name, category, price)
+}
+
+// Delete moves the product to the trash and raises ProductDeleted.
+func (p *product) Delete() {
+	event := newProductEvent(p.id)
+	deletedAt := event.Time
+	p.metadata.DeletedAt = &deletedAt
+	p.record(ProductDeleted{ProductEvent: event})
+}
+
+// Restore takes the product out of the trash and raises ProductRestored.
+func (p *product) Restore() {
+	event := newProductEvent(p.id)
+	p.metadata.DeletedAt = nil
+	p.metadata.UpdatedAt = event.Time
+	p.record(ProductRestored{ProductEvent: event})
+}
+
+// Purge raises ProductPurged for a product about to be removed for good.
+func (p *product) Purge() {
+	p.record(ProductPurged{ProductEvent: newProductEvent(p.id)})
+}
+
+// PullEvents returns the events raised since the last call and forgets them.
+func (p *product) PullEvents() []shared_models.DomainEvent {
+	events := p.events
+	p.events = nil
+	return events
+}
+
+func (p *product) record(event shared_models.DomainEvent) {
+	p.events = append(p.events, event)
+}
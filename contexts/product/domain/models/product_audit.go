@@ -0,0 +1,109 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductAuditAction string
+
+const (
+	ProductAuditCreated  ProductAuditAction = "created"
+	ProductAuditUpdated  ProductAuditAction = "updated"
+	ProductAuditPatched  ProductAuditAction = "patched"
+	ProductAuditDeleted  ProductAuditAction = "deleted"
+	ProductAuditRestored ProductAuditAction = "restored"
+	ProductAuditPurged   ProductAuditAction = "purged"
+)
+
+// ProductFieldChange is the value of a single field before and after a change.
+// From is nil for created products and To is nil for deleted ones.
+type ProductFieldChange struct {
+	Field string  `json:"field"`
+	From  *string `json:"from"`
+	To    *string `json:"to"`
+}
+
+// ProductAuditEntry records who changed a product, when, within which request
+// and which fields were affected.
+type ProductAuditEntry struct {
+	ID         uuid.UUID
+	ProductID  uuid.UUID
+	Action     ProductAuditAction
+	Actor      string
+	RequestID  string
+	OccurredAt time.Time
+	Changes    []ProductFieldChange
+}
+
+// NewProductAuditEntry builds an entry for the given field changes, as
+// returned by DiffProducts or Product.Update.
+func NewProductAuditEntry(productID uuid.UUID, action ProductAuditAction, actor, requestID string, changes []ProductFieldChange) ProductAuditEntry {
+	return ProductAuditEntry{
+		ID:        uuid.New(),
+		ProductID: productID,
+		Action:    action,
+		Actor:     actor,
+		RequestID: requestID,
+		// Postgres keeps microseconds; truncating keeps cursors exact.
+		OccurredAt: time.Now().UTC().Truncate(time.Microsecond),
+		Changes:    changes,
+	}
+}
+
+// DiffProducts lists the audited fields whose value differs between before
+// and after, in a stable order. Either of them may be nil when the product
+// did not exist on that side.
+func DiffProducts(before, after Product) []ProductFieldChange {
+	beforeFields, afterFields := auditedFields(before), auditedFields(after)
+	changes := make([]ProductFieldChange, 0, len(auditedFieldNames))
+	for _, field := range auditedFieldNames {
+		from, hadBefore := beforeFields[field]
+		to, hasAfter := afterFields[field]
+		if hadBefore && hasAfter && from == to {
+			continue
+		}
+		change := ProductFieldChange{Field: field}
+		if hadBefore {
+			change.From = &from
+		}
+		if hasAfter {
+			change.To = &to
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+var auditedFieldNames = []string{"sku", "name", "category", "price"}
+
+func auditedFields(product Product) map[string]string {
+	if product == nil {
+		return nil
+	}
+	return map[string]string{
+		"sku":      product.Sku(),
+		"name":     product.Name(),
+		"category": product.Category(),
+		"price":    product.Price().String(),
+	}
+}
+
+// ProductAuditCursor is the position of the last entry of a history page.
+// History is read newest first.
+type ProductAuditCursor struct {
+	OccurredAt time.Time
+	ID         uuid.UUID
+}
+
+type ProductAuditQuery struct {
+	ProductID uuid.UUID
+	Cursor    *ProductAuditCursor
+	Limit     *int
+}
+
+type ProductAuditPage struct {
+	Items []ProductAuditEntry
+	Next  *ProductAuditCursor
+}
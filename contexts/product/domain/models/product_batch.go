@@ -0,0 +1,51 @@
+package models
+
+import "github.com/google/uuid"
+
+// ProductBatchMode decides what happens to a batch when some of its items
+// fail.
+type ProductBatchMode string
+
+const (
+	// ProductBatchAllOrNothing applies every item or none of them.
+	ProductBatchAllOrNothing ProductBatchMode = "all_or_nothing"
+	// ProductBatchBestEffort applies every item that can be applied.
+	ProductBatchBestEffort ProductBatchMode = "best_effort"
+)
+
+func (m ProductBatchMode) IsValid() bool {
+	return m == ProductBatchAllOrNothing || m == ProductBatchBestEffort
+}
+
+// The items of a batch carry their position in the request. Err is set when
+// the item was already rejected while being read, so that it is reported
+// with the others and can fail an all-or-nothing batch.
+
+type ProductBatchCreateItem struct {
+	Index   int
+	Product Product
+	Err     error
+}
+
+type ProductBatchUpdateItem struct {
+	Index           int
+	ID              uuid.UUID
+	Product         Product
+	ExpectedVersion *int
+	Err             error
+}
+
+type ProductBatchDeleteItem struct {
+	Index           int
+	ID              uuid.UUID
+	ExpectedVersion *int
+	Err             error
+}
+
+// ProductBatchResult is the outcome of one item. Err is nil when the item
+// was applied.
+type ProductBatchResult struct {
+	Index     int
+	ProductID uuid.UUID
+	Err       error
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	ProductCreatedEventName      = "product.created"
+	ProductUpdatedEventName      = "product.updated"
+	ProductPriceChangedEventName = "product.price_changed"
+	ProductDeletedEventName      = "product.deleted"
+	ProductRestoredEventName     = "product.restored"
+	ProductPurgedEventName       = "product.purged"
+)
+
+// ProductEvent holds what every product event carries.
+type ProductEvent struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Time      time.Time `json:"occurred_at"`
+}
+
+func newProductEvent(productID uuid.UUID) ProductEvent {
+	return ProductEvent{ProductID: productID, Time: time.Now().UTC()}
+}
+
+func (e ProductEvent) AggregateID() uuid.UUID {
+	return e.ProductID
+}
+
+func (e ProductEvent) OccurredAt() time.Time {
+	return e.Time
+}
+
+type ProductCreated struct {
+	ProductEvent
+	Sku      string          `json:"sku"`
+	Name     string          `json:"name"`
+	Category string          `json:"category"`
+	Price    decimal.Decimal `json:"price"`
+}
+
+func (ProductCreated) EventName() string {
+	return ProductCreatedEventName
+}
+
+type ProductUpdated struct {
+	ProductEvent
+	Changes []ProductFieldChange `json:"changes"`
+}
+
+func (ProductUpdated) EventName() string {
+	return ProductUpdatedEventName
+}
+
+type ProductPriceChanged struct {
+	ProductEvent
+	OldPrice decimal.Decimal `json:"old_price"`
+	NewPrice decimal.Decimal `json:"new_price"`
+}
+
+func (ProductPriceChanged) EventName() string {
+	return ProductPriceChangedEventName
+}
+
+type ProductDeleted struct {
+	ProductEvent
+}
+
+func (ProductDeleted) EventName() string {
+	return ProductDeletedEventName
+}
+
+type ProductRestored struct {
+	ProductEvent
+}
+
+func (ProductRestored) EventName() string {
+	return ProductRestoredEventName
+}
+
+type ProductPurged struct {
+	ProductEvent
+}
+
+func (ProductPurged) EventName() string {
+	return ProductPurgedEventName
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrProductSkuDuplicatedInFile = shared_models.DomainError{
+		Code:    "PRODUCT_SKU_DUPLICATED_IN_FILE",
+		Message: "Product SKU appears on an earlier line of the file",
+	}
+
+	ErrProductSkuAlreadyExists = shared_models.DomainError{
+		Code:    "PRODUCT_SKU_ALREADY_EXISTS",
+		Message: "A product with this SKU already exists",
+	}
+)
+
+// ProductImportRow is one line of an import file. Product is nil and Err is
+// set when the line could not be turned into a product.
+type ProductImportRow struct {
+	Line    int
+	Sku     string
+	Product Product
+	Err     error
+}
+
+// ProductImportRowResult is the outcome of one line. ProductID is only set
+// for products that were actually created.
+type ProductImportRowResult struct {
+	Line      int
+	Sku       string
+	ProductID uuid.UUID
+	Err       error
+}
+
+func (r ProductImportRowResult) Accepted() bool {
+	return r.Err == nil
+}
+
+// ProductImportReport lists every line of an import in file order. A dry run
+// validates the lines without creating anything.
+type ProductImportReport struct {
+	DryRun bool
+	Rows   []ProductImportRowResult
+}
+
+func (r ProductImportReport) Accepted() int {
+	accepted := 0
+	for _, row := range r.Rows {
+		if row.Accepted() {
+			accepted++
+		}
+	}
+	return accepted
+}
+
+func (r ProductImportReport) Rejected() int {
+	return len(r.Rows) - r.Accepted()
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type ProductSortField string
+
+const (
+	ProductSortByID    ProductSortField = "id"
+	ProductSortByName  ProductSortField = "name"
+	ProductSortByPrice ProductSortField = "price"
+	ProductSortBySku   ProductSortField = "sku"
+)
+
+type SortDirection string
+
+const (
+	SortAscending  SortDirection = "asc"
+	SortDescending SortDirection = "desc"
+)
+
+func (f ProductSortField) IsValid() bool {
+	switch f {
+	case ProductSortByID, ProductSortByName, ProductSortByPrice, ProductSortBySku:
+		return true
+	}
+	return false
+}
+
+func (d SortDirection) IsValid() bool {
+	return d == SortAscending || d == SortDescending
+}
+
+// ProductSort describes the ordering of a product listing. The product ID is
+// always used as a tie-breaker so that the ordering is total.
+type ProductSort struct {
+	Field     ProductSortField
+	Direction SortDirection
+}
+
+func DefaultProductSort() ProductSort {
+	return ProductSort{
+		Field:     ProductSortByID,
+		Direction: SortAscending,
+	}
+}
+
+// ProductFilter narrows a product listing. Nil fields are not applied.
+// Trashed lists soft-deleted products instead of the live ones.
+type ProductFilter struct {
+	Category *string
+	Name     *string
+	MinPrice *decimal.Decimal
+	MaxPrice *decimal.Decimal
+	Trashed  bool
+}
+
+// ProductCursor is the keyset position of a product at the edge of a page:
+// the value of the sort column plus the product ID. Backward cursors read the
+// page that precedes the position instead of the one that follows it.
+type ProductCursor struct {
+	Sort     ProductSort
+	Value    string
+	ID       uuid.UUID
+	Backward bool
+}
+
+// ProductPage is one page of a product listing with the cursors needed to
+// move to the following and the preceding page, if any.
+type ProductPage struct {
+	Items []Product
+	Next  *ProductCursor
+	Prev  *ProductCursor
+}
+
+type ProductQuery struct {
+	Filter ProductFilter
+	Sort   ProductSort
+	Cursor *ProductCursor
+	Limit  *int
+}
+
+func NewProductCursor(product Product, sort ProductSort, backward bool) ProductCursor {
+	cursor := ProductCursor{
+		Sort:     sort,
+		ID:       product.ID(),
+		Backward: backward,
+	}
+	switch sort.Field {
+	case ProductSortByName:
+		cursor.Value = product.Name()
+	case ProductSortByPrice:
+		cursor.Value = product.Price().String()
+	case ProductSortBySku:
+		cursor.Value = product.Sku()
+	}
+	return cursor
+}
+
+// ProductExportQuery selects the products of an export. Unlike a listing it
+// has no cursor or limit: every live product matching Filter is read, in Sort
+// order. Trashed is ignored.
+type ProductExportQuery struct {
+	Filter ProductFilter
+	Sort   ProductSort
+}
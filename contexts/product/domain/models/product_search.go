@@ -0,0 +1,51 @@
+package models
+
+import "github.com/google/uuid"
+
+// ProductSearchHighlights holds the searchable fields of a hit, HTML-escaped,
+// with the matched terms wrapped in <mark> tags.
+type ProductSearchHighlights struct {
+	Name     string
+	Sku      string
+	Category string
+}
+
+// ProductSearchHit is a product that matches a full-text search, together with
+// its relevance for that search.
+type ProductSearchHit struct {
+	Product    Product
+	Rank       float32
+	Highlights ProductSearchHighlights
+}
+
+// ProductSearchCursor is the keyset position of a hit at the edge of a page.
+// Hits are ordered by rank, highest first, then by product ID.
+type ProductSearchCursor struct {
+	Rank     float32
+	ID       uuid.UUID
+	Backward bool
+}
+
+// ProductSearchQuery searches live products. Text uses web search syntax:
+// quoted phrases, "or" and "-" to exclude a word. Filter narrows the hits
+// like it narrows a listing; Trashed is ignored.
+type ProductSearchQuery struct {
+	Text   string
+	Filter ProductFilter
+	Cursor *ProductSearchCursor
+	Limit  *int
+}
+
+type ProductSearchPage struct {
+	Items []ProductSearchHit
+	Next  *ProductSearchCursor
+	Prev  *ProductSearchCursor
+}
+
+func NewProductSearchCursor(hit ProductSearchHit, backward bool) ProductSearchCursor {
+	return ProductSearchCursor{
+		Rank:     hit.Rank,
+		ID:       hit.Product.ID(),
+		Backward: backward,
+	}
+}
@@ -0,0 +1,178 @@
+package adapters_tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/product/infra/adapters"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedProductRepository(t *testing.T) {
+	ctx := request_context.WithTenant(context.Background(), "acme")
+
+	t.Run("should read a product from the repository once", func(t *testing.T) {
+		// Arrange
+		product := models_mothers.NewProductMother().WithVersion(3).MustBuild()
+		repo := &use_cases_mocks.MockProductRepository{}
+		repo.On("GetByID", mock.Anything, product.ID()).Return(product, nil).Once()
+		cached := adapters.NewCachedProductRepository(repo, cache.NewMemoryCacheStore(10), time.Hour)
+
+		// Act
+		first, err := cached.GetByID(ctx, product.ID())
+		require.NoError(t, err)
+		second, err := cached.GetByID(ctx, product.ID())
+		require.NoError(t, err)
+
+		// Assert
+		repo.AssertExpectations(t)
+		assert.Equal(t, product.Sku(), second.Sku())
+		assert.True(t, product.Price().Equal(second.Price()))
+		assert.Equal(t, 3, second.Version())
+		assert.NotSame(t, first, second)
+		assert.Equal(t, adapters.ProductCacheStats{Hits: 1, Misses: 1, HitRatio: 0.5}, cached.Stats())
+	})
+
+	t.Run("should keep the products of each tenant apart", func(t *testing.T) {
+		// Arrange
+		product := models_mothers.NewProductMother().MustBuild()
+		repo := &use_cases_mocks.MockProductRepository{}
+		repo.On("GetByID", mock.Anything, product.ID()).Return(product, nil).Once()
+		repo.On("GetByID", mock.Anything, product.ID()).Return(nil, nil).Once()
+		cached := adapters.NewCachedProductRepository(repo, cache.NewMemoryCacheStore(10), time.Hour)
+		cached.GetByID(ctx, product.ID())
+
+		// Act
+		found, err := cached.GetByID(request_context.WithTenant(context.Background(), "globex"), product.ID())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Nil(t, found)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should not cache missing products", func(t *testing.T) {
+		// Arrange
+		product := models_mothers.NewProductMother().MustBuild()
+		repo := &use_cases_mocks.MockProductRepository{}
+		repo.On("GetByID", mock.Anything, product.ID()).Return(nil, nil).Twice()
+		cached := adapters.NewCachedProductRepository(repo, cache.NewMemoryCacheStore(10), time.Hour)
+
+		// Act
+		cached.GetByID(ctx, product.ID())
+		cached.GetByID(ctx, product.ID())
+
+		// Assert
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should drop a product once it is written", func(t *testing.T) {
+		// Arrange
+		product := models_mothers.NewProductMother().MustBuild()
+		updated := models_mothers.NewProductMother().WithID(product.ID()).WithName("Updated").MustBuild()
+		repo := &use_cases_mocks.MockProductRepository{}
+		repo.On("GetByID", mock.Anything, product.ID()).Return(product, nil).Once()
+		repo.On("Update", mock.Anything, product.ID(), updated, (*int)(nil)).Return(nil)
+		repo.On("GetByID", mock.Anything, product.ID()).Return(updated, nil).Once()
+		cached := adapters.NewCachedProductRepository(repo, cache.NewMemoryCacheStore(10), time.Hour)
+		cached.GetByID(ctx, product.ID())
+
+		// Act
+		require.NoError(t, cached.Update(ctx, product.ID(), updated, nil))
+		found, err := cached.GetByID(ctx, product.ID())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "Updated", found.Name())
+		assert.Equal(t, uint64(1), cached.Stats().Invalidations)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should not cache a product written while it was read", func(t *testing.T) {
+		// Arrange
+		product := models_mothers.NewProductMother().MustBuild()
+		updated := models_mothers.NewProductMother().WithID(product.ID()).WithName("Updated").MustBuild()
+		repo := &use_cases_mocks.MockProductRepository{}
+		cached := adapters.NewCachedProductRepository(repo, cache.NewMemoryCacheStore(10), time.Hour)
+		repo.On("Update", mock.Anything, product.ID(), updated, (*int)(nil)).Return(nil)
+		repo.On("GetByID", mock.Anything, product.ID()).Return(product, nil).Once().Run(func(mock.Arguments) {
+			require.NoError(t, cached.Update(ctx, product.ID(), updated, nil))
+		})
+		repo.On("GetByID", mock.Anything, product.ID()).Return(updated, nil).Once()
+
+		// Act
+		stale, err := cached.GetByID(ctx, product.ID())
+		require.NoError(t, err)
+		found, err := cached.GetByID(ctx, product.ID())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, product.Name(), stale.Name())
+		assert.Equal(t, "Updated", found.Name())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should bypass the cache for uncached reads", func(t *testing.T) {
+		// Arrange
+		product := models_mothers.NewProductMother().MustBuild()
+		repo := &use_cases_mocks.MockProductRepository{}
+		repo.On("GetByID", mock.Anything, product.ID()).Return(product, nil).Times(3)
+		cached := adapters.NewCachedProductRepository(repo, cache.NewMemoryCacheStore(10), time.Hour)
+		cached.GetByID(ctx, product.ID())
+		uncached := request_context.WithUncachedReads(ctx)
+
+		// Act
+		cached.GetByID(uncached, product.ID())
+		cached.GetByID(uncached, product.ID())
+
+		// Assert
+		repo.AssertExpectations(t)
+		assert.Equal(t, uint64(1), cached.Stats().Misses)
+	})
+
+	t.Run("should keep the cached product when a write fails", func(t *testing.T) {
+		// Arrange
+		product := models_mothers.NewProductMother().MustBuild()
+		repo := &use_cases_mocks.MockProductRepository{}
+		repo.On("GetByID", mock.Anything, product.ID()).Return(product, nil).Once()
+		repo.On("Delete", mock.Anything, product.ID(), (*int)(nil)).Return(errors.New("connection refused"))
+		cached := adapters.NewCachedProductRepository(repo, cache.NewMemoryCacheStore(10), time.Hour)
+		cached.GetByID(ctx, product.ID())
+
+		// Act
+		err := cached.Delete(ctx, product.ID(), nil)
+		found, _ := cached.GetByID(ctx, product.ID())
+
+		// Assert
+		assert.Error(t, err)
+		assert.NotNil(t, found)
+		assert.Zero(t, cached.Stats().Invalidations)
+	})
+
+	t.Run("should read from the repository when the store fails", func(t *testing.T) {
+		// Arrange
+		product := models_mothers.NewProductMother().MustBuild()
+		repo := &use_cases_mocks.MockProductRepository{}
+		repo.On("GetByID", mock.Anything, product.ID()).Return(product, nil)
+		store := interfaces_mocks.NewMockCacheStore()
+		store.On("Get", mock.Anything, mock.Anything).Return(nil, false, errors.New("connection refused"))
+		store.On("Set", mock.Anything, mock.Anything, mock.Anything, time.Hour).Return(errors.New("connection refused"))
+		cached := adapters.NewCachedProductRepository(repo, store, time.Hour)
+
+		// Act
+		found, err := cached.GetByID(ctx, product.ID())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, product.ID(), found.ID())
+	})
+}
@@ -0,0 +1,100 @@
+package adapters_tests
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/outbox"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func (suite *ProductRepositoryTestSuite) TestAuditHistory() {
+	suite.Run("should page through history newest first", func() {
+		// Arrange
+		productID := uuid.New()
+		for i := 0; i < 3; i++ {
+			entry := models.NewProductAuditEntry(productID, models.ProductAuditPatched, "alice", "req", nil)
+			suite.Require().NoError(suite.auditRepo.Record(suite.ctx, entry))
+		}
+		other := models.NewProductAuditEntry(uuid.New(), models.ProductAuditCreated, "bob", "req", nil)
+		suite.Require().NoError(suite.auditRepo.Record(suite.ctx, other))
+		limit := 2
+
+		// Act
+		firstPage, err := suite.auditRepo.GetByProductID(suite.ctx, models.ProductAuditQuery{ProductID: productID, Limit: &limit})
+		suite.Require().NoError(err)
+		suite.Require().NotNil(firstPage.Next)
+		secondPage, err := suite.auditRepo.GetByProductID(suite.ctx, models.ProductAuditQuery{ProductID: productID, Cursor: firstPage.Next, Limit: &limit})
+		suite.Require().NoError(err)
+
+		// Assert
+		suite.Len(firstPage.Items, 2)
+		suite.Len(secondPage.Items, 1)
+		suite.Nil(secondPage.Next)
+		suite.False(firstPage.Items[0].OccurredAt.Before(firstPage.Items[1].OccurredAt))
+		suite.False(firstPage.Items[1].OccurredAt.Before(secondPage.Items[0].OccurredAt))
+	})
+
+	suite.Run("should record who changed a price and how", func() {
+		// Arrange
+		ctx := request_context.WithActor(request_context.WithRequestID(suite.ctx, "req-42"), "alice")
+		createUseCase := use_cases.NewCreateProductUseCase(suite.repo, suite.auditRepo, suite.outbox, suite.txManager)
+		patchUseCase := use_cases.NewPatchProductUseCase(suite.repo, suite.auditRepo, suite.outbox, suite.txManager)
+		product := models_mothers.NewProductMother().WithSku("AUDIT-001").WithPrice(decimal.NewFromInt(10)).MustBuild()
+		suite.Require().NoError(createUseCase.Execute(ctx, product))
+
+		// Act
+		price := decimal.NewFromInt(12)
+		err := patchUseCase.Execute(ctx, product.ID(), models.ProductPatch{Price: &price}, nil)
+
+		// Assert
+		suite.Require().NoError(err)
+		page, err := suite.auditRepo.GetByProductID(suite.ctx, models.ProductAuditQuery{ProductID: product.ID()})
+		suite.Require().NoError(err)
+		suite.Require().Len(page.Items, 2)
+		latest := page.Items[0]
+		suite.Equal(models.ProductAuditPatched, latest.Action)
+		suite.Equal("alice", latest.Actor)
+		suite.Equal("req-42", latest.RequestID)
+		suite.Require().Len(latest.Changes, 1)
+		suite.Equal("price", latest.Changes[0].Field)
+		suite.Equal("10", *latest.Changes[0].From)
+		suite.Equal("12", *latest.Changes[0].To)
+
+		var eventNames []string
+		suite.Require().NoError(suite.db.Model(&outbox.OutboxMessageEntity{}).
+			Where("aggregate_id = ?", product.ID()).
+			Order("sequence").
+			Pluck("event_name", &eventNames).Error)
+		suite.Equal([]string{
+			models.ProductCreatedEventName,
+			models.ProductUpdatedEventName,
+			models.ProductPriceChangedEventName,
+		}, eventNames)
+	})
+
+	suite.Run("should roll back the change when it cannot be audited", func() {
+		// Arrange
+		product := models_mothers.NewProductMother().WithSku("AUDIT-002").MustBuild()
+		auditFailure := errors.New("audit failure")
+
+		// Act
+		err := suite.txManager.WithinTransaction(suite.ctx, func(ctx context.Context) error {
+			if err := suite.repo.Create(ctx, product); err != nil {
+				return err
+			}
+			return auditFailure
+		})
+
+		// Assert
+		suite.ErrorIs(err, auditFailure)
+		retrieved, err := suite.repo.GetByID(suite.ctx, product.ID())
+		suite.Require().NoError(err)
+		suite.Nil(retrieved)
+	})
+}
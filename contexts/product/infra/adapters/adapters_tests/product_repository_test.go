@@ -2,12 +2,19 @@ package adapters_tests
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
 	"github.com/Akiles94/go-test-api/contexts/product/infra/adapters"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/cache"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/outbox"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_adapters"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/suite"
@@ -23,6 +30,9 @@ type ProductRepositoryTestSuite struct {
 	container *postgres.PostgresContainer
 	db        *gorm.DB
 	repo      *adapters.ProductRepository
+	auditRepo *adapters.ProductAuditRepository
+	txManager *shared_adapters.GormTransactionManager
+	outbox    *outbox.GormEventOutbox
 	ctx       context.Context
 }
 
@@ -51,11 +61,15 @@ func (suite *ProductRepositoryTestSuite) SetupSuite() {
 	suite.Require().NoError(err)
 
 	// Auto-migrate schema
-	err = db.AutoMigrate(&adapters.ProductEntity{})
+	suite.Require().NoError(adapters.MigrateProductEntities(db))
+	err = db.AutoMigrate(&outbox.OutboxMessageEntity{})
 	suite.Require().NoError(err)
 
 	// Create repository singleton
 	suite.repo = adapters.NewProductRepository(db)
+	suite.auditRepo = adapters.NewProductAuditRepository(db)
+	suite.txManager = shared_adapters.NewGormTransactionManager(db)
+	suite.outbox = outbox.NewGormEventOutbox(db)
 	suite.db = db
 }
 
@@ -69,7 +83,7 @@ func (suite *ProductRepositoryTestSuite) TearDownTest() {
 	// Clean up all products after each test to ensure isolation
 	// Access DB through the repository's internal DB connection
 	if suite.repo != nil && suite.db != nil {
-		suite.db.Exec("TRUNCATE TABLE product_entities")
+		suite.db.Exec("TRUNCATE TABLE product_entities, product_audit_entry_entities, outbox_message_entities")
 	}
 }
 
@@ -112,8 +126,7 @@ func (suite *ProductRepositoryTestSuite) TestCreate() {
 		err = suite.repo.Create(suite.ctx, product)
 
 		// Assert
-		suite.Error(err)
-		suite.Contains(err.Error(), "duplicate key")
+		suite.ErrorIs(err, shared_handlers.ErrAlreadyExists)
 	})
 }
 
@@ -171,12 +184,13 @@ func (suite *ProductRepositoryTestSuite) TestGetAll() {
 		}
 
 		// Act
-		result, nextCursor, err := suite.repo.GetAll(suite.ctx, nil, nil)
+		page, err := suite.repo.GetAll(suite.ctx, models.ProductQuery{})
 
 		// Assert
 		suite.NoError(err)
-		suite.Len(result, 3)
-		suite.Nil(nextCursor)
+		suite.Len(page.Items, 3)
+		suite.Nil(page.Next)
+		suite.Nil(page.Prev)
 	})
 
 	suite.Run("should handle pagination with limit", func() {
@@ -193,12 +207,12 @@ func (suite *ProductRepositoryTestSuite) TestGetAll() {
 		limit := 3
 
 		// Act
-		result, nextCursor, err := suite.repo.GetAll(suite.ctx, nil, &limit)
+		page, err := suite.repo.GetAll(suite.ctx, models.ProductQuery{Limit: &limit})
 
 		// Assert
 		suite.NoError(err)
-		suite.Len(result, 3)
-		suite.NotNil(nextCursor)
+		suite.Len(page.Items, 3)
+		suite.NotNil(page.Next)
 	})
 
 	suite.Run("should handle cursor pagination", func() {
@@ -214,24 +228,131 @@ func (suite *ProductRepositoryTestSuite) TestGetAll() {
 
 		// Get first page
 		limit := 2
-		firstPage, cursor, err := suite.repo.GetAll(suite.ctx, nil, &limit)
+		firstPage, err := suite.repo.GetAll(suite.ctx, models.ProductQuery{Limit: &limit})
 		suite.Require().NoError(err)
-		suite.Len(firstPage, 2)
-		suite.NotNil(cursor)
+		suite.Len(firstPage.Items, 2)
+		suite.NotNil(firstPage.Next)
 
 		// Act - Get second page
-		secondPage, _, err := suite.repo.GetAll(suite.ctx, cursor, &limit)
+		secondPage, err := suite.repo.GetAll(suite.ctx, models.ProductQuery{Cursor: firstPage.Next, Limit: &limit})
 
 		// Assert
 		suite.NoError(err)
-		suite.Len(secondPage, 2)
+		suite.Len(secondPage.Items, 2)
 		// Products should be different
-		for _, firstProduct := range firstPage {
-			for _, secondProduct := range secondPage {
+		for _, firstProduct := range firstPage.Items {
+			for _, secondProduct := range secondPage.Items {
 				suite.NotEqual(firstProduct.ID(), secondProduct.ID())
 			}
 		}
 	})
+
+	suite.Run("should page backwards with prev cursor", func() {
+		// Arrange
+		for i := 1; i <= 5; i++ {
+			product := models_mothers.NewProductMother().
+				WithSku(fmt.Sprintf("PREV-%03d", i)).
+				WithName(fmt.Sprintf("Product %d", i)).
+				MustBuild()
+			suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+		}
+		limit := 2
+		sort := models.ProductSort{Field: models.ProductSortBySku, Direction: models.SortAscending}
+		firstPage, err := suite.repo.GetAll(suite.ctx, models.ProductQuery{Sort: sort, Limit: &limit})
+		suite.Require().NoError(err)
+		suite.Nil(firstPage.Prev)
+		secondPage, err := suite.repo.GetAll(suite.ctx, models.ProductQuery{Sort: sort, Cursor: firstPage.Next, Limit: &limit})
+		suite.Require().NoError(err)
+		suite.Require().NotNil(secondPage.Prev)
+
+		// Act
+		backPage, err := suite.repo.GetAll(suite.ctx, models.ProductQuery{Sort: sort, Cursor: secondPage.Prev, Limit: &limit})
+
+		// Assert
+		suite.NoError(err)
+		suite.Require().Len(backPage.Items, 2)
+		suite.Equal("PREV-001", backPage.Items[0].Sku())
+		suite.Equal("PREV-002", backPage.Items[1].Sku())
+		suite.Nil(backPage.Prev)
+		suite.NotNil(backPage.Next)
+	})
+}
+
+func (suite *ProductRepositoryTestSuite) TestGetAllWithFiltersAndSort() {
+	suite.Run("should filter by category, price range and name", func() {
+		// Arrange
+		products := []models.Product{
+			models_mothers.NewProductMother().WithSku("FILTER-001").WithName("Blue Phone").WithCategory("Electronics").WithPriceFloat(25).MustBuild(),
+			models_mothers.NewProductMother().WithSku("FILTER-002").WithName("Red Phone").WithCategory("Electronics").WithPriceFloat(75).MustBuild(),
+			models_mothers.NewProductMother().WithSku("FILTER-003").WithName("Blue Shirt").WithCategory("Clothing").WithPriceFloat(30).MustBuild(),
+			models_mothers.NewProductMother().WithSku("FILTER-004").WithName("Phone Case").WithCategory("Electronics").WithPriceFloat(10).MustBuild(),
+		}
+		for _, product := range products {
+			suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+		}
+		category := "Electronics"
+		name := "phone"
+		minPrice := decimal.NewFromInt(10)
+		maxPrice := decimal.NewFromInt(50)
+
+		// Act
+		page, err := suite.repo.GetAll(suite.ctx, models.ProductQuery{
+			Filter: models.ProductFilter{
+				Category: &category,
+				Name:     &name,
+				MinPrice: &minPrice,
+				MaxPrice: &maxPrice,
+			},
+			Sort: models.ProductSort{Field: models.ProductSortByPrice, Direction: models.SortAscending},
+		})
+
+		// Assert
+		suite.NoError(err)
+		suite.Nil(page.Next)
+		suite.Require().Len(page.Items, 2)
+		suite.Equal("FILTER-004", page.Items[0].Sku())
+		suite.Equal("FILTER-001", page.Items[1].Sku())
+	})
+
+	suite.Run("should paginate with a stable order under non-unique sort keys", func() {
+		// Arrange
+		for i := 1; i <= 5; i++ {
+			product := models_mothers.NewProductMother().
+				WithSku(fmt.Sprintf("SORT-%03d", i)).
+				WithName(fmt.Sprintf("Product %d", i)).
+				WithPriceFloat(float64(10 * (i % 2))).
+				MustBuild()
+			suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+		}
+		limit := 2
+		query := models.ProductQuery{
+			Sort:  models.ProductSort{Field: models.ProductSortByPrice, Direction: models.SortDescending},
+			Limit: &limit,
+		}
+
+		// Act
+		var seen []models.Product
+		for {
+			page, err := suite.repo.GetAll(suite.ctx, query)
+			suite.Require().NoError(err)
+			seen = append(seen, page.Items...)
+			if page.Next == nil {
+				break
+			}
+			query.Cursor = page.Next
+		}
+
+		// Assert
+		suite.Require().Len(seen, 5)
+		ids := make(map[uuid.UUID]bool)
+		for i, product := range seen {
+			ids[product.ID()] = true
+			if i > 0 {
+				suite.False(product.Price().GreaterThan(seen[i-1].Price()))
+			}
+		}
+		suite.Len(ids, 5)
+	})
 }
 
 func (suite *ProductRepositoryTestSuite) TestUpdate() {
@@ -247,16 +368,12 @@ func (suite *ProductRepositoryTestSuite) TestUpdate() {
 		err := suite.repo.Create(suite.ctx, original)
 		suite.Require().NoError(err)
 
-		updated := models_mothers.NewProductMother().
-			WithID(original.ID()).
-			WithSku("UPDATED-001").
-			WithName("Updated Name").
-			WithCategory("Updated Category").
-			WithPriceFloat(200.00).
-			MustBuild()
+		updated := models_mothers.NewProductMother().WithID(original.ID()).MustBuild()
+		_, err = updated.Update("UPDATED-001", "Updated Name", "Updated Category", decimal.NewFromFloat(200.00))
+		suite.Require().NoError(err)
 
 		// Act
-		err = suite.repo.Update(suite.ctx, original.ID(), updated)
+		err = suite.repo.Update(suite.ctx, original.ID(), updated, nil)
 
 		// Assert
 		suite.NoError(err)
@@ -267,6 +384,8 @@ func (suite *ProductRepositoryTestSuite) TestUpdate() {
 		suite.Equal("Updated Name", retrieved.Name())
 		suite.Equal("Updated Category", retrieved.Category())
 		suite.True(decimal.NewFromFloat(200.00).Equal(retrieved.Price()))
+		suite.True(retrieved.UpdatedAt().After(original.UpdatedAt()))
+		suite.WithinDuration(updated.UpdatedAt(), retrieved.UpdatedAt(), time.Microsecond)
 	})
 
 	suite.Run("should return error when updating non-existent product", func() {
@@ -275,76 +394,482 @@ func (suite *ProductRepositoryTestSuite) TestUpdate() {
 		product := models_mothers.NewProductMother().WithID(nonExistentID).MustBuild()
 
 		// Act
-		err := suite.repo.Update(suite.ctx, nonExistentID, product)
+		err := suite.repo.Update(suite.ctx, nonExistentID, product, nil)
 
 		// Assert
-		suite.Error(err)
-		suite.Contains(err.Error(), "record not found")
+		suite.ErrorIs(err, shared_handlers.ErrNotFound)
 	})
 }
 
-func (suite *ProductRepositoryTestSuite) TestPatch() {
-	suite.Run("should patch product fields successfully", func() {
+func (suite *ProductRepositoryTestSuite) TestDelete() {
+	suite.Run("should delete product successfully", func() {
 		// Arrange
-		original := models_mothers.NewProductMother().
-			WithSku("PATCH-001").
-			WithName("Original Name").
-			WithCategory("Original Category").
-			WithPriceFloat(100.00).
-			MustBuild()
+		product := models_mothers.NewProductMother().MustBuild()
+		err := suite.repo.Create(suite.ctx, product)
+		suite.Require().NoError(err)
 
-		err := suite.repo.Create(suite.ctx, original)
+		// Act
+		err = suite.repo.Delete(suite.ctx, product.ID(), nil)
+
+		// Assert
+		suite.NoError(err)
+
+		deleted, err := suite.repo.GetByID(suite.ctx, product.ID())
+		suite.NoError(err)
+		suite.Nil(deleted)
+	})
+
+	suite.Run("should return not found when deleting non-existent product", func() {
+		// Act
+		err := suite.repo.Delete(suite.ctx, uuid.New(), nil)
+
+		// Assert
+		suite.ErrorIs(err, shared_handlers.ErrNotFound)
+	})
+
+	suite.Run("should return not found when deleting non-existent product with a version", func() {
+		// Arrange
+		version := 1
+
+		// Act
+		err := suite.repo.Delete(suite.ctx, uuid.New(), &version)
+
+		// Assert
+		suite.ErrorIs(err, shared_handlers.ErrNotFound)
+	})
+}
+
+func (suite *ProductRepositoryTestSuite) TestOptimisticConcurrency() {
+	suite.Run("should bump version on every update", func() {
+		// Arrange
+		product := models_mothers.NewProductMother().WithSku("VERSION-001").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+		updated := models_mothers.NewProductMother().WithID(product.ID()).WithSku("VERSION-001").WithName("Renamed").MustBuild()
+
+		// Act
+		err := suite.repo.Update(suite.ctx, product.ID(), updated, nil)
+		suite.Require().NoError(err)
+		renamedAgain := models_mothers.NewProductMother().WithID(product.ID()).WithSku("VERSION-001").WithName("Renamed again").WithVersion(2).MustBuild()
+		err = suite.repo.Update(suite.ctx, product.ID(), renamedAgain, nil)
 		suite.Require().NoError(err)
 
-		updates := map[string]interface{}{
-			"name":  "Patched Name",
-			"price": decimal.NewFromFloat(299.99),
-		}
+		// Assert
+		retrieved, err := suite.repo.GetByID(suite.ctx, product.ID())
+		suite.Require().NoError(err)
+		suite.Equal(3, retrieved.Version())
+	})
+
+	suite.Run("should reject update based on a stale version", func() {
+		// Arrange
+		product := models_mothers.NewProductMother().WithSku("VERSION-002").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+		staleVersion := product.Version()
+		firstEdit := models_mothers.NewProductMother().WithID(product.ID()).WithSku("VERSION-002").WithName("First editor").MustBuild()
+		secondEdit := models_mothers.NewProductMother().WithID(product.ID()).WithSku("VERSION-002").WithName("Second editor").MustBuild()
+		suite.Require().NoError(suite.repo.Update(suite.ctx, product.ID(), firstEdit, &staleVersion))
 
 		// Act
-		err = suite.repo.Patch(suite.ctx, original.ID(), updates)
+		err := suite.repo.Update(suite.ctx, product.ID(), secondEdit, &staleVersion)
 
 		// Assert
-		suite.NoError(err)
+		suite.ErrorIs(err, shared_handlers.ErrPreconditionFailed)
+		retrieved, err := suite.repo.GetByID(suite.ctx, product.ID())
+		suite.Require().NoError(err)
+		suite.Equal("First editor", retrieved.Name())
+	})
 
-		updated, err := suite.repo.GetByID(suite.ctx, original.ID())
+	suite.Run("should not overwrite a change committed after the product was loaded", func() {
+		// Arrange
+		product := models_mothers.NewProductMother().WithSku("VERSION-004").WithName("Original").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+		loaded, err := suite.repo.GetByID(suite.ctx, product.ID())
+		suite.Require().NoError(err)
+		concurrent, err := suite.repo.GetByID(suite.ctx, product.ID())
+		suite.Require().NoError(err)
+		_, err = concurrent.Update(concurrent.Sku(), "Renamed concurrently", concurrent.Category(), concurrent.Price())
+		suite.Require().NoError(err)
+		suite.Require().NoError(suite.repo.Update(suite.ctx, product.ID(), concurrent, nil))
+		_, err = loaded.Update(loaded.Sku(), loaded.Name(), loaded.Category(), decimal.NewFromInt(5))
 		suite.Require().NoError(err)
-		suite.Equal("Patched Name", updated.Name())
-		suite.True(decimal.NewFromFloat(299.99).Equal(updated.Price()))
-		suite.Equal(original.Sku(), updated.Sku())
-		suite.Equal(original.Category(), updated.Category())
+
+		// Act
+		err = suite.repo.Update(suite.ctx, product.ID(), loaded, nil)
+
+		// Assert
+		suite.ErrorIs(err, shared_handlers.ErrPreconditionFailed)
+		retrieved, err := suite.repo.GetByID(suite.ctx, product.ID())
+		suite.Require().NoError(err)
+		suite.Equal("Renamed concurrently", retrieved.Name())
+		suite.True(product.Price().Equal(retrieved.Price()))
 	})
 
-	suite.Run("should return error when patching non-existent product", func() {
+	suite.Run("should reject delete based on a stale version", func() {
 		// Arrange
-		nonExistentID := uuid.New()
-		updates := map[string]interface{}{"name": "New Name"}
+		product := models_mothers.NewProductMother().WithSku("VERSION-003").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+		staleVersion := product.Version() - 1
 
 		// Act
-		err := suite.repo.Patch(suite.ctx, nonExistentID, updates)
+		err := suite.repo.Delete(suite.ctx, product.ID(), &staleVersion)
 
 		// Assert
-		suite.Error(err)
-		suite.Contains(err.Error(), "record not found")
+		suite.ErrorIs(err, shared_handlers.ErrPreconditionFailed)
+		retrieved, err := suite.repo.GetByID(suite.ctx, product.ID())
+		suite.Require().NoError(err)
+		suite.NotNil(retrieved)
 	})
 }
 
-func (suite *ProductRepositoryTestSuite) TestDelete() {
-	suite.Run("should delete product successfully", func() {
+func (suite *ProductRepositoryTestSuite) TestSoftDelete() {
+	suite.Run("should move deleted product to the trash", func() {
 		// Arrange
-		product := models_mothers.NewProductMother().MustBuild()
-		err := suite.repo.Create(suite.ctx, product)
+		product := models_mothers.NewProductMother().WithSku("TRASH-001").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+
+		// Act
+		err := suite.repo.Delete(suite.ctx, product.ID(), nil)
+
+		// Assert
+		suite.Require().NoError(err)
+		live, err := suite.repo.GetAll(suite.ctx, models.ProductQuery{})
 		suite.Require().NoError(err)
+		suite.Empty(live.Items)
+
+		trash, err := suite.repo.GetAll(suite.ctx, models.ProductQuery{Filter: models.ProductFilter{Trashed: true}})
+		suite.Require().NoError(err)
+		suite.Require().Len(trash.Items, 1)
+		suite.Equal(product.ID(), trash.Items[0].ID())
+		suite.True(trash.Items[0].IsDeleted())
+	})
+
+	suite.Run("should allow reusing the SKU of a trashed product", func() {
+		// Arrange
+		product := models_mothers.NewProductMother().WithSku("TRASH-002").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+		suite.Require().NoError(suite.repo.Delete(suite.ctx, product.ID(), nil))
+		replacement := models_mothers.NewProductMother().WithSku("TRASH-002").MustBuild()
 
 		// Act
-		err = suite.repo.Delete(suite.ctx, product.ID())
+		err := suite.repo.Create(suite.ctx, replacement)
 
 		// Assert
 		suite.NoError(err)
+	})
 
-		deleted, err := suite.repo.GetByID(suite.ctx, product.ID())
-		suite.NoError(err)
-		suite.Nil(deleted)
+	suite.Run("should keep SKUs unique among live products", func() {
+		// Arrange
+		product := models_mothers.NewProductMother().WithSku("TRASH-003").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+		duplicate := models_mothers.NewProductMother().WithSku("TRASH-003").MustBuild()
+
+		// Act
+		err := suite.repo.Create(suite.ctx, duplicate)
+
+		// Assert
+		suite.ErrorIs(err, shared_handlers.ErrDuplicateSku)
+	})
+
+	suite.Run("should reject taking the SKU of another live product on update", func() {
+		// Arrange
+		product := models_mothers.NewProductMother().WithSku("TRASH-004").MustBuild()
+		other := models_mothers.NewProductMother().WithSku("TRASH-005").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+		suite.Require().NoError(suite.repo.Create(suite.ctx, other))
+		renamed := models_mothers.NewProductMother().WithID(other.ID()).WithSku("TRASH-004").MustBuild()
+
+		// Act
+		err := suite.repo.Update(suite.ctx, other.ID(), renamed, nil)
+
+		// Assert
+		suite.ErrorIs(err, shared_handlers.ErrDuplicateSku)
+	})
+}
+
+func (suite *ProductRepositoryTestSuite) TestGetTrashedByID() {
+	suite.Run("should return a trashed product", func() {
+		// Arrange
+		product := models_mothers.NewProductMother().WithSku("TRASHED-001").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+		suite.Require().NoError(suite.repo.Delete(suite.ctx, product.ID(), nil))
+
+		// Act
+		trashed, err := suite.repo.GetTrashedByID(suite.ctx, product.ID())
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().NotNil(trashed)
+		suite.Equal("TRASHED-001", trashed.Sku())
+		suite.NotNil(trashed.DeletedAt())
+	})
+
+	suite.Run("should not return a live product", func() {
+		// Arrange
+		product := models_mothers.NewProductMother().WithSku("TRASHED-002").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+
+		// Act
+		trashed, err := suite.repo.GetTrashedByID(suite.ctx, product.ID())
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Nil(trashed)
+	})
+}
+
+func (suite *ProductRepositoryTestSuite) TestRestore() {
+	suite.Run("should restore trashed product and bump its version", func() {
+		// Arrange
+		product := models_mothers.NewProductMother().WithSku("RESTORE-001").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+		suite.Require().NoError(suite.repo.Delete(suite.ctx, product.ID(), nil))
+
+		trashed, err := suite.repo.GetTrashedByID(suite.ctx, product.ID())
+		suite.Require().NoError(err)
+		trashed.Restore()
+
+		// Act
+		err = suite.repo.Restore(suite.ctx, trashed)
+
+		// Assert
+		suite.Require().NoError(err)
+		restored, err := suite.repo.GetByID(suite.ctx, product.ID())
+		suite.Require().NoError(err)
+		suite.Require().NotNil(restored)
+		suite.False(restored.IsDeleted())
+		suite.Equal(product.Version()+1, restored.Version())
+		suite.WithinDuration(trashed.UpdatedAt(), restored.UpdatedAt(), time.Microsecond)
+	})
+
+	suite.Run("should return not found for a live product", func() {
+		// Arrange
+		product := models_mothers.NewProductMother().WithSku("RESTORE-002").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+
+		// Act
+		err := suite.repo.Restore(suite.ctx, product)
+
+		// Assert
+		suite.ErrorIs(err, shared_handlers.ErrNotFound)
+	})
+}
+
+func (suite *ProductRepositoryTestSuite) TestPurge() {
+	suite.Run("should permanently remove trashed product", func() {
+		// Arrange
+		product := models_mothers.NewProductMother().WithSku("PURGE-001").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+		suite.Require().NoError(suite.repo.Delete(suite.ctx, product.ID(), nil))
+
+		// Act
+		err := suite.repo.Purge(suite.ctx, product.ID())
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.ErrorIs(suite.repo.Restore(suite.ctx, product), shared_handlers.ErrNotFound)
+	})
+
+	suite.Run("should not purge a live product", func() {
+		// Arrange
+		product := models_mothers.NewProductMother().WithSku("PURGE-002").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+
+		// Act
+		err := suite.repo.Purge(suite.ctx, product.ID())
+
+		// Assert
+		suite.ErrorIs(err, shared_handlers.ErrNotFound)
+		retrieved, err := suite.repo.GetByID(suite.ctx, product.ID())
+		suite.Require().NoError(err)
+		suite.NotNil(retrieved)
+	})
+}
+
+func (suite *ProductRepositoryTestSuite) TestExistingSkus() {
+	suite.Run("should return the SKUs of live products only", func() {
+		// Arrange
+		live := models_mothers.NewProductMother().WithSku("EXISTS-001").MustBuild()
+		trashed := models_mothers.NewProductMother().WithSku("EXISTS-002").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, live))
+		suite.Require().NoError(suite.repo.Create(suite.ctx, trashed))
+		suite.Require().NoError(suite.repo.Delete(suite.ctx, trashed.ID(), nil))
+
+		// Act
+		existing, err := suite.repo.ExistingSkus(suite.ctx, []string{"EXISTS-001", "EXISTS-002", "EXISTS-003"})
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Equal([]string{"EXISTS-001"}, existing)
+	})
+}
+
+func (suite *ProductRepositoryTestSuite) TestExport() {
+	suite.Run("should yield every live product matching the filter in sort order", func() {
+		// Arrange
+		category := "Export"
+		cheap := models_mothers.NewProductMother().WithSku("EXPORT-001").WithCategory(category).WithPriceFloat(5).MustBuild()
+		dear := models_mothers.NewProductMother().WithSku("EXPORT-002").WithCategory(category).WithPriceFloat(50).MustBuild()
+		trashed := models_mothers.NewProductMother().WithSku("EXPORT-003").WithCategory(category).WithPriceFloat(20).MustBuild()
+		other := models_mothers.NewProductMother().WithSku("EXPORT-004").WithCategory("Other").MustBuild()
+		for _, product := range []models.Product{cheap, dear, trashed, other} {
+			suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+		}
+		suite.Require().NoError(suite.repo.Delete(suite.ctx, trashed.ID(), nil))
+		query := models.ProductExportQuery{
+			Filter: models.ProductFilter{Category: &category},
+			Sort:   models.ProductSort{Field: models.ProductSortByPrice, Direction: models.SortDescending},
+		}
+
+		// Act
+		skus := []string{}
+		for product, err := range suite.repo.Export(suite.ctx, query) {
+			suite.Require().NoError(err)
+			skus = append(skus, product.Sku())
+		}
+
+		// Assert
+		suite.Equal([]string{"EXPORT-002", "EXPORT-001"}, skus)
+	})
+
+	suite.Run("should stop with the context error when cancelled", func() {
+		// Arrange
+		ctx, cancel := context.WithCancel(suite.ctx)
+		cancel()
+
+		// Act
+		var exportErr error
+		for _, err := range suite.repo.Export(ctx, models.ProductExportQuery{}) {
+			exportErr = err
+		}
+
+		// Assert
+		suite.ErrorIs(exportErr, context.Canceled)
+	})
+}
+
+func (suite *ProductRepositoryTestSuite) TestSearch() {
+	suite.Run("should rank name matches above category matches and highlight them", func() {
+		// Arrange
+		byName := models_mothers.NewProductMother().WithSku("SEARCH-001").WithName("Laptop Stand").WithCategory("Accessories").MustBuild()
+		byCategory := models_mothers.NewProductMother().WithSku("SEARCH-002").WithName("Docking Station").WithCategory("Laptop").MustBuild()
+		unrelated := models_mothers.NewProductMother().WithSku("SEARCH-003").WithName("Desk Lamp").WithCategory("Lighting").MustBuild()
+		trashed := models_mothers.NewProductMother().WithSku("SEARCH-004").WithName("Laptop Bag").WithCategory("Bags").MustBuild()
+		for _, product := range []models.Product{byName, byCategory, unrelated, trashed} {
+			suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+		}
+		suite.Require().NoError(suite.repo.Delete(suite.ctx, trashed.ID(), nil))
+
+		// Act
+		page, err := suite.repo.Search(suite.ctx, models.ProductSearchQuery{Text: "laptop"})
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(page.Items, 2)
+		suite.Equal(byName.ID(), page.Items[0].Product.ID())
+		suite.Equal(byCategory.ID(), page.Items[1].Product.ID())
+		suite.Greater(page.Items[0].Rank, page.Items[1].Rank)
+		suite.Equal("<mark>Laptop</mark> Stand", page.Items[0].Highlights.Name)
+		suite.Equal("<mark>Laptop</mark>", page.Items[1].Highlights.Category)
+		suite.Nil(page.Next)
+	})
+
+	suite.Run("should escape highlighted values for HTML", func() {
+		// Arrange
+		product := models_mothers.NewProductMother().WithSku("SEARCH-XSS").WithName(`Keyboard <img src=x onerror="alert(1)" & "<b`).MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+
+		// Act
+		page, err := suite.repo.Search(suite.ctx, models.ProductSearchQuery{Text: "keyboard"})
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().Len(page.Items, 1)
+		highlight := page.Items[0].Highlights.Name
+		suite.True(strings.HasPrefix(highlight, "<mark>Keyboard</mark>"))
+		unmarked := strings.NewReplacer("<mark>", "", "</mark>", "").Replace(highlight)
+		suite.NotContains(unmarked, "<")
+		suite.NotContains(unmarked, `"`)
+	})
+
+	suite.Run("should page forwards and backwards through hits", func() {
+		// Arrange
+		for i := range 5 {
+			product := models_mothers.NewProductMother().
+				WithSku(fmt.Sprintf("PAGE-%03d", i)).
+				WithName(fmt.Sprintf("Monitor %d", i)).
+				MustBuild()
+			suite.Require().NoError(suite.repo.Create(suite.ctx, product))
+		}
+		limit := 2
+
+		// Act
+		first, err := suite.repo.Search(suite.ctx, models.ProductSearchQuery{Text: "monitor", Limit: &limit})
+		suite.Require().NoError(err)
+		second, err := suite.repo.Search(suite.ctx, models.ProductSearchQuery{Text: "monitor", Limit: &limit, Cursor: first.Next})
+		suite.Require().NoError(err)
+		back, err := suite.repo.Search(suite.ctx, models.ProductSearchQuery{Text: "monitor", Limit: &limit, Cursor: second.Prev})
+		suite.Require().NoError(err)
+
+		// Assert
+		suite.Require().Len(first.Items, 2)
+		suite.Require().Len(second.Items, 2)
+		suite.NotEqual(first.Items[1].Product.ID(), second.Items[0].Product.ID())
+		suite.Require().Len(back.Items, 2)
+		suite.Equal(first.Items[0].Product.ID(), back.Items[0].Product.ID())
+		suite.Equal(first.Items[1].Product.ID(), back.Items[1].Product.ID())
+		suite.Nil(back.Prev)
+	})
+}
+
+func (suite *ProductRepositoryTestSuite) TestCachedUpdateWithinTransaction() {
+	suite.Run("should drop the cached product once the transaction commits", func() {
+		// Arrange
+		cached := adapters.NewCachedProductRepository(suite.repo, cache.NewMemoryCacheStore(10), time.Hour)
+		original := models_mothers.NewProductMother().WithSku("CACHE-001").WithName("Original Name").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, original))
+		_, err := cached.GetByID(suite.ctx, original.ID())
+		suite.Require().NoError(err)
+		updated := models_mothers.NewProductMother().WithID(original.ID()).WithSku("CACHE-001").WithName("Updated Name").MustBuild()
+
+		// Act
+		err = suite.txManager.WithinTransaction(suite.ctx, func(ctx context.Context) error {
+			if err := cached.Update(ctx, original.ID(), updated, nil); err != nil {
+				return err
+			}
+			stale, err := cached.GetByID(suite.ctx, original.ID())
+			suite.Require().NoError(err)
+			suite.Equal("Original Name", stale.Name())
+			return nil
+		})
+
+		// Assert
+		suite.Require().NoError(err)
+		retrieved, err := cached.GetByID(suite.ctx, original.ID())
+		suite.Require().NoError(err)
+		suite.Equal("Updated Name", retrieved.Name())
+	})
+
+	suite.Run("should keep the cached product when the transaction rolls back", func() {
+		// Arrange
+		cached := adapters.NewCachedProductRepository(suite.repo, cache.NewMemoryCacheStore(10), time.Hour)
+		original := models_mothers.NewProductMother().WithSku("CACHE-002").WithName("Original Name").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, original))
+		_, err := cached.GetByID(suite.ctx, original.ID())
+		suite.Require().NoError(err)
+		updated := models_mothers.NewProductMother().WithID(original.ID()).WithSku("CACHE-002").WithName("Updated Name").MustBuild()
+		rollback := errors.New("rollback")
+
+		// Act
+		err = suite.txManager.WithinTransaction(suite.ctx, func(ctx context.Context) error {
+			suite.Require().NoError(cached.Update(ctx, original.ID(), updated, nil))
+			return rollback
+		})
+
+		// Assert
+		suite.ErrorIs(err, rollback)
+		suite.Zero(cached.Stats().Invalidations)
+		retrieved, err := cached.GetByID(suite.ctx, original.ID())
+		suite.Require().NoError(err)
+		suite.Equal("Original Name", retrieved.Name())
 	})
 }
 
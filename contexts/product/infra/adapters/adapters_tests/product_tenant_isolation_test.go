@@ -0,0 +1,109 @@
+package adapters_tests
+
+import (
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+)
+
+func (suite *ProductRepositoryTestSuite) TestTenantIsolation() {
+	suite.Run("should never let a tenant read another tenant's products", func() {
+		// Arrange
+		acme := request_context.WithTenant(suite.ctx, "acme")
+		globex := request_context.WithTenant(suite.ctx, "globex")
+		product := models_mothers.NewProductMother().WithSku("TENANT-001").WithName("Anvil").MustBuild()
+		trashed := models_mothers.NewProductMother().WithSku("TENANT-002").WithName("Anvil Spare").MustBuild()
+		suite.Require().NoError(suite.repo.Create(acme, product))
+		suite.Require().NoError(suite.repo.Create(acme, trashed))
+		suite.Require().NoError(suite.repo.Delete(acme, trashed.ID(), nil))
+
+		// Act
+		byID, getErr := suite.repo.GetByID(globex, product.ID())
+		page, listErr := suite.repo.GetAll(globex, models.ProductQuery{})
+		trash, trashErr := suite.repo.GetAll(globex, models.ProductQuery{Filter: models.ProductFilter{Trashed: true}})
+		hits, searchErr := suite.repo.Search(globex, models.ProductSearchQuery{Text: "anvil"})
+		existing, skusErr := suite.repo.ExistingSkus(globex, []string{"TENANT-001"})
+		exported := 0
+		for _, err := range suite.repo.Export(globex, models.ProductExportQuery{}) {
+			suite.Require().NoError(err)
+			exported++
+		}
+
+		// Assert
+		suite.NoError(getErr)
+		suite.Nil(byID)
+		suite.NoError(listErr)
+		suite.Empty(page.Items)
+		suite.NoError(trashErr)
+		suite.Empty(trash.Items)
+		suite.NoError(searchErr)
+		suite.Empty(hits.Items)
+		suite.NoError(skusErr)
+		suite.Empty(existing)
+		suite.Zero(exported)
+	})
+
+	suite.Run("should never let a tenant modify another tenant's products", func() {
+		// Arrange
+		acme := request_context.WithTenant(suite.ctx, "acme")
+		globex := request_context.WithTenant(suite.ctx, "globex")
+		product := models_mothers.NewProductMother().WithSku("TENANT-003").WithName("Rocket").MustBuild()
+		trashed := models_mothers.NewProductMother().WithSku("TENANT-004").MustBuild()
+		suite.Require().NoError(suite.repo.Create(acme, product))
+		suite.Require().NoError(suite.repo.Create(acme, trashed))
+		suite.Require().NoError(suite.repo.Delete(acme, trashed.ID(), nil))
+		changed := models_mothers.NewProductMother().WithID(product.ID()).WithSku("TENANT-003").WithName("Hijacked").MustBuild()
+		version := 1
+
+		// Act & Assert
+		suite.ErrorIs(suite.repo.Update(globex, product.ID(), changed, nil), shared_handlers.ErrNotFound)
+		suite.ErrorIs(suite.repo.Delete(globex, product.ID(), nil), shared_handlers.ErrNotFound)
+		suite.ErrorIs(suite.repo.Delete(globex, product.ID(), &version), shared_handlers.ErrNotFound)
+		suite.ErrorIs(suite.repo.Restore(globex, trashed), shared_handlers.ErrNotFound)
+		suite.ErrorIs(suite.repo.Purge(globex, trashed.ID()), shared_handlers.ErrNotFound)
+
+		stored, err := suite.repo.GetByID(acme, product.ID())
+		suite.Require().NoError(err)
+		suite.Require().NotNil(stored)
+		suite.Equal("Rocket", stored.Name())
+		suite.Equal(1, stored.Version())
+		inTrash, err := suite.repo.GetAll(acme, models.ProductQuery{Filter: models.ProductFilter{Trashed: true}})
+		suite.Require().NoError(err)
+		suite.Len(inTrash.Items, 1)
+	})
+
+	suite.Run("should only require SKUs to be unique within a tenant", func() {
+		// Arrange
+		acme := request_context.WithTenant(suite.ctx, "acme")
+		globex := request_context.WithTenant(suite.ctx, "globex")
+		suite.Require().NoError(suite.repo.Create(acme, models_mothers.NewProductMother().WithSku("SHARED-001").MustBuild()))
+
+		// Act
+		otherTenantErr := suite.repo.Create(globex, models_mothers.NewProductMother().WithSku("SHARED-001").MustBuild())
+		sameTenantErr := suite.repo.Create(acme, models_mothers.NewProductMother().WithSku("SHARED-001").MustBuild())
+
+		// Assert
+		suite.NoError(otherTenantErr)
+		suite.ErrorIs(sameTenantErr, shared_handlers.ErrDuplicateSku)
+	})
+
+	suite.Run("should keep the history of a product within its tenant", func() {
+		// Arrange
+		acme := request_context.WithTenant(suite.ctx, "acme")
+		globex := request_context.WithTenant(suite.ctx, "globex")
+		product := models_mothers.NewProductMother().MustBuild()
+		entry := models.NewProductAuditEntry(product.ID(), models.ProductAuditCreated, "alice", "req", nil)
+		suite.Require().NoError(suite.auditRepo.Record(acme, entry))
+
+		// Act
+		foreign, foreignErr := suite.auditRepo.GetByProductID(globex, models.ProductAuditQuery{ProductID: product.ID()})
+		own, ownErr := suite.auditRepo.GetByProductID(acme, models.ProductAuditQuery{ProductID: product.ID()})
+
+		// Assert
+		suite.NoError(foreignErr)
+		suite.Empty(foreign.Items)
+		suite.NoError(ownErr)
+		suite.Len(own.Items, 1)
+	})
+}
@@ -0,0 +1,275 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"log"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_adapters"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ProductCacheStats counts how GetByID was answered since the cache started.
+type ProductCacheStats struct {
+	Hits          uint64  `json:"hits"`
+	Misses        uint64  `json:"misses"`
+	HitRatio      float64 `json:"hit_ratio"`
+	Invalidations uint64  `json:"invalidations"`
+}
+
+// cachedProduct is how a product is kept in the cache. Every read rebuilds a
+// product from it, so callers never share a cached product.
+type cachedProduct struct {
+	ID        uuid.UUID       `json:"id"`
+	Sku       string          `json:"sku"`
+	Name      string          `json:"name"`
+	Category  string          `json:"category"`
+	Price     decimal.Decimal `json:"price"`
+	Version   int             `json:"version"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	DeletedAt *time.Time      `json:"deleted_at,omitempty"`
+}
+
+// productLoad is a GetByID miss reading from repo. A write dropping the
+// product meanwhile marks it, since what was read may predate the write.
+type productLoad struct {
+	invalidated bool
+}
+
+// CachedProductRepository keeps the products read by GetByID in store for ttl,
+// in front of any other ProductRepositoryPort. Writes through it drop the
+// product they change once their transaction commits, and a miss that was
+// reading the product at the time does not store what it read. Products
+// written some other way, e.g. by another instance, may be served stale until
+// ttl has passed, even when the instances share store: a miss on one instance
+// can store a version read just before a write on the other.
+//
+// Reads inside a transaction, or asked for with
+// request_context.WithUncachedReads, always go to repo, since they usually
+// precede a write and must see the latest version. If store fails, repo
+// answers.
+type CachedProductRepository struct {
+	repo  outbound.ProductRepositoryPort
+	store interfaces.CacheStore
+	ttl   time.Duration
+
+	mu    sync.Mutex
+	loads map[string][]*productLoad
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+func NewCachedProductRepository(repo outbound.ProductRepositoryPort, store interfaces.CacheStore, ttl time.Duration) *CachedProductRepository {
+	return &CachedProductRepository{repo: repo, store: store, ttl: ttl, loads: map[string][]*productLoad{}}
+}
+
+func (cr *CachedProductRepository) GetByID(ctx context.Context, id uuid.UUID) (models.Product, error) {
+	if shared_adapters.InTransaction(ctx) || request_context.UncachedReads(ctx) {
+		return cr.repo.GetByID(ctx, id)
+	}
+	key := productCacheKey(ctx, id)
+	if product, ok := cr.cached(ctx, key); ok {
+		cr.hits.Add(1)
+		return product, nil
+	}
+	cr.misses.Add(1)
+
+	load := cr.startLoad(key)
+	product, err := cr.repo.GetByID(ctx, id)
+	if err != nil || product == nil {
+		cr.finishLoad(key, load)
+		return product, err
+	}
+	if cr.wasInvalidated(load) {
+		cr.finishLoad(key, load)
+		return product, nil
+	}
+	value, err := json.Marshal(cachedProduct{
+		ID:        product.ID(),
+		Sku:       product.Sku(),
+		Name:      product.Name(),
+		Category:  product.Category(),
+		Price:     product.Price(),
+		Version:   product.Version(),
+		UpdatedAt: product.UpdatedAt(),
+		DeletedAt: product.DeletedAt(),
+	})
+	if err == nil {
+		err = cr.store.Set(ctx, key, value, cr.ttl)
+	}
+	if err != nil {
+		log.Printf("⚠️  Caching product %s failed: %v", id, err)
+	}
+	// A write that dropped the product between the check above and Set
+	// found nothing to drop, so what was just stored goes too.
+	if cr.finishLoad(key, load) && err == nil {
+		if err := cr.store.Delete(context.WithoutCancel(ctx), key); err != nil {
+			log.Printf("⚠️  Invalidating cached product %s failed: %v", id, err)
+		}
+	}
+	return product, nil
+}
+
+func (cr *CachedProductRepository) GetTrashedByID(ctx context.Context, id uuid.UUID) (models.Product, error) {
+	return cr.repo.GetTrashedByID(ctx, id)
+}
+
+func (cr *CachedProductRepository) Create(ctx context.Context, product models.Product) error {
+	if err := cr.repo.Create(ctx, product); err != nil {
+		return err
+	}
+	cr.invalidate(ctx, product.ID())
+	return nil
+}
+
+func (cr *CachedProductRepository) Update(ctx context.Context, id uuid.UUID, product models.Product, expectedVersion *int) error {
+	if err := cr.repo.Update(ctx, id, product, expectedVersion); err != nil {
+		return err
+	}
+	cr.invalidate(ctx, id)
+	return nil
+}
+
+func (cr *CachedProductRepository) Delete(ctx context.Context, id uuid.UUID, expectedVersion *int) error {
+	if err := cr.repo.Delete(ctx, id, expectedVersion); err != nil {
+		return err
+	}
+	cr.invalidate(ctx, id)
+	return nil
+}
+
+func (cr *CachedProductRepository) Restore(ctx context.Context, product models.Product) error {
+	if err := cr.repo.Restore(ctx, product); err != nil {
+		return err
+	}
+	cr.invalidate(ctx, product.ID())
+	return nil
+}
+
+func (cr *CachedProductRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	if err := cr.repo.Purge(ctx, id); err != nil {
+		return err
+	}
+	cr.invalidate(ctx, id)
+	return nil
+}
+
+func (cr *CachedProductRepository) GetAll(ctx context.Context, query models.ProductQuery) (models.ProductPage, error) {
+	return cr.repo.GetAll(ctx, query)
+}
+
+func (cr *CachedProductRepository) Search(ctx context.Context, query models.ProductSearchQuery) (models.ProductSearchPage, error) {
+	return cr.repo.Search(ctx, query)
+}
+
+func (cr *CachedProductRepository) ExistingSkus(ctx context.Context, skus []string) ([]string, error) {
+	return cr.repo.ExistingSkus(ctx, skus)
+}
+
+func (cr *CachedProductRepository) Export(ctx context.Context, query models.ProductExportQuery) iter.Seq2[models.Product, error] {
+	return cr.repo.Export(ctx, query)
+}
+
+// Stats returns the hits and misses of GetByID so far.
+func (cr *CachedProductRepository) Stats() ProductCacheStats {
+	stats := ProductCacheStats{
+		Hits:          cr.hits.Load(),
+		Misses:        cr.misses.Load(),
+		Invalidations: cr.invalidations.Load(),
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+// cached returns the product stored under key, if there is a usable one.
+func (cr *CachedProductRepository) cached(ctx context.Context, key string) (models.Product, bool) {
+	value, found, err := cr.store.Get(ctx, key)
+	if err != nil {
+		log.Printf("⚠️  Reading product cache failed: %v", err)
+		return nil, false
+	}
+	if !found {
+		return nil, false
+	}
+	var entry cachedProduct
+	if err := json.Unmarshal(value, &entry); err != nil {
+		return nil, false
+	}
+	product, err := models.ReconstituteProduct(entry.ID, entry.Sku, entry.Name, entry.Category, entry.Price, models.ProductMetadata{
+		Version:   entry.Version,
+		UpdatedAt: entry.UpdatedAt,
+		DeletedAt: entry.DeletedAt,
+	})
+	if err != nil {
+		return nil, false
+	}
+	return product, true
+}
+
+// invalidate drops the cached product id once the transaction of ctx, if any,
+// commits. Dropping it earlier would let a concurrent read cache the version
+// the transaction is about to replace.
+func (cr *CachedProductRepository) invalidate(ctx context.Context, id uuid.UUID) {
+	key := productCacheKey(ctx, id)
+	shared_adapters.AfterCommit(ctx, func() {
+		cr.invalidations.Add(1)
+		cr.mu.Lock()
+		for _, load := range cr.loads[key] {
+			load.invalidated = true
+		}
+		cr.mu.Unlock()
+		if err := cr.store.Delete(context.WithoutCancel(ctx), key); err != nil {
+			log.Printf("⚠️  Invalidating cached product %s failed: %v", id, err)
+		}
+	})
+}
+
+// startLoad records that a miss is reading the product under key.
+func (cr *CachedProductRepository) startLoad(key string) *productLoad {
+	load := &productLoad{}
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.loads[key] = append(cr.loads[key], load)
+	return load
+}
+
+// wasInvalidated reports whether the product of load was dropped so far.
+func (cr *CachedProductRepository) wasInvalidated(load *productLoad) bool {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	return load.invalidated
+}
+
+// finishLoad forgets load and reports whether the product was dropped while
+// it ran.
+func (cr *CachedProductRepository) finishLoad(key string, load *productLoad) bool {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	loads := slices.DeleteFunc(cr.loads[key], func(other *productLoad) bool { return other == load })
+	if len(loads) == 0 {
+		delete(cr.loads, key)
+	} else {
+		cr.loads[key] = loads
+	}
+	return load.invalidated
+}
+
+// productCacheKey keeps the products of each tenant apart, as the repository
+// does.
+func productCacheKey(ctx context.Context, id uuid.UUID) string {
+	return "product:" + request_context.TenantFrom(ctx) + ":" + id.String()
+}
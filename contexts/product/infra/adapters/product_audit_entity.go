@@ -0,0 +1,68 @@
+package adapters
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/google/uuid"
+)
+
+// ProductAuditEntryEntity is append-only. History is read per product, newest
+// first, which the composite index serves directly.
+type ProductAuditEntryEntity struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TenantID   string    `gorm:"not null;default:default"`
+	ProductID  uuid.UUID `gorm:"type:uuid;not null;index:idx_product_audit_history,priority:1"`
+	Action     string    `gorm:"not null"`
+	Actor      string    `gorm:"not null"`
+	RequestID  string
+	OccurredAt time.Time `gorm:"not null;index:idx_product_audit_history,priority:2"`
+	Changes    []byte    `gorm:"type:jsonb;not null"`
+}
+
+type productFieldChangeRecord struct {
+	Field string  `json:"field"`
+	From  *string `json:"from"`
+	To    *string `json:"to"`
+}
+
+func NewProductAuditEntryEntity(entry models.ProductAuditEntry) (ProductAuditEntryEntity, error) {
+	records := make([]productFieldChangeRecord, 0, len(entry.Changes))
+	for _, change := range entry.Changes {
+		records = append(records, productFieldChangeRecord(change))
+	}
+	changes, err := json.Marshal(records)
+	if err != nil {
+		return ProductAuditEntryEntity{}, err
+	}
+	return ProductAuditEntryEntity{
+		ID:         entry.ID,
+		ProductID:  entry.ProductID,
+		Action:     string(entry.Action),
+		Actor:      entry.Actor,
+		RequestID:  entry.RequestID,
+		OccurredAt: entry.OccurredAt,
+		Changes:    changes,
+	}, nil
+}
+
+func (e *ProductAuditEntryEntity) ToDomainModel() (models.ProductAuditEntry, error) {
+	var records []productFieldChangeRecord
+	if err := json.Unmarshal(e.Changes, &records); err != nil {
+		return models.ProductAuditEntry{}, err
+	}
+	changes := make([]models.ProductFieldChange, 0, len(records))
+	for _, record := range records {
+		changes = append(changes, models.ProductFieldChange(record))
+	}
+	return models.ProductAuditEntry{
+		ID:         e.ID,
+		ProductID:  e.ProductID,
+		Action:     models.ProductAuditAction(e.Action),
+		Actor:      e.Actor,
+		RequestID:  e.RequestID,
+		OccurredAt: e.OccurredAt.UTC(),
+		Changes:    changes,
+	}, nil
+}
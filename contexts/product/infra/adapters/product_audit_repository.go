@@ -0,0 +1,69 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_adapters"
+	"gorm.io/gorm"
+)
+
+type ProductAuditRepository struct {
+	db *gorm.DB
+}
+
+func NewProductAuditRepository(db *gorm.DB) *ProductAuditRepository {
+	return &ProductAuditRepository{db: db}
+}
+
+func (ar *ProductAuditRepository) Record(ctx context.Context, entry models.ProductAuditEntry) error {
+	entity, err := NewProductAuditEntryEntity(entry)
+	if err != nil {
+		return err
+	}
+	entity.TenantID = request_context.TenantFrom(ctx)
+	return shared_adapters.DBFromContext(ctx, ar.db).Create(&entity).Error
+}
+
+// GetByProductID pages through the history of a product, newest first. Only
+// the history recorded in the tenant of ctx is read.
+func (ar *ProductAuditRepository) GetByProductID(ctx context.Context, query models.ProductAuditQuery) (models.ProductAuditPage, error) {
+	handledLimit := defaultLimit
+	if query.Limit != nil {
+		handledLimit = *query.Limit
+	}
+
+	db := shared_adapters.DBFromContext(ctx, ar.db).
+		Where("product_id = ? AND tenant_id = ?", query.ProductID, request_context.TenantFrom(ctx)).
+		Order("occurred_at DESC").
+		Order("id DESC").
+		Limit(handledLimit + oneMore)
+	if query.Cursor != nil {
+		db = db.Where("(occurred_at, id) < (?, ?)", query.Cursor.OccurredAt, query.Cursor.ID)
+	}
+
+	var entities []ProductAuditEntryEntity
+	if err := db.Find(&entities).Error; err != nil {
+		return models.ProductAuditPage{}, err
+	}
+
+	hasMore := len(entities) > handledLimit
+	if hasMore {
+		entities = entities[:handledLimit]
+	}
+
+	page := models.ProductAuditPage{Items: make([]models.ProductAuditEntry, 0, len(entities))}
+	for _, entity := range entities {
+		entry, err := entity.ToDomainModel()
+		if err != nil {
+			return models.ProductAuditPage{}, err
+		}
+		page.Items = append(page.Items, entry)
+	}
+	if hasMore {
+		last := page.Items[len(page.Items)-1]
+		page.Next = &models.ProductAuditCursor{OccurredAt: last.OccurredAt, ID: last.ID}
+	}
+	return page, nil
+}
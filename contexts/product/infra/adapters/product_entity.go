@@ -1,26 +1,46 @@
 package adapters
 
 import (
+	"time"
+
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
 )
 
+// ProductEntity belongs to the catalogue of one tenant and is soft-deleted
+// through DeletedAt, so SKUs only have to be unique among the products of a
+// tenant that are not in the trash. SearchVector is computed by Postgres for
+// full-text search and never read or written by GORM.
 type ProductEntity struct {
-	ID       uuid.UUID `gorm:"type:uuid;primaryKey"`
-	Sku      string    `gorm:"unique"`
-	Name     string
-	Category string
-	Price    decimal.Decimal `gorm:"type:decimal(10,2)"`
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TenantID  string    `gorm:"not null;default:default;uniqueIndex:idx_product_entities_tenant_sku_active,priority:1,where:deleted_at IS NULL"`
+	Sku       string    `gorm:"uniqueIndex:idx_product_entities_tenant_sku_active,priority:2"`
+	Name      string
+	Category  string
+	Price     decimal.Decimal `gorm:"type:decimal(10,2)"`
+	Version   int             `gorm:"not null;default:1"`
+	UpdatedAt time.Time       `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt  `gorm:"index"`
+	// SearchVector weighs name and SKU above category. The 'simple'
+	// configuration does no stemming, which keeps SKUs and brand names intact.
+	SearchVector string `gorm:"->:false;type:tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(name, '')), 'A') || setweight(to_tsvector('simple', coalesce(sku, '')), 'A') || setweight(to_tsvector('simple', coalesce(category, '')), 'B')) STORED;index:idx_product_entities_search,type:gin"`
 }
 
 func (p *ProductEntity) ToDomainModel() *models.Product {
-	product, err := models.NewProduct(
+	metadata := models.ProductMetadata{Version: p.Version, UpdatedAt: p.UpdatedAt}
+	if p.DeletedAt.Valid {
+		deletedAt := p.DeletedAt.Time
+		metadata.DeletedAt = &deletedAt
+	}
+	product, err := models.ReconstituteProduct(
 		p.ID,
 		p.Sku,
 		p.Name,
 		p.Category,
 		p.Price,
+		metadata,
 	)
 	if err != nil {
 		return nil
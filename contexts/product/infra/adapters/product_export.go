@@ -0,0 +1,56 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+)
+
+// Export walks every live product matching the query. Rows are scanned one at
+// a time as Postgres sends them, so the catalogue is never held in memory.
+// The query runs with ctx: cancelling it stops the iteration with ctx's error.
+func (pr *ProductRepository) Export(ctx context.Context, query models.ProductExportQuery) iter.Seq2[models.Product, error] {
+	return func(yield func(models.Product, error) bool) {
+		if query.Sort.Field == "" {
+			query.Sort = models.DefaultProductSort()
+		}
+		column, ok := productSortColumns[query.Sort.Field]
+		if !ok {
+			yield(nil, shared_handlers.ErrInvalidSort)
+			return
+		}
+		direction := "ASC"
+		if query.Sort.Direction == models.SortDescending {
+			direction = "DESC"
+		}
+		query.Filter.Trashed = false
+
+		db := applyProductFilter(pr.scoped(ctx).Model(&ProductEntity{}), query.Filter)
+		if column != "id" {
+			db = db.Order(fmt.Sprintf("%s %s", column, direction))
+		}
+		rows, err := db.Order(fmt.Sprintf("id %s", direction)).Rows()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var entity ProductEntity
+			if err := pr.db.ScanRows(rows, &entity); err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(*entity.ToDomainModel(), nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package adapters
+
+import "gorm.io/gorm"
+
+// legacyProductSkuIndex kept SKUs unique across every tenant, before
+// catalogues were split by tenant.
+const legacyProductSkuIndex = "idx_product_entities_sku_active"
+
+// MigrateProductEntities creates or updates the product tables. Products
+// stored before tenants existed land in the default tenant, and the index
+// that kept their SKUs unique across tenants is dropped. Products stored
+// before they carried an update time count as updated by the migration.
+func MigrateProductEntities(db *gorm.DB) error {
+	if err := db.AutoMigrate(&ProductEntity{}, &ProductAuditEntryEntity{}); err != nil {
+		return err
+	}
+	if db.Migrator().HasIndex(&ProductEntity{}, legacyProductSkuIndex) {
+		return db.Migrator().DropIndex(&ProductEntity{}, legacyProductSkuIndex)
+	}
+	return nil
+}
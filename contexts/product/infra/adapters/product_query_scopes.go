@@ -0,0 +1,57 @@
+package adapters
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+var productSortColumns = map[models.ProductSortField]string{
+	models.ProductSortByID:    "id",
+	models.ProductSortByName:  "name",
+	models.ProductSortByPrice: "price",
+	models.ProductSortBySku:   "sku",
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// tenantScope limits a query to the products of the tenant of ctx. The column
+// is qualified because search joins another relation.
+func tenantScope(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	tenant := request_context.TenantFrom(ctx)
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("product_entities.tenant_id = ?", tenant)
+	}
+}
+
+func applyProductFilter(db *gorm.DB, filter models.ProductFilter) *gorm.DB {
+	if filter.Trashed {
+		db = db.Unscoped().Where("deleted_at IS NOT NULL")
+	}
+	if filter.Category != nil {
+		db = db.Where("category = ?", *filter.Category)
+	}
+	if filter.Name != nil {
+		db = db.Where("name ILIKE ?", "%"+likeEscaper.Replace(*filter.Name)+"%")
+	}
+	if filter.MinPrice != nil {
+		db = db.Where("price >= ?", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		db = db.Where("price <= ?", *filter.MaxPrice)
+	}
+	return db
+}
+
+// productSortValue converts the raw cursor value back to the type of the sort
+// column so that Postgres compares it with the right semantics.
+func productSortValue(field models.ProductSortField, raw string) (interface{}, error) {
+	if field == models.ProductSortByPrice {
+		return decimal.NewFromString(raw)
+	}
+	return raw, nil
+}
@@ -2,16 +2,31 @@ package adapters
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_adapters"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
 	"github.com/google/uuid"
-	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
 const oneMore = 1
 const defaultLimit = 10
 
+// existingSkusChunkSize keeps the IN list of ExistingSkus well below the
+// Postgres limit on bind parameters.
+const existingSkusChunkSize = 1000
+
+// productSkuIndex is the unique index that keeps SKUs of live products unique
+// within a tenant.
+const productSkuIndex = "idx_product_entities_tenant_sku_active"
+
+// ProductRepository only ever sees the products of the tenant of the request
+// context: every query starts from scoped.
 type ProductRepository struct {
 	db *gorm.DB
 }
@@ -20,32 +35,70 @@ func NewProductRepository(db *gorm.DB) *ProductRepository {
 	return &ProductRepository{db: db}
 }
 
-func (pr *ProductRepository) GetAll(ctx context.Context, cursor *string, limit *int) ([]models.Product, *string, error) {
+// scoped returns the database handle for ctx, limited to the products of its
+// tenant.
+func (pr *ProductRepository) scoped(ctx context.Context) *gorm.DB {
+	return shared_adapters.DBFromContext(ctx, pr.db).Scopes(tenantScope(ctx))
+}
+
+func (pr *ProductRepository) GetAll(ctx context.Context, query models.ProductQuery) (models.ProductPage, error) {
 	var products []ProductEntity
 	handledLimit := defaultLimit
-	if limit != nil {
-		handledLimit = *limit
-	}
-	query := pr.db.WithContext(ctx).Order("id ASC").Limit(handledLimit + oneMore)
-	if cursor != nil {
-		parsedCursor, err := uuid.Parse(*cursor)
-		if err != nil {
-			return nil, nil, err
+	if query.Limit != nil {
+		handledLimit = *query.Limit
+	}
+	if query.Sort.Field == "" {
+		query.Sort = models.DefaultProductSort()
+	}
+	column, ok := productSortColumns[query.Sort.Field]
+	if !ok {
+		return models.ProductPage{}, shared_handlers.ErrInvalidSort
+	}
+
+	// Reading backwards walks the same ordering in reverse and flips the page
+	// back once loaded.
+	backward := query.Cursor != nil && query.Cursor.Backward
+	descending := query.Sort.Direction == models.SortDescending
+	if backward {
+		descending = !descending
+	}
+	direction, comparator := "ASC", ">"
+	if descending {
+		direction, comparator = "DESC", "<"
+	}
+
+	db := applyProductFilter(pr.scoped(ctx), query.Filter)
+	if column != "id" {
+		db = db.Order(fmt.Sprintf("%s %s", column, direction))
+	}
+	db = db.Order(fmt.Sprintf("id %s", direction)).Limit(handledLimit + oneMore)
+
+	if query.Cursor != nil {
+		if query.Cursor.Sort != query.Sort {
+			return models.ProductPage{}, shared_handlers.ErrInvalidCursor
+		}
+		if column == "id" {
+			db = db.Where(fmt.Sprintf("id %s ?", comparator), query.Cursor.ID)
+		} else {
+			value, err := productSortValue(query.Sort.Field, query.Cursor.Value)
+			if err != nil {
+				return models.ProductPage{}, shared_handlers.ErrInvalidCursor
+			}
+			db = db.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, comparator), value, query.Cursor.ID)
 		}
-		query = query.Where("id > ?", parsedCursor)
 	}
 
-	if err := query.Find(&products).Error; err != nil {
-		return nil, nil, err
+	if err := db.Find(&products).Error; err != nil {
+		return models.ProductPage{}, err
 	}
 
-	var nextCursor *string
-	if len(products) > handledLimit {
-		lastItemInPage := products[handledLimit-1]
-		cursorStr := lastItemInPage.ID.String()
-		nextCursor = &cursorStr
+	hasMore := len(products) > handledLimit
+	if hasMore {
 		products = products[:handledLimit]
 	}
+	if backward {
+		slices.Reverse(products)
+	}
 
 	var productModels []models.Product
 	for _, entity := range products {
@@ -53,11 +106,25 @@ func (pr *ProductRepository) GetAll(ctx context.Context, cursor *string, limit *
 		productModels = append(productModels, *model)
 	}
 
-	return productModels, nextCursor, nil
+	page := models.ProductPage{Items: productModels}
+	if len(productModels) == 0 {
+		return page, nil
+	}
+	first, last := productModels[0], productModels[len(productModels)-1]
+	if hasMore || backward {
+		next := models.NewProductCursor(last, query.Sort, false)
+		page.Next = &next
+	}
+	if (hasMore && backward) || (query.Cursor != nil && !backward) {
+		prev := models.NewProductCursor(first, query.Sort, true)
+		page.Prev = &prev
+	}
+
+	return page, nil
 }
 func (pr *ProductRepository) GetByID(ctx context.Context, id uuid.UUID) (models.Product, error) {
 	var entity ProductEntity
-	if err := pr.db.WithContext(ctx).First(&entity, id).Error; err != nil {
+	if err := pr.scoped(ctx).First(&entity, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -67,48 +134,157 @@ func (pr *ProductRepository) GetByID(ctx context.Context, id uuid.UUID) (models.
 	productModel := entity.ToDomainModel()
 	return *productModel, nil
 }
+
+func (pr *ProductRepository) GetTrashedByID(ctx context.Context, id uuid.UUID) (models.Product, error) {
+	var entity ProductEntity
+	if err := pr.scoped(ctx).Unscoped().Where("deleted_at IS NOT NULL").First(&entity, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	productModel := entity.ToDomainModel()
+	return *productModel, nil
+}
+
 func (pr *ProductRepository) Create(ctx context.Context, product models.Product) error {
 	productEntity := ProductEntity{
-		ID:       product.ID(),
-		Sku:      product.Sku(),
-		Name:     product.Name(),
-		Category: product.Category(),
-		Price:    product.Price(),
+		ID:        product.ID(),
+		TenantID:  request_context.TenantFrom(ctx),
+		Sku:       product.Sku(),
+		Name:      product.Name(),
+		Category:  product.Category(),
+		Price:     product.Price(),
+		Version:   product.Version(),
+		UpdatedAt: product.UpdatedAt(),
 	}
-	return pr.db.WithContext(ctx).Create(&productEntity).Error
+	err := shared_adapters.DBFromContext(ctx, pr.db).Create(&productEntity).Error
+	return translateProductError(err, productEntity.Sku)
 }
-func (pr *ProductRepository) Update(ctx context.Context, id uuid.UUID, product models.Product) error {
-	var storedProduct ProductEntity
-	if err := pr.db.WithContext(ctx).First(&storedProduct, id).Error; err != nil {
-		return err
-	}
-	storedProduct.Name = product.Name()
-	storedProduct.Price = product.Price()
-	storedProduct.Sku = product.Sku()
-	storedProduct.Category = product.Category()
-	return pr.db.WithContext(ctx).Save(storedProduct).Error
+// Update writes product over the stored row and bumps its version, provided
+// the row still has the version product was loaded with. A concurrent writer
+// that got there first makes it fail with ErrPreconditionFailed instead of
+// being overwritten.
+func (pr *ProductRepository) Update(ctx context.Context, id uuid.UUID, product models.Product, expectedVersion *int) error {
+	if expectedVersion != nil && product.Version() != *expectedVersion {
+		return shared_handlers.ErrPreconditionFailed
+	}
+
+	result := pr.scoped(ctx).Model(&ProductEntity{}).
+		Where("id = ? AND version = ?", id, product.Version()).
+		Updates(map[string]interface{}{
+			"sku":        product.Sku(),
+			"name":       product.Name(),
+			"category":   product.Category(),
+			"price":      product.Price(),
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": product.UpdatedAt(),
+		})
+	if result.Error != nil {
+		return translateProductError(result.Error, product.Sku())
+	}
+	if result.RowsAffected == 0 {
+		return pr.staleOrMissing(ctx, id)
+	}
+	return nil
 }
-func (pr *ProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return pr.db.WithContext(ctx).Delete(&ProductEntity{}, id).Error
+
+func (pr *ProductRepository) Delete(ctx context.Context, id uuid.UUID, expectedVersion *int) error {
+	if expectedVersion == nil {
+		result := pr.scoped(ctx).Delete(&ProductEntity{}, id)
+		if result.Error != nil {
+			return shared_adapters.TranslateError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return shared_handlers.ErrNotFound
+		}
+		return nil
+	}
+
+	result := pr.scoped(ctx).Where("version = ?", *expectedVersion).Delete(&ProductEntity{}, id)
+	if result.Error != nil {
+		return shared_adapters.TranslateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return pr.staleOrMissing(ctx, id)
+	}
+	return nil
 }
-func (pr *ProductRepository) Patch(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
-	var storedProduct ProductEntity
-	if err := pr.db.WithContext(ctx).First(&storedProduct, id).Error; err != nil {
-		return err
+// Restore brings a soft-deleted product back, as of its UpdatedAt, and bumps
+// its version, since any ETag handed out for the trashed product no longer
+// describes a live one.
+func (pr *ProductRepository) Restore(ctx context.Context, product models.Product) error {
+	result := pr.scoped(ctx).Unscoped().Model(&ProductEntity{}).
+		Where("id = ? AND deleted_at IS NOT NULL", product.ID()).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": product.UpdatedAt(),
+		})
+	if shared_adapters.IsUniqueViolation(result.Error, productSkuIndex) {
+		// A live product took the SKU while this one was in the trash.
+		return shared_handlers.ErrDuplicateSku
+	}
+	if result.Error != nil {
+		return shared_adapters.TranslateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return shared_handlers.ErrNotFound
 	}
+	return nil
+}
 
-	if updates["sku"] != nil {
-		storedProduct.Sku = updates["sku"].(string)
+// Purge permanently removes a product. Only products already in the trash
+// can be purged.
+func (pr *ProductRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	result := pr.scoped(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Delete(&ProductEntity{}, id)
+	if result.Error != nil {
+		return shared_adapters.TranslateError(result.Error)
 	}
-	if updates["name"] != nil {
-		storedProduct.Name = updates["name"].(string)
+	if result.RowsAffected == 0 {
+		return shared_handlers.ErrNotFound
 	}
-	if updates["category"] != nil {
-		storedProduct.Category = updates["category"].(string)
+	return nil
+}
+
+func (pr *ProductRepository) ExistingSkus(ctx context.Context, skus []string) ([]string, error) {
+	existing := []string{}
+	for chunk := range slices.Chunk(skus, existingSkusChunkSize) {
+		var found []string
+		if err := pr.scoped(ctx).Model(&ProductEntity{}).Where("sku IN ?", chunk).Pluck("sku", &found).Error; err != nil {
+			return nil, err
+		}
+		existing = append(existing, found...)
+	}
+	return existing, nil
+}
+
+// staleOrMissing tells why a write guarded by a version matched no rows: the
+// product changed since it was read, or it does not exist.
+func (pr *ProductRepository) staleOrMissing(ctx context.Context, id uuid.UUID) error {
+	var count int64
+	if err := pr.scoped(ctx).Model(&ProductEntity{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return shared_adapters.TranslateError(err)
 	}
-	if updates["price"] != nil {
-		storedProduct.Price = updates["price"].(decimal.Decimal)
+	if count > 0 {
+		return shared_handlers.ErrPreconditionFailed
 	}
+	return shared_handlers.ErrNotFound
+}
 
-	return pr.db.WithContext(ctx).Save(&storedProduct).Error
+// translateProductError reports a taken SKU as ErrDuplicateSku, naming the
+// field, and translates any other database error the shared way.
+func translateProductError(err error, sku string) error {
+	if shared_adapters.IsUniqueViolation(err, productSkuIndex) {
+		return shared_handlers.ErrDuplicateSku.WithFieldViolations(shared_models.FieldViolation{
+			Field:   "sku",
+			Rule:    "unique",
+			Value:   sku,
+			Message: "sku already exists",
+		})
+	}
+	return shared_adapters.TranslateError(err)
 }
@@ -0,0 +1,116 @@
+package adapters
+
+import (
+	"context"
+	"html"
+	"slices"
+	"strings"
+
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+)
+
+const (
+	productSearchQuery = "CROSS JOIN websearch_to_tsquery('simple', ?) AS search_query"
+	productSearchRank  = "ts_rank(product_entities.search_vector, search_query)"
+	// ts_headline leaves the text around matches as it is stored, so it marks
+	// them with characters of the private use area. markHighlight turns those
+	// into <mark> tags once the rest is escaped.
+	highlightStart   = "\uE000"
+	highlightStop    = "\uE001"
+	highlightOptions = "'StartSel=\"" + highlightStart + "\", StopSel=\"" + highlightStop + "\", HighlightAll=true'"
+)
+
+var highlightMarks = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// markHighlight escapes a headline for HTML and wraps its matches in <mark>
+// tags, the only markup it may then contain.
+func markHighlight(headline string) string {
+	return highlightMarks.Replace(html.EscapeString(headline))
+}
+
+var productSearchColumns = "product_entities.*, " +
+	productSearchRank + " AS search_rank, " +
+	"ts_headline('simple', product_entities.name, search_query, " + highlightOptions + ") AS name_highlight, " +
+	"ts_headline('simple', product_entities.sku, search_query, " + highlightOptions + ") AS sku_highlight, " +
+	"ts_headline('simple', product_entities.category, search_query, " + highlightOptions + ") AS category_highlight"
+
+type productSearchRow struct {
+	ProductEntity     `gorm:"embedded"`
+	SearchRank        float32
+	NameHighlight     string
+	SkuHighlight      string
+	CategoryHighlight string
+}
+
+// Search pages through the live products matching query.Text, most relevant
+// first. Like GetAll, backward pages walk the ordering in reverse and are
+// flipped once loaded.
+func (pr *ProductRepository) Search(ctx context.Context, query models.ProductSearchQuery) (models.ProductSearchPage, error) {
+	handledLimit := defaultLimit
+	if query.Limit != nil {
+		handledLimit = *query.Limit
+	}
+	query.Filter.Trashed = false
+
+	backward := query.Cursor != nil && query.Cursor.Backward
+	rankDirection, idDirection := "DESC", "ASC"
+	if backward {
+		rankDirection, idDirection = "ASC", "DESC"
+	}
+
+	db := applyProductFilter(pr.scoped(ctx).Model(&ProductEntity{}), query.Filter).
+		Select(productSearchColumns).
+		Joins(productSearchQuery, query.Text).
+		Where("product_entities.search_vector @@ search_query").
+		Order("search_rank " + rankDirection).
+		Order("product_entities.id " + idDirection).
+		Limit(handledLimit + oneMore)
+	if query.Cursor != nil {
+		if backward {
+			db = db.Where("("+productSearchRank+" > ? OR ("+productSearchRank+" = ? AND product_entities.id < ?))",
+				query.Cursor.Rank, query.Cursor.Rank, query.Cursor.ID)
+		} else {
+			db = db.Where("("+productSearchRank+" < ? OR ("+productSearchRank+" = ? AND product_entities.id > ?))",
+				query.Cursor.Rank, query.Cursor.Rank, query.Cursor.ID)
+		}
+	}
+
+	var rows []productSearchRow
+	if err := db.Scan(&rows).Error; err != nil {
+		return models.ProductSearchPage{}, err
+	}
+
+	hasMore := len(rows) > handledLimit
+	if hasMore {
+		rows = rows[:handledLimit]
+	}
+	if backward {
+		slices.Reverse(rows)
+	}
+
+	page := models.ProductSearchPage{Items: make([]models.ProductSearchHit, 0, len(rows))}
+	for _, row := range rows {
+		page.Items = append(page.Items, models.ProductSearchHit{
+			Product: *row.ProductEntity.ToDomainModel(),
+			Rank:    row.SearchRank,
+			Highlights: models.ProductSearchHighlights{
+				Name:     markHighlight(row.NameHighlight),
+				Sku:      markHighlight(row.SkuHighlight),
+				Category: markHighlight(row.CategoryHighlight),
+			},
+		})
+	}
+	if len(page.Items) == 0 {
+		return page, nil
+	}
+	first, last := page.Items[0], page.Items[len(page.Items)-1]
+	if hasMore || backward {
+		next := models.NewProductSearchCursor(last, false)
+		page.Next = &next
+	}
+	if (hasMore && backward) || (query.Cursor != nil && !backward) {
+		prev := models.NewProductSearchCursor(first, true)
+		page.Prev = &prev
+	}
+	return page, nil
+}
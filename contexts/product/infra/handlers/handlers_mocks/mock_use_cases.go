@@ -2,6 +2,7 @@ package handlers_mocks
 
 import (
 	"context"
+	"iter"
 
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
 	"github.com/google/uuid"
@@ -21,8 +22,8 @@ type MockUpdateProductUseCase struct {
 	mock.Mock
 }
 
-func (m *MockUpdateProductUseCase) Execute(ctx context.Context, id uuid.UUID, product models.Product) error {
-	args := m.Called(ctx, id, product)
+func (m *MockUpdateProductUseCase) Execute(ctx context.Context, id uuid.UUID, product models.Product, expectedVersion *int) error {
+	args := m.Called(ctx, id, product, expectedVersion)
 	return args.Error(0)
 }
 
@@ -30,8 +31,8 @@ type MockPatchProductUseCase struct {
 	mock.Mock
 }
 
-func (m *MockPatchProductUseCase) Execute(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
-	args := m.Called(ctx, id, updates)
+func (m *MockPatchProductUseCase) Execute(ctx context.Context, id uuid.UUID, patch models.ProductPatch, expectedVersion *int) error {
+	args := m.Called(ctx, id, patch, expectedVersion)
 	return args.Error(0)
 }
 
@@ -39,8 +40,8 @@ type MockDeleteProductUseCase struct {
 	mock.Mock
 }
 
-func (m *MockDeleteProductUseCase) Execute(ctx context.Context, id uuid.UUID) error {
-	args := m.Called(ctx, id)
+func (m *MockDeleteProductUseCase) Execute(ctx context.Context, id uuid.UUID, expectedVersion *int) error {
+	args := m.Called(ctx, id, expectedVersion)
 	return args.Error(0)
 }
 
@@ -48,9 +49,9 @@ type MockGetAllProductsUseCase struct {
 	mock.Mock
 }
 
-func (m *MockGetAllProductsUseCase) Execute(ctx context.Context, cursor *string, limit *int) ([]models.Product, *string, error) {
-	args := m.Called(ctx, cursor, limit)
-	return args.Get(0).([]models.Product), args.Get(1).(*string), args.Error(2)
+func (m *MockGetAllProductsUseCase) Execute(ctx context.Context, query models.ProductQuery) (models.ProductPage, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(models.ProductPage), args.Error(1)
 }
 
 type MockGetOneProductUseCase struct {
@@ -64,3 +65,93 @@ func (m *MockGetOneProductUseCase) Execute(ctx context.Context, id uuid.UUID) (m
 	}
 	return args.Get(0).(models.Product), args.Error(1)
 }
+
+type MockRestoreProductUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRestoreProductUseCase) Execute(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockPurgeProductUseCase struct {
+	mock.Mock
+}
+
+func (m *MockPurgeProductUseCase) Execute(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockGetProductHistoryUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetProductHistoryUseCase) Execute(ctx context.Context, query models.ProductAuditQuery) (models.ProductAuditPage, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(models.ProductAuditPage), args.Error(1)
+}
+
+type MockSearchProductsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSearchProductsUseCase) Execute(ctx context.Context, query models.ProductSearchQuery) (models.ProductSearchPage, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(models.ProductSearchPage), args.Error(1)
+}
+
+type MockBatchCreateProductsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockBatchCreateProductsUseCase) Execute(ctx context.Context, mode models.ProductBatchMode, items []models.ProductBatchCreateItem) ([]models.ProductBatchResult, error) {
+	args := m.Called(ctx, mode, items)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ProductBatchResult), args.Error(1)
+}
+
+type MockBatchUpdateProductsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockBatchUpdateProductsUseCase) Execute(ctx context.Context, mode models.ProductBatchMode, items []models.ProductBatchUpdateItem) ([]models.ProductBatchResult, error) {
+	args := m.Called(ctx, mode, items)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ProductBatchResult), args.Error(1)
+}
+
+type MockBatchDeleteProductsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockBatchDeleteProductsUseCase) Execute(ctx context.Context, mode models.ProductBatchMode, items []models.ProductBatchDeleteItem) ([]models.ProductBatchResult, error) {
+	args := m.Called(ctx, mode, items)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ProductBatchResult), args.Error(1)
+}
+
+type MockImportProductsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockImportProductsUseCase) Execute(ctx context.Context, rows []models.ProductImportRow, dryRun bool) (models.ProductImportReport, error) {
+	args := m.Called(ctx, rows, dryRun)
+	return args.Get(0).(models.ProductImportReport), args.Error(1)
+}
+
+type MockExportProductsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockExportProductsUseCase) Execute(ctx context.Context, query models.ProductExportQuery) iter.Seq2[models.Product, error] {
+	args := m.Called(ctx, query)
+	return args.Get(0).(iter.Seq2[models.Product, error])
+}
@@ -2,19 +2,28 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"iter"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Akiles94/go-test-api/contexts/product/application/dto"
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
 	"github.com/Akiles94/go-test-api/contexts/product/domain/models/models_mothers"
 	"github.com/Akiles94/go-test-api/contexts/product/infra/handlers"
 	"github.com/Akiles94/go-test-api/contexts/product/infra/handlers/handlers_mocks"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/pagination"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
 	"github.com/Akiles94/go-test-api/contexts/shared/application/shared_dto"
 	"github.com/Akiles94/go-test-api/contexts/shared/infra/middlewares"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -26,15 +35,30 @@ type ProductHandlerTestSuite struct {
 	suite.Suite
 	handler *handlers.ProductHandler
 	router  *gin.Engine
+	codec   *pagination.CursorCodec
 	// Mocks - solo para poder hacer assertions y configurar expectations
-	mockCreateUseCase *handlers_mocks.MockCreateProductUseCase
-	mockUpdateUseCase *handlers_mocks.MockUpdateProductUseCase
-	mockPatchUseCase  *handlers_mocks.MockPatchProductUseCase
-	mockDeleteUseCase *handlers_mocks.MockDeleteProductUseCase
-	mockGetAllUseCase *handlers_mocks.MockGetAllProductsUseCase
-	mockGetOneUseCase *handlers_mocks.MockGetOneProductUseCase
+	mockCreateUseCase  *handlers_mocks.MockCreateProductUseCase
+	mockUpdateUseCase  *handlers_mocks.MockUpdateProductUseCase
+	mockPatchUseCase   *handlers_mocks.MockPatchProductUseCase
+	mockDeleteUseCase  *handlers_mocks.MockDeleteProductUseCase
+	mockGetAllUseCase  *handlers_mocks.MockGetAllProductsUseCase
+	mockGetOneUseCase  *handlers_mocks.MockGetOneProductUseCase
+	mockRestoreUseCase *handlers_mocks.MockRestoreProductUseCase
+	mockPurgeUseCase   *handlers_mocks.MockPurgeProductUseCase
+	mockHistoryUseCase *handlers_mocks.MockGetProductHistoryUseCase
+	mockSearchUseCase  *handlers_mocks.MockSearchProductsUseCase
+	mockBatchCreate    *handlers_mocks.MockBatchCreateProductsUseCase
+	mockBatchUpdate    *handlers_mocks.MockBatchUpdateProductsUseCase
+	mockBatchDelete    *handlers_mocks.MockBatchDeleteProductsUseCase
+	mockImportUseCase  *handlers_mocks.MockImportProductsUseCase
+	mockExportUseCase  *handlers_mocks.MockExportProductsUseCase
 }
 
+const testBatchMaxItems = 3
+const testImportMaxRows = 3
+const testItemCacheControl = "private, max-age=60"
+const testListCacheControl = "private, no-cache"
+
 func (suite *ProductHandlerTestSuite) SetupSuite() {
 	gin.SetMode(gin.TestMode)
 }
@@ -47,6 +71,17 @@ func (suite *ProductHandlerTestSuite) SetupTest() {
 	suite.mockDeleteUseCase = new(handlers_mocks.MockDeleteProductUseCase)
 	suite.mockGetAllUseCase = new(handlers_mocks.MockGetAllProductsUseCase)
 	suite.mockGetOneUseCase = new(handlers_mocks.MockGetOneProductUseCase)
+	suite.mockRestoreUseCase = new(handlers_mocks.MockRestoreProductUseCase)
+	suite.mockPurgeUseCase = new(handlers_mocks.MockPurgeProductUseCase)
+	suite.mockHistoryUseCase = new(handlers_mocks.MockGetProductHistoryUseCase)
+	suite.mockSearchUseCase = new(handlers_mocks.MockSearchProductsUseCase)
+	suite.mockBatchCreate = new(handlers_mocks.MockBatchCreateProductsUseCase)
+	suite.mockBatchUpdate = new(handlers_mocks.MockBatchUpdateProductsUseCase)
+	suite.mockBatchDelete = new(handlers_mocks.MockBatchDeleteProductsUseCase)
+	suite.mockImportUseCase = new(handlers_mocks.MockImportProductsUseCase)
+	suite.mockExportUseCase = new(handlers_mocks.MockExportProductsUseCase)
+
+	suite.codec = pagination.NewCursorCodec([]byte("test-secret"))
 
 	// Create handler with injected mocks
 	suite.handler = handlers.NewProductHandler(
@@ -56,6 +91,19 @@ func (suite *ProductHandlerTestSuite) SetupTest() {
 		suite.mockDeleteUseCase,
 		suite.mockGetAllUseCase,
 		suite.mockGetOneUseCase,
+		suite.mockRestoreUseCase,
+		suite.mockPurgeUseCase,
+		suite.mockHistoryUseCase,
+		suite.mockSearchUseCase,
+		suite.mockBatchCreate,
+		suite.mockBatchUpdate,
+		suite.mockBatchDelete,
+		suite.mockImportUseCase,
+		suite.mockExportUseCase,
+		testBatchMaxItems,
+		testImportMaxRows,
+		suite.codec,
+		handlers.ProductCacheControl{Item: testItemCacheControl, List: testListCacheControl},
 	)
 
 	// Setup router
@@ -63,7 +111,17 @@ func (suite *ProductHandlerTestSuite) SetupTest() {
 	suite.router.Use(middlewares.ErrorHandlerMiddleware())
 
 	suite.router.GET("/products", suite.handler.GetPaginated)
+	suite.router.GET("/products/search", suite.handler.Search)
+	suite.router.GET("/products/trash", suite.handler.GetTrashPaginated)
+	suite.router.POST("/products/import", suite.handler.Import)
+	suite.router.GET("/products/export", suite.handler.Export)
+	suite.router.POST("/products/batch/create", suite.handler.BatchCreate)
+	suite.router.POST("/products/batch/update", suite.handler.BatchUpdate)
+	suite.router.POST("/products/batch/delete", suite.handler.BatchDelete)
+	suite.router.POST("/products/trash/:id/restore", suite.handler.Restore)
+	suite.router.DELETE("/products/trash/:id", suite.handler.Purge)
 	suite.router.GET("/products/:id", suite.handler.GetByID)
+	suite.router.GET("/products/:id/history", suite.handler.GetHistory)
 	suite.router.POST("/products", suite.handler.Create)
 	suite.router.PUT("/products/:id", suite.handler.Update)
 	suite.router.PATCH("/products/:id", suite.handler.Patch)
@@ -78,6 +136,21 @@ func (suite *ProductHandlerTestSuite) TearDownTest() {
 	suite.mockDeleteUseCase.ExpectedCalls = nil
 	suite.mockGetAllUseCase.ExpectedCalls = nil
 	suite.mockGetOneUseCase.ExpectedCalls = nil
+	suite.mockRestoreUseCase.ExpectedCalls = nil
+	suite.mockPurgeUseCase.ExpectedCalls = nil
+	suite.mockHistoryUseCase.ExpectedCalls = nil
+	suite.mockSearchUseCase.ExpectedCalls = nil
+	suite.mockBatchCreate.ExpectedCalls = nil
+	suite.mockBatchUpdate.ExpectedCalls = nil
+	suite.mockBatchDelete.ExpectedCalls = nil
+	suite.mockImportUseCase.ExpectedCalls = nil
+	suite.mockExportUseCase.ExpectedCalls = nil
+}
+
+func (suite *ProductHandlerTestSuite) encodeCursor(filter models.ProductFilter, position models.ProductCursor) string {
+	token, err := suite.codec.Encode(dto.NewProductPaginationCursor(filter, position))
+	suite.Require().NoError(err)
+	return token
 }
 
 func (suite *ProductHandlerTestSuite) TestGetPaginated() {
@@ -87,10 +160,10 @@ func (suite *ProductHandlerTestSuite) TestGetPaginated() {
 			models_mothers.NewProductMother().WithName("Product 1").MustBuild(),
 			models_mothers.NewProductMother().WithName("Product 2").MustBuild(),
 		}
-		nextCursor := "next-cursor"
+		nextCursor := models.NewProductCursor(products[1], models.DefaultProductSort(), false)
 
-		suite.mockGetAllUseCase.On("Execute", mock.Anything, (*string)(nil), (*int)(nil)).
-			Return(products, &nextCursor, nil)
+		suite.mockGetAllUseCase.On("Execute", mock.Anything, models.ProductQuery{Sort: models.DefaultProductSort()}).
+			Return(models.ProductPage{Items: products, Next: &nextCursor}, nil)
 
 		// Act
 		req := httptest.NewRequest(http.MethodGet, "/products", nil)
@@ -104,7 +177,12 @@ func (suite *ProductHandlerTestSuite) TestGetPaginated() {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		suite.NoError(err)
 		suite.Len(response.Items, 2)
-		suite.Equal(&nextCursor, response.NextCursor)
+		suite.Require().NotNil(response.NextCursor)
+		suite.Nil(response.PrevCursor)
+
+		decoded, err := suite.codec.Decode(*response.NextCursor)
+		suite.NoError(err)
+		suite.Equal(pagination.DirectionNext, decoded.Direction)
 
 		suite.mockGetAllUseCase.AssertExpectations(suite.T())
 	})
@@ -116,8 +194,8 @@ func (suite *ProductHandlerTestSuite) TestGetPaginated() {
 		}
 		limit := 1
 
-		suite.mockGetAllUseCase.On("Execute", mock.Anything, (*string)(nil), &limit).
-			Return(products, (*string)(nil), nil)
+		suite.mockGetAllUseCase.On("Execute", mock.Anything, models.ProductQuery{Sort: models.DefaultProductSort(), Limit: &limit}).
+			Return(models.ProductPage{Items: products}, nil)
 
 		// Act
 		req := httptest.NewRequest(http.MethodGet, "/products?limit=1", nil)
@@ -129,6 +207,93 @@ func (suite *ProductHandlerTestSuite) TestGetPaginated() {
 		suite.mockGetAllUseCase.AssertExpectations(suite.T())
 	})
 
+	suite.Run("should pass filters and sort to use case", func() {
+		// Arrange
+		category := "Electronics"
+		minPrice := decimal.NewFromInt(10)
+		maxPrice := decimal.NewFromInt(50)
+		expectedQuery := models.ProductQuery{
+			Filter: models.ProductFilter{
+				Category: &category,
+				MinPrice: &minPrice,
+				MaxPrice: &maxPrice,
+			},
+			Sort: models.ProductSort{Field: models.ProductSortByPrice, Direction: models.SortDescending},
+		}
+
+		suite.mockGetAllUseCase.On("Execute", mock.Anything, expectedQuery).
+			Return(models.ProductPage{Items: []models.Product{}}, nil)
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products?category=Electronics&min_price=10&max_price=50&sort=price:desc", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		suite.mockGetAllUseCase.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should restore filters and sort from cursor", func() {
+		// Arrange
+		category := "Electronics"
+		filter := models.ProductFilter{Category: &category}
+		sort := models.ProductSort{Field: models.ProductSortByName, Direction: models.SortAscending}
+		cursor := models.ProductCursor{Sort: sort, Value: "Product 1", ID: uuid.New(), Backward: true}
+		previous := models.ProductCursor{Sort: sort, Value: "Product 0", ID: uuid.New(), Backward: true}
+
+		suite.mockGetAllUseCase.On("Execute", mock.Anything, models.ProductQuery{Filter: filter, Sort: sort, Cursor: &cursor}).
+			Return(models.ProductPage{Items: []models.Product{}, Prev: &previous}, nil)
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products?cursor="+suite.encodeCursor(filter, cursor), nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+
+		var response shared_dto.PaginatedResult[dto.ProductResponse]
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Nil(response.NextCursor)
+		suite.Require().NotNil(response.PrevCursor)
+		decoded, err := suite.codec.Decode(*response.PrevCursor)
+		suite.NoError(err)
+		suite.Equal(pagination.DirectionPrev, decoded.Direction)
+		suite.Equal(category, decoded.Filters["category"])
+
+		suite.mockGetAllUseCase.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should return error for cursor issued for another sort", func() {
+		// Arrange
+		cursor := models.ProductCursor{Sort: models.DefaultProductSort(), ID: uuid.New()}
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products?sort=price&cursor="+suite.encodeCursor(models.ProductFilter{}, cursor), nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	suite.Run("should return error for tampered cursor", func() {
+		// Arrange
+		cursor := models.ProductCursor{Sort: models.DefaultProductSort(), ID: uuid.New()}
+		forged, err := pagination.NewCursorCodec([]byte("another-secret")).
+			Encode(dto.NewProductPaginationCursor(models.ProductFilter{}, cursor))
+		suite.Require().NoError(err)
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products?cursor="+forged, nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+
 	suite.Run("should return error for invalid cursor", func() {
 		// Act
 		req := httptest.NewRequest(http.MethodGet, "/products?cursor=invalid-uuid", nil)
@@ -148,6 +313,26 @@ func (suite *ProductHandlerTestSuite) TestGetPaginated() {
 		// Assert
 		suite.Equal(http.StatusBadRequest, w.Code)
 	})
+
+	suite.Run("should return error for unknown sort field", func() {
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products?sort=category", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	suite.Run("should return error for inverted price range", func() {
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products?min_price=50&max_price=10", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
 }
 
 func (suite *ProductHandlerTestSuite) TestGetByID() {
@@ -175,6 +360,7 @@ func (suite *ProductHandlerTestSuite) TestGetByID() {
 		suite.NoError(err)
 		suite.Equal(productID, response.ID)
 		suite.Equal("Test Product", response.Name)
+		suite.Equal(`"1"`, w.Header().Get("ETag"))
 
 		suite.mockGetOneUseCase.AssertExpectations(suite.T())
 	})
@@ -207,6 +393,92 @@ func (suite *ProductHandlerTestSuite) TestGetByID() {
 	})
 }
 
+func (suite *ProductHandlerTestSuite) TestConditionalGetByID() {
+	productID := uuid.New()
+	updatedAt := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+	product := models_mothers.NewProductMother().WithID(productID).WithVersion(4).WithUpdatedAt(updatedAt).MustBuild()
+
+	cases := []struct {
+		name   string
+		header map[string]string
+		status int
+	}{
+		{"return the product with its validators", nil, http.StatusOK},
+		{"return 304 when the ETag is current", map[string]string{"If-None-Match": `"4"`}, http.StatusNotModified},
+		{"return the product when the ETag is stale", map[string]string{"If-None-Match": `"3"`}, http.StatusOK},
+		{"return 304 when not modified since", map[string]string{"If-Modified-Since": updatedAt.Format(http.TimeFormat)}, http.StatusNotModified},
+		{"return the product when modified since", map[string]string{"If-Modified-Since": updatedAt.Add(-time.Minute).Format(http.TimeFormat)}, http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		suite.Run("should "+tc.name, func() {
+			// Arrange
+			suite.mockGetOneUseCase.On("Execute", mock.Anything, productID).Return(product, nil).Once()
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/products/%s", productID), nil)
+			for name, value := range tc.header {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			suite.router.ServeHTTP(w, req)
+
+			// Assert
+			suite.Equal(tc.status, w.Code)
+			suite.Equal(`"4"`, w.Header().Get("ETag"))
+			suite.Equal("Fri, 01 Mar 2024 10:30:00 GMT", w.Header().Get("Last-Modified"))
+			suite.Equal(testItemCacheControl, w.Header().Get("Cache-Control"))
+			if tc.status == http.StatusNotModified {
+				suite.Empty(w.Body.String())
+			}
+		})
+	}
+}
+
+func (suite *ProductHandlerTestSuite) TestConditionalGetPaginated() {
+	suite.Run("should return 304 while the page does not change", func() {
+		// Arrange
+		products := []models.Product{models_mothers.NewProductMother().MustBuild()}
+		suite.mockGetAllUseCase.On("Execute", mock.Anything, models.ProductQuery{Sort: models.DefaultProductSort()}).
+			Return(models.ProductPage{Items: products}, nil)
+		first := httptest.NewRecorder()
+		suite.router.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/products", nil))
+		suite.Require().Equal(http.StatusOK, first.Code)
+		etag := first.Header().Get("ETag")
+		suite.Require().NotEmpty(etag)
+
+		req := httptest.NewRequest(http.MethodGet, "/products", nil)
+		req.Header.Set("If-None-Match", etag)
+		w := httptest.NewRecorder()
+
+		// Act
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusNotModified, w.Code)
+		suite.Empty(w.Body.String())
+		suite.Equal(etag, w.Header().Get("ETag"))
+		suite.Empty(w.Header().Get("Last-Modified"))
+		suite.Equal(testListCacheControl, first.Header().Get("Cache-Control"))
+		suite.Contains(first.Header().Get("Vary"), "X-Tenant-ID")
+		suite.Equal("application/json; charset=utf-8", first.Header().Get("Content-Type"))
+	})
+
+	suite.Run("should return the page once it changed", func() {
+		// Arrange
+		req := httptest.NewRequest(http.MethodGet, "/products", nil)
+		req.Header.Set("If-None-Match", shared_handlers.ContentETag([]byte(`{"items":[]}`)))
+		w := httptest.NewRecorder()
+
+		// Act
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Contains(w.Body.String(), "Default Product")
+	})
+}
+
 func (suite *ProductHandlerTestSuite) TestCreate() {
 	suite.Run("should create product successfully", func() {
 		// Arrange
@@ -239,6 +511,29 @@ func (suite *ProductHandlerTestSuite) TestCreate() {
 		suite.mockCreateUseCase.AssertExpectations(suite.T())
 	})
 
+	suite.Run("should report each invalid field", func() {
+		// Act
+		req := httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(`{"sku":"TEST-001","price":-1}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+		var response shared_dto.ErrorResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(string(shared_handlers.ErrorCodeBadRequest), response.Error)
+		suite.Len(response.Details, 3)
+		suite.Equal(map[string]interface{}{
+			"field":   "price",
+			"rule":    "min",
+			"value":   -1.0,
+			"message": "price must be at least 0",
+		}, response.Details["price"])
+		suite.Contains(response.Details, "name")
+		suite.Contains(response.Details, "category")
+	})
+
 	suite.Run("should return error for invalid payload", func() {
 		// Act
 		req := httptest.NewRequest(http.MethodPost, "/products", bytes.NewBuffer([]byte("invalid json")))
@@ -262,13 +557,40 @@ func (suite *ProductHandlerTestSuite) TestUpdate() {
 			Price:    199.99,
 		}
 
-		suite.mockUpdateUseCase.On("Execute", mock.Anything, productID, mock.AnythingOfType("*models.product")).
+		suite.mockUpdateUseCase.On("Execute", mock.Anything, productID, mock.AnythingOfType("*models.product"), (*int)(nil)).
+			Return(nil)
+
+		// Act
+		body, _ := json.Marshal(updateRequest)
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/products/%s", productID), bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusNoContent, w.Code)
+		suite.mockUpdateUseCase.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should pass If-Match version to use case", func() {
+		// Arrange
+		productID := uuid.New()
+		updateRequest := dto.CreateProductRequest{
+			Sku:      "UPDATED-001",
+			Name:     "Updated Product",
+			Category: "Updated Category",
+			Price:    199.99,
+		}
+		expectedVersion := 3
+
+		suite.mockUpdateUseCase.On("Execute", mock.Anything, productID, mock.AnythingOfType("*models.product"), &expectedVersion).
 			Return(nil)
 
 		// Act
 		body, _ := json.Marshal(updateRequest)
 		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/products/%s", productID), bytes.NewBuffer(body))
 		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"3"`)
 		w := httptest.NewRecorder()
 		suite.router.ServeHTTP(w, req)
 
@@ -277,6 +599,32 @@ func (suite *ProductHandlerTestSuite) TestUpdate() {
 		suite.mockUpdateUseCase.AssertExpectations(suite.T())
 	})
 
+	suite.Run("should return 412 when version has moved on", func() {
+		// Arrange
+		productID := uuid.New()
+		updateRequest := dto.CreateProductRequest{
+			Sku:      "UPDATED-001",
+			Name:     "Updated Product",
+			Category: "Updated Category",
+			Price:    199.99,
+		}
+
+		suite.mockUpdateUseCase.On("Execute", mock.Anything, productID, mock.AnythingOfType("*models.product"), mock.Anything).
+			Return(shared_handlers.ErrPreconditionFailed)
+
+		// Act
+		body, _ := json.Marshal(updateRequest)
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/products/%s", productID), bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"1"`)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusPreconditionFailed, w.Code)
+		suite.mockUpdateUseCase.AssertExpectations(suite.T())
+	})
+
 	suite.Run("should return error for invalid UUID", func() {
 		// Act
 		req := httptest.NewRequest(http.MethodPut, "/products/invalid-uuid", bytes.NewBuffer([]byte("{}")))
@@ -300,12 +648,10 @@ func (suite *ProductHandlerTestSuite) TestPatch() {
 			Price: &price,
 		}
 
-		expectedUpdates := map[string]interface{}{
-			"name":  name,
-			"price": decimal.NewFromFloat(price),
-		}
+		expectedPrice := decimal.NewFromFloat(price)
+		expectedPatch := models.ProductPatch{Name: &name, Price: &expectedPrice}
 
-		suite.mockPatchUseCase.On("Execute", mock.Anything, productID, expectedUpdates).
+		suite.mockPatchUseCase.On("Execute", mock.Anything, productID, expectedPatch, (*int)(nil)).
 			Return(nil)
 
 		// Act
@@ -321,12 +667,160 @@ func (suite *ProductHandlerTestSuite) TestPatch() {
 	})
 }
 
+func (suite *ProductHandlerTestSuite) sendPatch(id uuid.UUID, contentType string, body string, ifMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/products/%s", id), strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *ProductHandlerTestSuite) TestPatchDocuments() {
+	productID := uuid.New()
+	stored := func() models.Product {
+		return models_mothers.NewProductMother().
+			WithID(productID).
+			WithName("Mouse").
+			WithPriceFloat(19.9).
+			WithVersion(4).
+			MustBuild()
+	}
+	readVersion := 4
+
+	suite.Run("should apply a merge patch against the read version", func() {
+		// Arrange
+		suite.mockGetOneUseCase.On("Execute", mock.MatchedBy(request_context.UncachedReads), productID).Return(stored(), nil).Once()
+		name := "Keyboard"
+		price := decimal.RequireFromString("42.5")
+		expectedPatch := models.ProductPatch{Name: &name, Price: &price}
+		suite.mockPatchUseCase.On("Execute", mock.Anything, productID, expectedPatch, &readVersion).Return(nil).Once()
+
+		// Act
+		w := suite.sendPatch(productID, "application/merge-patch+json", `{"name":"Keyboard","price":"42.5"}`, "")
+
+		// Assert
+		suite.Equal(http.StatusNoContent, w.Code)
+		suite.mockPatchUseCase.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should test then replace with a JSON patch", func() {
+		// Arrange
+		suite.mockGetOneUseCase.On("Execute", mock.Anything, productID).Return(stored(), nil).Once()
+		price := decimal.RequireFromString("24.5")
+		expectedPatch := models.ProductPatch{Price: &price}
+		suite.mockPatchUseCase.On("Execute", mock.Anything, productID, expectedPatch, &readVersion).Return(nil).Once()
+
+		// Act
+		w := suite.sendPatch(productID, "application/json-patch+json",
+			`[{"op":"test","path":"/price","value":"19.9"},{"op":"replace","path":"/price","value":24.5}]`, `"4"`)
+
+		// Assert
+		suite.Equal(http.StatusNoContent, w.Code)
+		suite.mockPatchUseCase.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should return 422 when a test operation fails", func() {
+		// Arrange
+		suite.mockGetOneUseCase.On("Execute", mock.Anything, productID).Return(stored(), nil).Once()
+
+		// Act
+		w := suite.sendPatch(productID, "application/json-patch+json",
+			`[{"op":"test","path":"/name","value":"Keyboard"},{"op":"replace","path":"/name","value":"Trackpad"}]`, "")
+
+		// Assert
+		suite.Equal(http.StatusUnprocessableEntity, w.Code)
+	})
+
+	suite.Run("should return 422 when the patch changes a read-only field", func() {
+		// Arrange
+		suite.mockGetOneUseCase.On("Execute", mock.Anything, productID).Return(stored(), nil).Once()
+
+		// Act
+		w := suite.sendPatch(productID, "application/json-patch+json", `[{"op":"replace","path":"/version","value":9}]`, "")
+
+		// Assert
+		suite.Equal(http.StatusUnprocessableEntity, w.Code)
+	})
+
+	suite.Run("should return 422 when the patch adds an unknown field", func() {
+		// Arrange
+		suite.mockGetOneUseCase.On("Execute", mock.Anything, productID).Return(stored(), nil).Once()
+
+		// Act
+		w := suite.sendPatch(productID, "application/merge-patch+json", `{"color":"red"}`, "")
+
+		// Assert
+		suite.Equal(http.StatusUnprocessableEntity, w.Code)
+	})
+
+	suite.Run("should reject a patched product that breaks invariants", func() {
+		// Arrange
+		suite.mockGetOneUseCase.On("Execute", mock.Anything, productID).Return(stored(), nil).Once()
+		empty := ""
+		suite.mockPatchUseCase.On("Execute", mock.Anything, productID, models.ProductPatch{Name: &empty}, &readVersion).
+			Return(models.ErrProductNameEmpty).Once()
+
+		// Act
+		w := suite.sendPatch(productID, "application/merge-patch+json", `{"name":null}`, "")
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+		var response shared_dto.ErrorResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(models.ErrProductNameEmpty.Code, response.Error)
+	})
+
+	suite.Run("should return 400 for a malformed patch", func() {
+		// Arrange
+		suite.mockGetOneUseCase.On("Execute", mock.Anything, productID).Return(stored(), nil).Once()
+
+		// Act
+		w := suite.sendPatch(productID, "application/json-patch+json", `[{"op":"increment","path":"/price"}]`, "")
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	suite.Run("should return 412 when If-Match is not the stored version", func() {
+		// Arrange
+		suite.mockGetOneUseCase.On("Execute", mock.Anything, productID).Return(stored(), nil).Once()
+
+		// Act
+		w := suite.sendPatch(productID, "application/merge-patch+json", `{"name":"Keyboard"}`, `"3"`)
+
+		// Assert
+		suite.Equal(http.StatusPreconditionFailed, w.Code)
+	})
+
+	suite.Run("should return 404 when product not found", func() {
+		// Arrange
+		suite.mockGetOneUseCase.On("Execute", mock.Anything, productID).Return(nil, nil).Once()
+
+		// Act
+		w := suite.sendPatch(productID, "application/merge-patch+json", `{"name":"Keyboard"}`, "")
+
+		// Assert
+		suite.Equal(http.StatusNotFound, w.Code)
+	})
+
+	suite.Run("should return 415 for other content types", func() {
+		// Act
+		w := suite.sendPatch(productID, "text/plain", `name=Keyboard`, "")
+
+		// Assert
+		suite.Equal(http.StatusUnsupportedMediaType, w.Code)
+	})
+}
+
 func (suite *ProductHandlerTestSuite) TestDelete() {
 	suite.Run("should delete product successfully", func() {
 		// Arrange
 		productID := uuid.New()
 
-		suite.mockDeleteUseCase.On("Execute", mock.Anything, productID).
+		suite.mockDeleteUseCase.On("Execute", mock.Anything, productID, (*int)(nil)).
 			Return(nil)
 
 		// Act
@@ -339,6 +833,17 @@ func (suite *ProductHandlerTestSuite) TestDelete() {
 		suite.mockDeleteUseCase.AssertExpectations(suite.T())
 	})
 
+	suite.Run("should return 412 for an If-Match that is not a version", func() {
+		// Act
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/products/%s", uuid.New()), nil)
+		req.Header.Set("If-Match", `W/"1"`)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusPreconditionFailed, w.Code)
+	})
+
 	suite.Run("should return error for invalid UUID", func() {
 		// Act
 		req := httptest.NewRequest(http.MethodDelete, "/products/invalid-uuid", nil)
@@ -350,6 +855,775 @@ func (suite *ProductHandlerTestSuite) TestDelete() {
 	})
 }
 
+func (suite *ProductHandlerTestSuite) TestGetTrashPaginated() {
+	suite.Run("should list trashed products", func() {
+		// Arrange
+		deletedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		products := []models.Product{
+			models_mothers.NewProductMother().WithDeletedAt(deletedAt).MustBuild(),
+		}
+		expectedQuery := models.ProductQuery{
+			Filter: models.ProductFilter{Trashed: true},
+			Sort:   models.DefaultProductSort(),
+		}
+
+		suite.mockGetAllUseCase.On("Execute", mock.Anything, expectedQuery).
+			Return(models.ProductPage{Items: products}, nil)
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products/trash", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+
+		var response shared_dto.PaginatedResult[dto.ProductResponse]
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		suite.NoError(err)
+		suite.Require().Len(response.Items, 1)
+		suite.Require().NotNil(response.Items[0].DeletedAt)
+		suite.True(deletedAt.Equal(*response.Items[0].DeletedAt))
+		suite.mockGetAllUseCase.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should reject a cursor issued for the live listing", func() {
+		// Arrange
+		product := models_mothers.NewProductMother().MustBuild()
+		cursor := suite.encodeCursor(models.ProductFilter{}, models.NewProductCursor(product, models.DefaultProductSort(), false))
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products/trash?cursor="+cursor, nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	suite.Run("should reject a trash cursor on the live listing", func() {
+		// Arrange
+		product := models_mothers.NewProductMother().MustBuild()
+		cursor := suite.encodeCursor(models.ProductFilter{Trashed: true}, models.NewProductCursor(product, models.DefaultProductSort(), false))
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products?cursor="+cursor, nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+}
+
+func (suite *ProductHandlerTestSuite) TestRestore() {
+	suite.Run("should restore product successfully", func() {
+		// Arrange
+		productID := uuid.New()
+		suite.mockRestoreUseCase.On("Execute", mock.Anything, productID).Return(nil)
+
+		// Act
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/products/trash/%s/restore", productID), nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusNoContent, w.Code)
+		suite.mockRestoreUseCase.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should return 404 when product is not in trash", func() {
+		// Arrange
+		productID := uuid.New()
+		suite.mockRestoreUseCase.On("Execute", mock.Anything, productID).Return(shared_handlers.ErrNotFound)
+
+		// Act
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/products/trash/%s/restore", productID), nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusNotFound, w.Code)
+	})
+}
+
+func (suite *ProductHandlerTestSuite) TestPurge() {
+	suite.Run("should purge product successfully", func() {
+		// Arrange
+		productID := uuid.New()
+		suite.mockPurgeUseCase.On("Execute", mock.Anything, productID).Return(nil)
+
+		// Act
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/products/trash/%s", productID), nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusNoContent, w.Code)
+		suite.mockPurgeUseCase.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should return error for invalid UUID", func() {
+		// Act
+		req := httptest.NewRequest(http.MethodDelete, "/products/trash/invalid-uuid", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+}
+
+func (suite *ProductHandlerTestSuite) TestGetHistory() {
+	suite.Run("should return history with a cursor to the next page", func() {
+		// Arrange
+		productID := uuid.New()
+		price := "10"
+		entry := models.ProductAuditEntry{
+			ID:         uuid.New(),
+			ProductID:  productID,
+			Action:     models.ProductAuditCreated,
+			Actor:      "alice",
+			RequestID:  "req-1",
+			OccurredAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Changes:    []models.ProductFieldChange{{Field: "price", To: &price}},
+		}
+		next := models.ProductAuditCursor{OccurredAt: entry.OccurredAt, ID: entry.ID}
+		suite.mockHistoryUseCase.On("Execute", mock.Anything, models.ProductAuditQuery{ProductID: productID}).
+			Return(models.ProductAuditPage{Items: []models.ProductAuditEntry{entry}, Next: &next}, nil)
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/products/%s/history", productID), nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+
+		var response shared_dto.PaginatedResult[dto.ProductHistoryEntryResponse]
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Require().Len(response.Items, 1)
+		suite.Equal("alice", response.Items[0].Actor)
+		suite.Equal("req-1", response.Items[0].RequestID)
+		suite.Require().Len(response.Items[0].Changes, 1)
+		suite.Nil(response.Items[0].Changes[0].From)
+		suite.Equal("10", *response.Items[0].Changes[0].To)
+		suite.Require().NotNil(response.NextCursor)
+
+		// The cursor is bound to the product it was issued for
+		cursor, err := suite.codec.Decode(*response.NextCursor)
+		suite.Require().NoError(err)
+		position, err := dto.ProductAuditCursorFromPaginationCursor(productID, cursor)
+		suite.Require().NoError(err)
+		suite.True(next.OccurredAt.Equal(position.OccurredAt))
+		suite.Equal(next.ID, position.ID)
+	})
+
+	suite.Run("should reject a cursor issued for another product", func() {
+		// Arrange
+		cursor, err := suite.codec.Encode(dto.NewProductHistoryPaginationCursor(uuid.New(), models.ProductAuditCursor{OccurredAt: time.Now(), ID: uuid.New()}))
+		suite.Require().NoError(err)
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/products/%s/history?cursor=%s", uuid.New(), cursor), nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+}
+
+func (suite *ProductHandlerTestSuite) TestSearch() {
+	suite.Run("should return ranked hits with highlights and cursors", func() {
+		// Arrange
+		hit := models.ProductSearchHit{
+			Product:    models_mothers.NewProductMother().WithName("Gaming Laptop").MustBuild(),
+			Rank:       0.6079271,
+			Highlights: models.ProductSearchHighlights{Name: "Gaming <mark>Laptop</mark>", Sku: "DEFAULT-001", Category: "Electronics"},
+		}
+		next := models.NewProductSearchCursor(hit, false)
+		category := "Electronics"
+		expectedQuery := models.ProductSearchQuery{Text: "laptop", Filter: models.ProductFilter{Category: &category}}
+		suite.mockSearchUseCase.On("Execute", mock.Anything, expectedQuery).
+			Return(models.ProductSearchPage{Items: []models.ProductSearchHit{hit}, Next: &next}, nil)
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products/search?q=+laptop+&category=Electronics", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+
+		var response shared_dto.PaginatedResult[dto.ProductSearchHitResponse]
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Require().Len(response.Items, 1)
+		suite.Equal(hit.Product.ID(), response.Items[0].ID)
+		suite.Equal("Gaming Laptop", response.Items[0].Name)
+		suite.Equal(hit.Rank, response.Items[0].Rank)
+		suite.Equal("Gaming <mark>Laptop</mark>", response.Items[0].Highlights.Name)
+		suite.Nil(response.PrevCursor)
+		suite.Require().NotNil(response.NextCursor)
+
+		// The cursor carries the search text, filters and exact rank
+		cursor, err := suite.codec.Decode(*response.NextCursor)
+		suite.Require().NoError(err)
+		cursorQuery, err := dto.ProductSearchQueryFromPaginationCursor(cursor)
+		suite.Require().NoError(err)
+		suite.Equal("laptop", cursorQuery.Text)
+		suite.Equal(expectedQuery.Filter, cursorQuery.Filter)
+		suite.Equal(next, *cursorQuery.Cursor)
+	})
+
+	suite.Run("should continue from a cursor without repeating the text", func() {
+		// Arrange
+		position := models.ProductSearchCursor{Rank: 0.5, ID: uuid.New()}
+		cursor, err := suite.codec.Encode(dto.NewProductSearchPaginationCursor("laptop", models.ProductFilter{}, position))
+		suite.Require().NoError(err)
+		expectedQuery := models.ProductSearchQuery{Text: "laptop", Filter: models.ProductFilter{}, Cursor: &position}
+		suite.mockSearchUseCase.On("Execute", mock.Anything, expectedQuery).Return(models.ProductSearchPage{}, nil)
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products/search?cursor="+cursor, nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		suite.mockSearchUseCase.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should reject a cursor issued for another search", func() {
+		// Arrange
+		cursor, err := suite.codec.Encode(dto.NewProductSearchPaginationCursor("phone", models.ProductFilter{}, models.ProductSearchCursor{Rank: 0.5, ID: uuid.New()}))
+		suite.Require().NoError(err)
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products/search?q=laptop&cursor="+cursor, nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	suite.Run("should require search text", func() {
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products/search?q=++", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+		var response shared_dto.ErrorResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(shared_handlers.ErrInvalidSearchQuery.Message, response.Message)
+	})
+}
+
+func (suite *ProductHandlerTestSuite) postBatch(path string, payload any) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(payload)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *ProductHandlerTestSuite) TestBatchCreate() {
+	suite.Run("should return 200 when every item is created", func() {
+		// Arrange
+		productID := uuid.New()
+		request := dto.BatchCreateProductsRequest{
+			Items: []dto.CreateProductRequest{
+				{Sku: "TEST-001", Name: "Test Product", Category: "Test Category", Price: 9.99},
+			},
+		}
+		suite.mockBatchCreate.On("Execute", mock.Anything, models.ProductBatchAllOrNothing, mock.MatchedBy(func(items []models.ProductBatchCreateItem) bool {
+			return len(items) == 1 && items[0].Err == nil && items[0].Product.Sku() == "TEST-001"
+		})).Return([]models.ProductBatchResult{{Index: 0, ProductID: productID}}, nil).Once()
+
+		// Act
+		w := suite.postBatch("/products/batch/create", request)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		var response dto.ProductBatchResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(string(models.ProductBatchAllOrNothing), response.Mode)
+		suite.Equal(1, response.Succeeded)
+		suite.Equal(0, response.Failed)
+		suite.Equal(http.StatusCreated, response.Results[0].Status)
+		suite.Equal(productID.String(), response.Results[0].ID)
+		suite.Nil(response.Results[0].Error)
+		suite.mockBatchCreate.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should hand invalid items to the use case already rejected and return 207", func() {
+		// Arrange
+		request := dto.BatchCreateProductsRequest{
+			Mode: string(models.ProductBatchBestEffort),
+			Items: []dto.CreateProductRequest{
+				{Sku: "TEST-001", Name: "Test Product", Category: "Test Category", Price: 9.99},
+				{Sku: "TEST-002", Category: "Test Category", Price: 9.99},
+			},
+		}
+		suite.mockBatchCreate.On("Execute", mock.Anything, models.ProductBatchBestEffort, mock.MatchedBy(func(items []models.ProductBatchCreateItem) bool {
+			itemErr, ok := items[1].Err.(shared_handlers.InfraError)
+			return len(items) == 2 && items[0].Err == nil && ok &&
+				errors.Is(itemErr, shared_handlers.ErrInvalidPayload) && itemErr.Details["name"] != nil
+		})).Return([]models.ProductBatchResult{
+			{Index: 0, ProductID: uuid.New()},
+			{Index: 1, Err: shared_handlers.ErrInvalidPayload},
+		}, nil).Once()
+
+		// Act
+		w := suite.postBatch("/products/batch/create", request)
+
+		// Assert
+		suite.Equal(http.StatusMultiStatus, w.Code)
+		var response dto.ProductBatchResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(1, response.Succeeded)
+		suite.Equal(1, response.Failed)
+		suite.Equal(http.StatusBadRequest, response.Results[1].Status)
+		suite.Empty(response.Results[1].ID)
+		suite.Require().NotNil(response.Results[1].Error)
+		suite.Equal(string(shared_handlers.ErrInvalidPayload.Code), response.Results[1].Error.Error)
+		suite.mockBatchCreate.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should report aborted items as failed dependencies", func() {
+		// Arrange
+		request := dto.BatchCreateProductsRequest{
+			Items: []dto.CreateProductRequest{
+				{Sku: "TEST-001", Name: "Test Product", Category: "Test Category", Price: 9.99},
+				{Sku: "TEST-002", Name: "Test Product", Category: "Test Category", Price: 9.99},
+			},
+		}
+		suite.mockBatchCreate.On("Execute", mock.Anything, models.ProductBatchAllOrNothing, mock.Anything).
+			Return([]models.ProductBatchResult{
+				{Index: 0, Err: shared_handlers.ErrBatchItemAborted},
+				{Index: 1, Err: shared_handlers.ErrInvalidPayload},
+			}, nil).Once()
+
+		// Act
+		w := suite.postBatch("/products/batch/create", request)
+
+		// Assert
+		suite.Equal(http.StatusMultiStatus, w.Code)
+		var response dto.ProductBatchResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(0, response.Succeeded)
+		suite.Equal(2, response.Failed)
+		suite.Equal(http.StatusFailedDependency, response.Results[0].Status)
+		suite.Equal(http.StatusBadRequest, response.Results[1].Status)
+	})
+
+	suite.Run("should return 400 for an empty batch", func() {
+		// Act
+		w := suite.postBatch("/products/batch/create", dto.BatchCreateProductsRequest{Items: []dto.CreateProductRequest{}})
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	suite.Run("should return 400 for a batch over the maximum size", func() {
+		// Arrange
+		items := make([]dto.CreateProductRequest, testBatchMaxItems+1)
+
+		// Act
+		w := suite.postBatch("/products/batch/create", dto.BatchCreateProductsRequest{Items: items})
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	suite.Run("should return 400 for an unknown mode", func() {
+		// Arrange
+		request := dto.BatchCreateProductsRequest{
+			Mode:  "sometimes",
+			Items: []dto.CreateProductRequest{{Sku: "TEST-001", Name: "Test Product", Category: "Test Category", Price: 9.99}},
+		}
+
+		// Act
+		w := suite.postBatch("/products/batch/create", request)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+		var response shared_dto.ErrorResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(shared_handlers.ErrInvalidBatchMode.Message, response.Message)
+	})
+}
+
+func (suite *ProductHandlerTestSuite) TestBatchUpdate() {
+	suite.Run("should pass ids and versions and reject malformed ids", func() {
+		// Arrange
+		productID := uuid.New()
+		version := 2
+		request := dto.BatchUpdateProductsRequest{
+			Items: []dto.BatchUpdateProductItemRequest{
+				{ID: productID.String(), Sku: "TEST-001", Name: "Test Product", Category: "Test Category", Price: 9.99, Version: &version},
+				{ID: "invalid-uuid", Sku: "TEST-002", Name: "Test Product", Category: "Test Category", Price: 9.99},
+			},
+		}
+		suite.mockBatchUpdate.On("Execute", mock.Anything, models.ProductBatchAllOrNothing, mock.MatchedBy(func(items []models.ProductBatchUpdateItem) bool {
+			return len(items) == 2 &&
+				items[0].ID == productID && items[0].ExpectedVersion != nil && *items[0].ExpectedVersion == version && items[0].Err == nil &&
+				errors.Is(items[1].Err, shared_handlers.ErrInvalidUUID)
+		})).Return([]models.ProductBatchResult{
+			{Index: 0, ProductID: productID, Err: shared_handlers.ErrBatchItemAborted},
+			{Index: 1, Err: shared_handlers.ErrInvalidUUID},
+		}, nil).Once()
+
+		// Act
+		w := suite.postBatch("/products/batch/update", request)
+
+		// Assert
+		suite.Equal(http.StatusMultiStatus, w.Code)
+		var response dto.ProductBatchResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(productID.String(), response.Results[0].ID)
+		suite.Equal(http.StatusFailedDependency, response.Results[0].Status)
+		suite.Equal(http.StatusBadRequest, response.Results[1].Status)
+		suite.mockBatchUpdate.AssertExpectations(suite.T())
+	})
+}
+
+func (suite *ProductHandlerTestSuite) TestBatchDelete() {
+	suite.Run("should return 200 with a 204 status per deleted item", func() {
+		// Arrange
+		productIDs := []uuid.UUID{uuid.New(), uuid.New()}
+		request := dto.BatchDeleteProductsRequest{
+			Mode: string(models.ProductBatchBestEffort),
+			Items: []dto.BatchDeleteProductItemRequest{
+				{ID: productIDs[0].String()},
+				{ID: productIDs[1].String()},
+			},
+		}
+		suite.mockBatchDelete.On("Execute", mock.Anything, models.ProductBatchBestEffort, mock.Anything).
+			Return([]models.ProductBatchResult{
+				{Index: 0, ProductID: productIDs[0]},
+				{Index: 1, ProductID: productIDs[1]},
+			}, nil).Once()
+
+		// Act
+		w := suite.postBatch("/products/batch/delete", request)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		var response dto.ProductBatchResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(2, response.Succeeded)
+		for _, result := range response.Results {
+			suite.Equal(http.StatusNoContent, result.Status)
+		}
+		suite.mockBatchDelete.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should return the error when the batch cannot run", func() {
+		// Arrange
+		request := dto.BatchDeleteProductsRequest{
+			Items: []dto.BatchDeleteProductItemRequest{{ID: uuid.New().String()}},
+		}
+		suite.mockBatchDelete.On("Execute", mock.Anything, models.ProductBatchAllOrNothing, mock.Anything).
+			Return(nil, errors.New("database is down")).Once()
+
+		// Act
+		w := suite.postBatch("/products/batch/delete", request)
+
+		// Assert
+		suite.Equal(http.StatusInternalServerError, w.Code)
+	})
+}
+
+func (suite *ProductHandlerTestSuite) postImport(query string, file string) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "products.csv")
+	suite.Require().NoError(err)
+	_, err = part.Write([]byte(file))
+	suite.Require().NoError(err)
+	suite.Require().NoError(writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/products/import"+query, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *ProductHandlerTestSuite) TestImport() {
+	file := "sku,name,category,price\nTEST-001,Test Product,Electronics,9.99\nTEST-002,,Electronics,1\n"
+
+	suite.Run("should return the report as JSON", func() {
+		// Arrange
+		productID := uuid.New()
+		suite.mockImportUseCase.On("Execute", mock.Anything, mock.MatchedBy(func(rows []models.ProductImportRow) bool {
+			return len(rows) == 2 && rows[0].Line == 2 && rows[0].Err == nil && rows[1].Err != nil
+		}), false).Return(models.ProductImportReport{
+			Rows: []models.ProductImportRowResult{
+				{Line: 2, Sku: "TEST-001", ProductID: productID},
+				{Line: 3, Sku: "TEST-002", Err: models.ErrProductNameEmpty},
+			},
+		}, nil).Once()
+
+		// Act
+		w := suite.postImport("", file)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		var response dto.ProductImportReportResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.False(response.DryRun)
+		suite.Equal(1, response.Accepted)
+		suite.Equal(1, response.Rejected)
+		suite.Equal(dto.ProductImportRowAccepted, response.Rows[0].Status)
+		suite.Equal(productID.String(), response.Rows[0].ID)
+		suite.Equal(dto.ProductImportRowRejected, response.Rows[1].Status)
+		suite.Equal(models.ErrProductNameEmpty.Code, response.Rows[1].Error.Error)
+		suite.mockImportUseCase.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should return a dry run report as a CSV download", func() {
+		// Arrange
+		suite.mockImportUseCase.On("Execute", mock.Anything, mock.Anything, true).Return(models.ProductImportReport{
+			DryRun: true,
+			Rows: []models.ProductImportRowResult{
+				{Line: 2, Sku: "TEST-001"},
+				{Line: 3, Sku: "TEST-002", Err: models.ErrProductNameEmpty},
+			},
+		}, nil).Once()
+
+		// Act
+		w := suite.postImport("?dry_run=true&format=csv", file)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Contains(w.Header().Get("Content-Type"), "text/csv")
+		suite.Contains(w.Header().Get("Content-Disposition"), "attachment")
+		suite.Equal("line,sku,status,id,error_code,error_message\n"+
+			"2,TEST-001,accepted,,,\n"+
+			"3,TEST-002,rejected,,PRODUCT_NAME_EMPTY,Product name cannot be empty\n", w.Body.String())
+		suite.mockImportUseCase.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should return 400 for a file without a header", func() {
+		// Act
+		w := suite.postImport("", "TEST-001,Test Product,Electronics,9.99\n")
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+		var response shared_dto.ErrorResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(shared_handlers.ErrInvalidImportFile.Message, response.Message)
+	})
+
+	suite.Run("should return 400 for a file with more rows than allowed", func() {
+		// Arrange
+		tooManyRows := "sku,name,category,price\n" + strings.Repeat("TEST-001,Test Product,Electronics,9.99\n", testImportMaxRows+1)
+
+		// Act
+		w := suite.postImport("", tooManyRows)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	suite.Run("should return 400 for invalid options", func() {
+		// Act
+		w := suite.postImport("?format=xml", file)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	suite.Run("should return 400 when no file is sent", func() {
+		// Act
+		req := httptest.NewRequest(http.MethodPost, "/products/import", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+}
+
+func productSeq(products []models.Product, err error) iter.Seq2[models.Product, error] {
+	return func(yield func(models.Product, error) bool) {
+		for _, product := range products {
+			if !yield(product, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+func (suite *ProductHandlerTestSuite) TestExport() {
+	product := models_mothers.NewProductMother().WithSku("TEST-001").WithName("Test Product").WithCategory("Electronics").WithPrice(decimal.RequireFromString("9.99")).MustBuild()
+	defaultQuery := models.ProductExportQuery{Sort: models.DefaultProductSort()}
+
+	suite.Run("should stream CSV by default with filters and sort applied", func() {
+		// Arrange
+		category := "Electronics"
+		expectedQuery := models.ProductExportQuery{
+			Filter: models.ProductFilter{Category: &category},
+			Sort:   models.ProductSort{Field: models.ProductSortByPrice, Direction: models.SortDescending},
+		}
+		suite.mockExportUseCase.On("Execute", mock.Anything, expectedQuery).Return(productSeq([]models.Product{product}, nil)).Once()
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products/export?category=Electronics&sort=price:desc", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Equal("text/csv", w.Header().Get("Content-Type"))
+		suite.Equal(`attachment; filename="products.csv"`, w.Header().Get("Content-Disposition"))
+		suite.Equal("id,sku,name,category,price,version\n"+
+			product.ID().String()+",TEST-001,Test Product,Electronics,9.99,1\n", w.Body.String())
+		suite.mockExportUseCase.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should negotiate NDJSON from the Accept header", func() {
+		// Arrange
+		suite.mockExportUseCase.On("Execute", mock.Anything, defaultQuery).Return(productSeq([]models.Product{product, product}, nil)).Once()
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products/export", nil)
+		req.Header.Set("Accept", "application/json;q=0.5, application/x-ndjson")
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Equal("application/x-ndjson", w.Header().Get("Content-Type"))
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		suite.Len(lines, 2)
+		var response dto.ProductResponse
+		suite.NoError(json.Unmarshal([]byte(lines[0]), &response))
+		suite.Equal(product.ID(), response.ID)
+	})
+
+	suite.Run("should let the format parameter override the Accept header", func() {
+		// Arrange
+		suite.mockExportUseCase.On("Execute", mock.Anything, defaultQuery).Return(productSeq([]models.Product{product}, nil)).Once()
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products/export?format=xlsx", nil)
+		req.Header.Set("Accept", "text/csv")
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Equal("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", w.Header().Get("Content-Type"))
+		suite.Equal(`attachment; filename="products.xlsx"`, w.Header().Get("Content-Disposition"))
+		suite.True(bytes.HasPrefix(w.Body.Bytes(), []byte("PK")))
+	})
+
+	suite.Run("should write only the header when nothing matches", func() {
+		// Arrange
+		suite.mockExportUseCase.On("Execute", mock.Anything, defaultQuery).Return(productSeq(nil, nil)).Once()
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products/export", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Equal("id,sku,name,category,price,version\n", w.Body.String())
+	})
+
+	suite.Run("should return 406 when no accepted media type can be produced", func() {
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products/export", nil)
+		req.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusNotAcceptable, w.Code)
+	})
+
+	suite.Run("should return 400 for an unknown format", func() {
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products/export?format=xml", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+		var response shared_dto.ErrorResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(shared_handlers.ErrInvalidExportFormat.Message, response.Message)
+	})
+
+	suite.Run("should return 400 for an invalid filter", func() {
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products/export?min_price=cheap", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	suite.Run("should return 500 when the export fails before the first product", func() {
+		// Arrange
+		suite.mockExportUseCase.On("Execute", mock.Anything, defaultQuery).Return(productSeq(nil, errors.New("database error"))).Once()
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products/export", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusInternalServerError, w.Code)
+		suite.Contains(w.Header().Get("Content-Type"), "application/json")
+	})
+
+	suite.Run("should end the download when the export fails after it started", func() {
+		// Arrange
+		suite.mockExportUseCase.On("Execute", mock.Anything, defaultQuery).Return(productSeq([]models.Product{product}, errors.New("database error"))).Once()
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products/export?format=ndjson", nil)
+		w := httptest.NewRecorder()
+		serve := func() { suite.router.ServeHTTP(w, req) }
+
+		// Assert
+		suite.PanicsWithValue(http.ErrAbortHandler, serve)
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Equal(1, strings.Count(w.Body.String(), "\n"))
+		suite.NotContains(w.Body.String(), "error")
+	})
+
+	suite.Run("should stop the download quietly when the client went away", func() {
+		// Arrange
+		suite.mockExportUseCase.On("Execute", mock.Anything, defaultQuery).Return(productSeq([]models.Product{product}, context.Canceled)).Once()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/products/export?format=ndjson", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+		serve := func() { suite.router.ServeHTTP(w, req) }
+
+		// Assert
+		suite.NotPanics(serve)
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Equal(1, strings.Count(w.Body.String(), "\n"))
+	})
+}
+
 func TestProductHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(ProductHandlerTestSuite))
 }
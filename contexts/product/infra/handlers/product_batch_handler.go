@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/dto"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin"
+)
+
+// BatchCreate godoc
+// @Summary Create products in batch
+// @Description Create up to BATCH_MAX_ITEMS products. In all_or_nothing mode (the default) nothing is created if any item fails; in best_effort mode every valid item is created. Each item gets its own status and error
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param batch body dto.BatchCreateProductsRequest true "Products to create"
+// @Param Idempotency-Key header string false "Unique key that makes retrying the request safe: a repeat gets the original response replayed"
+// @Param X-Tenant-ID header string false "Tenant whose catalogue is used (default: the tenant of the credentials, else default)"
+// @Success 200 {object} dto.ProductBatchResponse
+// @Success 207 {object} dto.ProductBatchResponse
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 409 {object} shared_dto.ErrorResponse
+// @Failure 422 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /products/batch/create [post]
+func (ph *ProductHandler) BatchCreate(c *gin.Context) {
+	var batchDto dto.BatchCreateProductsRequest
+	if err := c.ShouldBindJSON(&batchDto); err != nil {
+		c.Error(shared_handlers.BindingError(err))
+		return
+	}
+	mode, err := ph.parseProductBatch(batchDto.Mode, len(batchDto.Items))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	results, err := ph.batchCreateUseCase.Execute(c.Request.Context(), mode, batchDto.ToDomainItems())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeProductBatchResponse(c, dto.NewProductBatchResponse(mode, results, http.StatusCreated))
+}
+
+// BatchUpdate godoc
+// @Summary Update products in batch
+// @Description Update all fields of up to BATCH_MAX_ITEMS products. An item may give the version it is based on. In all_or_nothing mode (the default) nothing is updated if any item fails; in best_effort mode every valid item is updated
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param batch body dto.BatchUpdateProductsRequest true "Products to update"
+// @Param Idempotency-Key header string false "Unique key that makes retrying the request safe: a repeat gets the original response replayed"
+// @Param X-Tenant-ID header string false "Tenant whose catalogue is used (default: the tenant of the credentials, else default)"
+// @Success 200 {object} dto.ProductBatchResponse
+// @Success 207 {object} dto.ProductBatchResponse
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 409 {object} shared_dto.ErrorResponse
+// @Failure 422 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /products/batch/update [post]
+func (ph *ProductHandler) BatchUpdate(c *gin.Context) {
+	var batchDto dto.BatchUpdateProductsRequest
+	if err := c.ShouldBindJSON(&batchDto); err != nil {
+		c.Error(shared_handlers.BindingError(err))
+		return
+	}
+	mode, err := ph.parseProductBatch(batchDto.Mode, len(batchDto.Items))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	results, err := ph.batchUpdateUseCase.Execute(c.Request.Context(), mode, batchDto.ToDomainItems())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeProductBatchResponse(c, dto.NewProductBatchResponse(mode, results, http.StatusNoContent))
+}
+
+// BatchDelete godoc
+// @Summary Delete products in batch
+// @Description Move up to BATCH_MAX_ITEMS products to the trash. An item may give the version it is based on. In all_or_nothing mode (the default) nothing is deleted if any item fails; in best_effort mode every valid item is deleted
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param batch body dto.BatchDeleteProductsRequest true "Products to delete"
+// @Param Idempotency-Key header string false "Unique key that makes retrying the request safe: a repeat gets the original response replayed"
+// @Param X-Tenant-ID header string false "Tenant whose catalogue is used (default: the tenant of the credentials, else default)"
+// @Success 200 {object} dto.ProductBatchResponse
+// @Success 207 {object} dto.ProductBatchResponse
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 409 {object} shared_dto.ErrorResponse
+// @Failure 422 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /products/batch/delete [post]
+func (ph *ProductHandler) BatchDelete(c *gin.Context) {
+	var batchDto dto.BatchDeleteProductsRequest
+	if err := c.ShouldBindJSON(&batchDto); err != nil {
+		c.Error(shared_handlers.BindingError(err))
+		return
+	}
+	mode, err := ph.parseProductBatch(batchDto.Mode, len(batchDto.Items))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	results, err := ph.batchDeleteUseCase.Execute(c.Request.Context(), mode, batchDto.ToDomainItems())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeProductBatchResponse(c, dto.NewProductBatchResponse(mode, results, http.StatusNoContent))
+}
+
+func (ph *ProductHandler) parseProductBatch(mode string, size int) (models.ProductBatchMode, error) {
+	if size == 0 || size > ph.batchMaxItems {
+		return "", shared_handlers.ErrInvalidBatchSize
+	}
+	return dto.ParseProductBatchMode(mode)
+}
+
+// writeProductBatchResponse answers 200 when every item was applied and 207
+// Multi-Status otherwise.
+func writeProductBatchResponse(c *gin.Context, response dto.ProductBatchResponse) {
+	if response.Failed > 0 {
+		c.JSON(http.StatusMultiStatus, response)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
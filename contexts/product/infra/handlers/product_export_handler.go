@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/dto"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin"
+)
+
+const productExportFilename = "products"
+
+type productExportMediaType struct {
+	format      string
+	contentType string
+}
+
+// productExportMediaTypes are offered in order of preference: a client that
+// accepts anything gets CSV.
+var productExportMediaTypes = []productExportMediaType{
+	{format: dto.ProductExportFormatCSV, contentType: "text/csv"},
+	{format: dto.ProductExportFormatNDJSON, contentType: "application/x-ndjson"},
+	{format: dto.ProductExportFormatXLSX, contentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+}
+
+// Export godoc
+// @Summary Export products
+// @Description Stream every product matching the filters, in the given sort order, as CSV, NDJSON or XLSX. The format is taken from the format parameter or else negotiated from the Accept header, CSV being the default. Unlike listings the export is not paged. A failure after the download has started ends it early
+// @Tags products
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format, overrides the Accept header" Enums(csv, ndjson, xlsx)
+// @Param category query string false "Exact category to filter by"
+// @Param name query string false "Case-insensitive substring of the product name"
+// @Param min_price query number false "Minimum price (inclusive)"
+// @Param max_price query number false "Maximum price (inclusive)"
+// @Param sort query string false "Sort field and optional direction: id, name, price or sku, e.g. price:desc"
+// @Param X-Tenant-ID header string false "Tenant whose catalogue is used (default: the tenant of the credentials, else default)"
+// @Success 200 {file} file
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 406 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /products/export [get]
+func (ph *ProductHandler) Export(c *gin.Context) {
+	mediaType, err := negotiateProductExportMediaType(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	query, err := parseProductExportQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Headers are only sent with the first product, so that an error reading
+	// the first one can still be reported with a proper status.
+	var writer dto.ProductExportWriter
+	for product, err := range ph.exportUseCase.Execute(c.Request.Context(), query) {
+		if err != nil {
+			if writer == nil {
+				c.Error(err)
+				return
+			}
+			interruptProductExport(c, err)
+			return
+		}
+		if writer == nil {
+			if writer, err = startProductExport(c, mediaType); err != nil {
+				interruptProductExport(c, err)
+				return
+			}
+		}
+		if err := writer.Write(product); err != nil {
+			interruptProductExport(c, err)
+			return
+		}
+	}
+	if writer == nil {
+		if writer, err = startProductExport(c, mediaType); err != nil {
+			interruptProductExport(c, err)
+			return
+		}
+	}
+	if err := writer.Close(); err != nil {
+		interruptProductExport(c, err)
+	}
+}
+
+func negotiateProductExportMediaType(c *gin.Context) (productExportMediaType, error) {
+	if format := c.Query("format"); format != "" {
+		for _, mediaType := range productExportMediaTypes {
+			if mediaType.format == format {
+				return mediaType, nil
+			}
+		}
+		return productExportMediaType{}, shared_handlers.ErrInvalidExportFormat
+	}
+
+	offered := make([]string, len(productExportMediaTypes))
+	for i, mediaType := range productExportMediaTypes {
+		offered[i] = mediaType.contentType
+	}
+	negotiated := c.NegotiateFormat(offered...)
+	for _, mediaType := range productExportMediaTypes {
+		if mediaType.contentType == negotiated {
+			return mediaType, nil
+		}
+	}
+	return productExportMediaType{}, shared_handlers.ErrNotAcceptable
+}
+
+func startProductExport(c *gin.Context, mediaType productExportMediaType) (dto.ProductExportWriter, error) {
+	c.Header("Content-Type", mediaType.contentType)
+	c.Header("Content-Disposition", `attachment; filename="`+productExportFilename+"."+mediaType.format+`"`)
+	return dto.NewProductExportWriter(c.Writer, mediaType.format)
+}
+
+// interruptProductExport ends a download that has already started. The status
+// has been sent, so the error can only be logged, and the connection is
+// dropped so that the client sees a truncated download instead of a file that
+// looks complete. A client that went away needs neither.
+func interruptProductExport(c *gin.Context, err error) {
+	if c.Request.Context().Err() != nil {
+		return
+	}
+	log.Printf("⚠️  Product export interrupted: %v", err)
+	panic(http.ErrAbortHandler)
+}
@@ -1,21 +1,35 @@
 package handlers
 
 import (
+	"encoding/json"
 	"net/http"
-	"strconv"
+	"time"
 
 	"github.com/Akiles94/go-test-api/contexts/product/application/dto"
 	"github.com/Akiles94/go-test-api/contexts/product/application/ports/inbound"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/pagination"
 	"github.com/Akiles94/go-test-api/contexts/shared/application/shared_dto"
 	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/shopspring/decimal"
 )
 
 const topLimitValue = 100
 const bottomLimitValue = 1
 
+// productReadVary lists the request headers, besides the URL, that pick
+// which products a read returns.
+const productReadVary = "Authorization, X-API-Key, X-Tenant-ID"
+
+// ProductCacheControl holds the Cache-Control header of the product reads
+// that clients can revalidate: Item for a single product and List for the
+// listings. An empty value sends no header.
+type ProductCacheControl struct {
+	Item string
+	List string
+}
+
 // ProductHandler handles HTTP requests for products
 type ProductHandler struct {
 	createProductUseCase  inbound.CreateProductUseCasePort
@@ -24,10 +38,23 @@ type ProductHandler struct {
 	deleteProductUseCase  inbound.DeleteProductUseCasePort
 	getAllProductsUseCase inbound.GetAllProductsUseCasePort
 	getOneProductUseCase  inbound.GetOneProductUseCasePort
+	restoreProductUseCase inbound.RestoreProductUseCasePort
+	purgeProductUseCase   inbound.PurgeProductUseCasePort
+	getHistoryUseCase     inbound.GetProductHistoryUseCasePort
+	searchUseCase         inbound.SearchProductsUseCasePort
+	batchCreateUseCase    inbound.BatchCreateProductsUseCasePort
+	batchUpdateUseCase    inbound.BatchUpdateProductsUseCasePort
+	batchDeleteUseCase    inbound.BatchDeleteProductsUseCasePort
+	importUseCase         inbound.ImportProductsUseCasePort
+	exportUseCase         inbound.ExportProductsUseCasePort
+	batchMaxItems         int
+	importMaxRows         int
+	cursorCodec           *pagination.CursorCodec
+	cacheControl          ProductCacheControl
 }
 
 // NewProductHandler creates a new ProductHandler
-func NewProductHandler(createProductUseCase inbound.CreateProductUseCasePort, updateProductUseCase inbound.UpdateProductUseCasePort, patchProductUseCase inbound.PatchProductUseCasePort, deleteProductUseCase inbound.DeleteProductUseCasePort, getAllProductsUseCase inbound.GetAllProductsUseCasePort, getOneProductUseCase inbound.GetOneProductUseCasePort) *ProductHandler {
+func NewProductHandler(createProductUseCase inbound.CreateProductUseCasePort, updateProductUseCase inbound.UpdateProductUseCasePort, patchProductUseCase inbound.PatchProductUseCasePort, deleteProductUseCase inbound.DeleteProductUseCasePort, getAllProductsUseCase inbound.GetAllProductsUseCasePort, getOneProductUseCase inbound.GetOneProductUseCasePort, restoreProductUseCase inbound.RestoreProductUseCasePort, purgeProductUseCase inbound.PurgeProductUseCasePort, getHistoryUseCase inbound.GetProductHistoryUseCasePort, searchUseCase inbound.SearchProductsUseCasePort, batchCreateUseCase inbound.BatchCreateProductsUseCasePort, batchUpdateUseCase inbound.BatchUpdateProductsUseCasePort, batchDeleteUseCase inbound.BatchDeleteProductsUseCasePort, importUseCase inbound.ImportProductsUseCasePort, exportUseCase inbound.ExportProductsUseCasePort, batchMaxItems int, importMaxRows int, cursorCodec *pagination.CursorCodec, cacheControl ProductCacheControl) *ProductHandler {
 	return &ProductHandler{
 		createProductUseCase:  createProductUseCase,
 		updateProductUseCase:  updateProductUseCase,
@@ -35,67 +62,171 @@ func NewProductHandler(createProductUseCase inbound.CreateProductUseCasePort, up
 		deleteProductUseCase:  deleteProductUseCase,
 		getAllProductsUseCase: getAllProductsUseCase,
 		getOneProductUseCase:  getOneProductUseCase,
+		restoreProductUseCase: restoreProductUseCase,
+		purgeProductUseCase:   purgeProductUseCase,
+		getHistoryUseCase:     getHistoryUseCase,
+		searchUseCase:         searchUseCase,
+		batchCreateUseCase:    batchCreateUseCase,
+		batchUpdateUseCase:    batchUpdateUseCase,
+		batchDeleteUseCase:    batchDeleteUseCase,
+		importUseCase:         importUseCase,
+		exportUseCase:         exportUseCase,
+		batchMaxItems:         batchMaxItems,
+		importMaxRows:         importMaxRows,
+		cursorCodec:           cursorCodec,
+		cacheControl:          cacheControl,
 	}
 }
 
 // GetPaginated godoc
 // @Summary Get paginated products
-// @Description Get a paginated list of products with optional cursor and limit
+// @Description Get a paginated list of products with optional filters, sorting, cursor and limit
 // @Tags products
 // @Accept json
 // @Produce json
-// @Param cursor query string false "Cursor for pagination (UUID)"
+// @Param cursor query string false "Opaque cursor, as returned in next_cursor or prev_cursor"
 // @Param limit query int false "Limit of products per page (1-100)" minimum(1) maximum(100)
+// @Param category query string false "Exact category to filter by"
+// @Param name query string false "Case-insensitive substring of the product name"
+// @Param min_price query number false "Minimum price (inclusive)"
+// @Param max_price query number false "Maximum price (inclusive)"
+// @Param sort query string false "Sort field and optional direction: id, name, price or sku, e.g. price:desc"
+// @Param X-Tenant-ID header string false "Tenant whose catalogue is used (default: the tenant of the credentials, else default)"
+// @Param If-None-Match header string false "ETag of the page the client has; answered with 304 if it did not change"
 // @Success 200 {object} shared_dto.PaginatedResult[dto.ProductResponse]
+// @Header 200 {string} ETag "Tag of the page content"
+// @Success 304 "Not Modified"
 // @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
 // @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Router /products [get]
 func (ph *ProductHandler) GetPaginated(c *gin.Context) {
-	cursorStr := c.Query("cursor")
-	limitStr := c.Query("limit")
-	var limit *int
-	var cursor *string
-	if cursorStr != "" {
-		_, err := uuid.Parse(cursorStr)
-		if err != nil {
-			c.Error(shared_handlers.ErrInvalidCursor)
-			return
-		}
-		cursor = &cursorStr
-	}
-	if limitStr != "" {
-		limitValue, err := strconv.Atoi(limitStr)
-		if err != nil {
-			c.Error(shared_handlers.ErrInvalidLimit)
-			return
-		}
-
-		if limitValue < bottomLimitValue {
-			c.Error(shared_handlers.ErrInvalidLimit)
-			return
-		}
-
-		const maxLimit = topLimitValue
-		if limitValue > maxLimit {
-			c.Error(shared_handlers.ErrInvalidLimit)
-			return
-		}
-
-		limit = &limitValue
-	}
-	products, nextCursor, err := ph.getAllProductsUseCase.Execute(c.Request.Context(), cursor, limit)
+	ph.listProducts(c, false)
+}
+
+// GetTrashPaginated godoc
+// @Summary Get paginated trashed products
+// @Description Get a paginated list of soft-deleted products with optional filters, sorting, cursor and limit
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param cursor query string false "Opaque cursor, as returned in next_cursor or prev_cursor"
+// @Param limit query int false "Limit of products per page (1-100)" minimum(1) maximum(100)
+// @Param category query string false "Exact category to filter by"
+// @Param name query string false "Case-insensitive substring of the product name"
+// @Param min_price query number false "Minimum price (inclusive)"
+// @Param max_price query number false "Maximum price (inclusive)"
+// @Param sort query string false "Sort field and optional direction: id, name, price or sku, e.g. price:desc"
+// @Param X-Tenant-ID header string false "Tenant whose catalogue is used (default: the tenant of the credentials, else default)"
+// @Param If-None-Match header string false "ETag of the page the client has; answered with 304 if it did not change"
+// @Success 200 {object} shared_dto.PaginatedResult[dto.ProductResponse]
+// @Header 200 {string} ETag "Tag of the page content"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /products/trash [get]
+func (ph *ProductHandler) GetTrashPaginated(c *gin.Context) {
+	ph.listProducts(c, true)
+}
+
+func (ph *ProductHandler) listProducts(c *gin.Context, trashed bool) {
+	query, err := ph.parseProductQuery(c, trashed)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	var productResponses []dto.ProductResponse = make([]dto.ProductResponse, 0, len(products))
-	for _, product := range products {
+	page, err := ph.getAllProductsUseCase.Execute(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var productResponses []dto.ProductResponse = make([]dto.ProductResponse, 0, len(page.Items))
+	for _, product := range page.Items {
 		productResponse := dto.NewProductResponseFromDomainModel(product)
 		productResponses = append(productResponses, productResponse)
 	}
-	response := shared_dto.NewPaginatedResult(productResponses, nextCursor)
+	nextCursor, err := ph.encodeProductCursor(query.Filter, page.Next)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	prevCursor, err := ph.encodeProductCursor(query.Filter, page.Prev)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response := shared_dto.NewPaginatedResult(productResponses, nextCursor, prevCursor)
+	body, err := json.Marshal(response)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// A page has no update time of its own: a product leaving it would not
+	// make it newer, so it is only revalidated by its content.
+	ph.setProductReadCaching(c, ph.cacheControl.List)
+	if shared_handlers.NotModified(c, shared_handlers.ContentETag(body), time.Time{}) {
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// Search godoc
+// @Summary Search products
+// @Description Full-text search over product name, SKU and category, most relevant first. q accepts web search syntax: quoted phrases, "or" and "-word" to exclude. Matches are wrapped in <mark> tags in the highlights, which are otherwise HTML-escaped
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param q query string true "Search text (up to 256 characters); may be omitted when a cursor is given"
+// @Param cursor query string false "Opaque cursor, as returned in next_cursor or prev_cursor"
+// @Param limit query int false "Limit of products per page (1-100)" minimum(1) maximum(100)
+// @Param category query string false "Exact category to filter by"
+// @Param name query string false "Case-insensitive substring of the product name"
+// @Param min_price query number false "Minimum price (inclusive)"
+// @Param max_price query number false "Maximum price (inclusive)"
+// @Param X-Tenant-ID header string false "Tenant whose catalogue is used (default: the tenant of the credentials, else default)"
+// @Success 200 {object} shared_dto.PaginatedResult[dto.ProductSearchHitResponse]
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /products/search [get]
+func (ph *ProductHandler) Search(c *gin.Context) {
+	query, err := ph.parseProductSearchQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	page, err := ph.searchUseCase.Execute(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	hits := make([]dto.ProductSearchHitResponse, 0, len(page.Items))
+	for _, hit := range page.Items {
+		hits = append(hits, dto.NewProductSearchHitResponseFromDomainModel(hit))
+	}
+	nextCursor, err := ph.encodeProductSearchCursor(query, page.Next)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	prevCursor, err := ph.encodeProductSearchCursor(query, page.Prev)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, shared_dto.NewPaginatedResult(hits, nextCursor, prevCursor))
 }
 
 // GetByID godoc
@@ -105,10 +236,20 @@ func (ph *ProductHandler) GetPaginated(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Product ID (UUID)" format(uuid)
+// @Param X-Tenant-ID header string false "Tenant whose catalogue is used (default: the tenant of the credentials, else default)"
+// @Param If-None-Match header string false "ETag of the product version the client has; answered with 304 if it is current"
+// @Param If-Modified-Since header string false "Last-Modified of the product the client has; answered with 304 if it did not change since"
 // @Success 200 {object} dto.ProductResponse
+// @Header 200 {string} ETag "Current product version"
+// @Header 200 {string} Last-Modified "When the product was created or last changed"
+// @Success 304 "Not Modified"
 // @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
 // @Failure 404 {object} shared_dto.ErrorResponse
 // @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Router /products/{id} [get]
 func (ph *ProductHandler) GetByID(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -126,9 +267,69 @@ func (ph *ProductHandler) GetByID(c *gin.Context) {
 		return
 	}
 	productResponse := dto.NewProductResponseFromDomainModel(product)
+	ph.setProductReadCaching(c, ph.cacheControl.Item)
+	if shared_handlers.NotModified(c, shared_handlers.VersionETag(product.Version()), product.UpdatedAt()) {
+		return
+	}
 	c.JSON(http.StatusOK, productResponse)
 }
 
+// setProductReadCaching sets the caching headers of a product read that
+// succeeded.
+func (ph *ProductHandler) setProductReadCaching(c *gin.Context, cacheControl string) {
+	if cacheControl != "" {
+		c.Header("Cache-Control", cacheControl)
+	}
+	c.Header("Vary", productReadVary)
+}
+
+// GetHistory godoc
+// @Summary Get product change history
+// @Description Get the audit trail of a product, newest change first, with who made each change and which fields it touched
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID (UUID)" format(uuid)
+// @Param cursor query string false "Opaque cursor, as returned in next_cursor"
+// @Param limit query int false "Limit of entries per page (1-100)" minimum(1) maximum(100)
+// @Param X-Tenant-ID header string false "Tenant whose catalogue is used (default: the tenant of the credentials, else default)"
+// @Success 200 {object} shared_dto.PaginatedResult[dto.ProductHistoryEntryResponse]
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /products/{id}/history [get]
+func (ph *ProductHandler) GetHistory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(shared_handlers.ErrInvalidUUID)
+		return
+	}
+	query, err := ph.parseProductHistoryQuery(c, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	page, err := ph.getHistoryUseCase.Execute(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	entries := make([]dto.ProductHistoryEntryResponse, 0, len(page.Items))
+	for _, entry := range page.Items {
+		entries = append(entries, dto.NewProductHistoryEntryResponseFromDomainModel(entry))
+	}
+	nextCursor, err := ph.encodeProductHistoryCursor(id, page.Next)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, shared_dto.NewPaginatedResult(entries, nextCursor, nil))
+}
+
 // Create godoc
 // @Summary Create a new product
 // @Description Create a new product with the provided details
@@ -136,14 +337,23 @@ func (ph *ProductHandler) GetByID(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param product body dto.CreateProductRequest true "Product creation details"
+// @Param Idempotency-Key header string false "Unique key that makes retrying the request safe: a repeat gets the original response replayed"
+// @Param X-Tenant-ID header string false "Tenant whose catalogue is used (default: the tenant of the credentials, else default)"
 // @Success 201 {object} dto.ProductResponse
+// @Header 201 {string} ETag "Current product version"
 // @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 409 {object} shared_dto.ErrorResponse
+// @Failure 422 {object} shared_dto.ErrorResponse
 // @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Router /products [post]
 func (ph *ProductHandler) Create(c *gin.Context) {
 	var productDto dto.CreateProductRequest
 	if err := c.ShouldBindJSON(&productDto); err != nil {
-		c.Error(shared_handlers.ErrInvalidPayload)
+		c.Error(shared_handlers.BindingError(err))
 		return
 	}
 	product, err := productDto.ToDomainModel()
@@ -156,14 +366,9 @@ func (ph *ProductHandler) Create(c *gin.Context) {
 		c.Error(err)
 		return
 	}
-	productResponse := dto.ProductResponse{
-		ID:       product.ID(),
-		Sku:      product.Sku(),
-		Name:     product.Name(),
-		Category: product.Category(),
-		Price:    product.Price(),
-	}
+	productResponse := dto.NewProductResponseFromDomainModel(product)
 
+	c.Header("ETag", shared_handlers.VersionETag(product.Version()))
 	c.JSON(http.StatusCreated, productResponse)
 }
 
@@ -174,11 +379,19 @@ func (ph *ProductHandler) Create(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Product ID (UUID)" format(uuid)
+// @Param If-Match header string false "ETag of the product version the change is based on"
 // @Param product body dto.CreateProductRequest true "Product update details"
+// @Param X-Tenant-ID header string false "Tenant whose catalogue is used (default: the tenant of the credentials, else default)"
 // @Success 204
 // @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
 // @Failure 404 {object} shared_dto.ErrorResponse
+// @Failure 409 {object} shared_dto.ErrorResponse
+// @Failure 412 {object} shared_dto.ErrorResponse
 // @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Router /products/{id} [put]
 func (ph *ProductHandler) Update(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -187,9 +400,15 @@ func (ph *ProductHandler) Update(c *gin.Context) {
 		return
 	}
 
+	expectedVersion, err := shared_handlers.ParseIfMatchVersion(c.GetHeader("If-Match"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	var productDto dto.CreateProductRequest
 	if err := c.ShouldBindJSON(&productDto); err != nil {
-		c.Error(shared_handlers.ErrInvalidPayload)
+		c.Error(shared_handlers.BindingError(err))
 		return
 	}
 	product, err := productDto.ToDomainModel()
@@ -198,7 +417,7 @@ func (ph *ProductHandler) Update(c *gin.Context) {
 		return
 	}
 
-	if err := ph.updateProductUseCase.Execute(c.Request.Context(), id, product); err != nil {
+	if err := ph.updateProductUseCase.Execute(c.Request.Context(), id, product, expectedVersion); err != nil {
 		c.Error(err)
 		return
 	}
@@ -208,16 +427,26 @@ func (ph *ProductHandler) Update(c *gin.Context) {
 
 // Patch godoc
 // @Summary Partially update a product
-// @Description Update specific fields of a product by ID
+// @Description Update specific fields of a product by ID. Send application/json to set the fields present in the body, application/merge-patch+json for an RFC 7396 merge patch or application/json-patch+json for an RFC 6902 list of operations. Patch documents apply to the product as GET returns it
 // @Tags products
-// @Accept json
+// @Accept json,json-patch+json,merge-patch+json
 // @Produce json
 // @Param id path string true "Product ID (UUID)" format(uuid)
+// @Param If-Match header string false "ETag of the product version the change is based on"
 // @Param product body dto.PatchProductRequest true "Product patch details"
+// @Param X-Tenant-ID header string false "Tenant whose catalogue is used (default: the tenant of the credentials, else default)"
 // @Success 204
 // @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
 // @Failure 404 {object} shared_dto.ErrorResponse
+// @Failure 409 {object} shared_dto.ErrorResponse
+// @Failure 412 {object} shared_dto.ErrorResponse
+// @Failure 415 {object} shared_dto.ErrorResponse
+// @Failure 422 {object} shared_dto.ErrorResponse
 // @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Router /products/{id} [patch]
 func (ph *ProductHandler) Patch(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -226,27 +455,27 @@ func (ph *ProductHandler) Patch(c *gin.Context) {
 		return
 	}
 
-	var patch dto.PatchProductRequest
-	if err := c.ShouldBindJSON(&patch); err != nil {
-		c.Error(shared_handlers.ErrInvalidPayload)
+	expectedVersion, err := shared_handlers.ParseIfMatchVersion(c.GetHeader("If-Match"))
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	updates := make(map[string]interface{})
-	if patch.Sku != nil {
-		updates["sku"] = *patch.Sku
+	var patch models.ProductPatch
+	switch c.ContentType() {
+	case "", "application/json":
+		patch, err = bindProductPatchRequest(c)
+	case mergePatchContentType, jsonPatchContentType:
+		patch, expectedVersion, err = ph.applyProductPatchDocument(c, id, expectedVersion)
+	default:
+		err = shared_handlers.ErrUnsupportedPatchType
 	}
-	if patch.Name != nil {
-		updates["name"] = *patch.Name
-	}
-	if patch.Category != nil {
-		updates["category"] = *patch.Category
-	}
-	if patch.Price != nil {
-		updates["price"] = decimal.NewFromFloat(*patch.Price)
+	if err != nil {
+		c.Error(err)
+		return
 	}
 
-	if err := ph.patchProductUseCase.Execute(c.Request.Context(), id, updates); err != nil {
+	if err := ph.patchProductUseCase.Execute(c.Request.Context(), id, patch, expectedVersion); err != nil {
 		c.Error(err)
 		return
 	}
@@ -256,15 +485,22 @@ func (ph *ProductHandler) Patch(c *gin.Context) {
 
 // Delete godoc
 // @Summary Delete a product
-// @Description Delete a product by ID
+// @Description Move a product to the trash by ID. It can be restored or purged from there
 // @Tags products
 // @Accept json
 // @Produce json
 // @Param id path string true "Product ID (UUID)" format(uuid)
+// @Param If-Match header string false "ETag of the product version the change is based on"
+// @Param X-Tenant-ID header string false "Tenant whose catalogue is used (default: the tenant of the credentials, else default)"
 // @Success 204
 // @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
 // @Failure 404 {object} shared_dto.ErrorResponse
+// @Failure 412 {object} shared_dto.ErrorResponse
 // @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
 // @Router /products/{id} [delete]
 func (ph *ProductHandler) Delete(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -273,7 +509,78 @@ func (ph *ProductHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := ph.deleteProductUseCase.Execute(c.Request.Context(), id); err != nil {
+	expectedVersion, err := shared_handlers.ParseIfMatchVersion(c.GetHeader("If-Match"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := ph.deleteProductUseCase.Execute(c.Request.Context(), id, expectedVersion); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Restore godoc
+// @Summary Restore a trashed product
+// @Description Move a soft-deleted product back to the live catalogue
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID (UUID)" format(uuid)
+// @Param X-Tenant-ID header string false "Tenant whose catalogue is used (default: the tenant of the credentials, else default)"
+// @Success 204
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 404 {object} shared_dto.ErrorResponse
+// @Failure 409 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /products/trash/{id}/restore [post]
+func (ph *ProductHandler) Restore(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(shared_handlers.ErrInvalidUUID)
+		return
+	}
+
+	if err := ph.restoreProductUseCase.Execute(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Purge godoc
+// @Summary Purge a trashed product
+// @Description Permanently delete a product that is in the trash
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID (UUID)" format(uuid)
+// @Param X-Tenant-ID header string false "Tenant whose catalogue is used (default: the tenant of the credentials, else default)"
+// @Success 204
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 404 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /products/trash/{id} [delete]
+func (ph *ProductHandler) Purge(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(shared_handlers.ErrInvalidUUID)
+		return
+	}
+
+	if err := ph.purgeProductUseCase.Execute(c.Request.Context(), id); err != nil {
 		c.Error(err)
 		return
 	}
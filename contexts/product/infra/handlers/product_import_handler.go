@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/dto"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportFileSize bounds the upload, whatever the number of rows.
+const maxImportFileSize = 10 << 20
+
+const (
+	importReportFormatJSON = "json"
+	importReportFormatCSV  = "csv"
+)
+
+const importReportFilename = "product-import-report.csv"
+
+// Import godoc
+// @Summary Import products from CSV
+// @Description Create products from a CSV file whose header names the sku, name, category and price columns. Lines that are invalid, repeat an earlier SKU of the file or use the SKU of an existing product are rejected; the others are created. The report lists every line with its number and, for rejected lines, the error code. With dry_run nothing is written
+// @Tags products
+// @Accept multipart/form-data
+// @Produce json
+// @Produce text/csv
+// @Param file formData file true "CSV file, up to 10 MB and IMPORT_MAX_ROWS rows"
+// @Param dry_run query bool false "Validate the file without creating any product"
+// @Param format query string false "Report format" Enums(json, csv) default(json)
+// @Param Idempotency-Key header string false "Unique key that makes retrying the request safe: a repeat gets the original response replayed"
+// @Param X-Tenant-ID header string false "Tenant whose catalogue is used (default: the tenant of the credentials, else default)"
+// @Success 200 {object} dto.ProductImportReportResponse
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 409 {object} shared_dto.ErrorResponse
+// @Failure 422 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /products/import [post]
+func (ph *ProductHandler) Import(c *gin.Context) {
+	dryRun, format, err := parseProductImportOptions(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.Error(shared_handlers.ErrImportFileTooLarge)
+			return
+		}
+		c.Error(shared_handlers.ErrInvalidImportFile)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Error(shared_handlers.ErrInvalidImportFile)
+		return
+	}
+	defer file.Close()
+
+	rows, err := dto.ParseProductImportCSV(file, ph.importMaxRows)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	report, err := ph.importUseCase.Execute(c.Request.Context(), rows, dryRun)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if format == importReportFormatCSV {
+		c.Header("Content-Disposition", `attachment; filename="`+importReportFilename+`"`)
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Status(http.StatusOK)
+		if err := dto.WriteProductImportReportCSV(c.Writer, report); err != nil {
+			c.Error(err)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewProductImportReportResponse(report))
+}
+
+func parseProductImportOptions(c *gin.Context) (bool, string, error) {
+	dryRun := false
+	if value := c.Query("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return false, "", shared_handlers.ErrInvalidImportOptions
+		}
+		dryRun = parsed
+	}
+	format := c.DefaultQuery("format", importReportFormatJSON)
+	if format != importReportFormatJSON && format != importReportFormatCSV {
+		return false, "", shared_handlers.ErrInvalidImportOptions
+	}
+	return dryRun, format, nil
+}
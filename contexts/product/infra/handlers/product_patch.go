@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/dto"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/jsonpatch"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+)
+
+// bindProductPatchRequest reads a plain JSON body where every field present
+// is set on the product.
+func bindProductPatchRequest(c *gin.Context) (models.ProductPatch, error) {
+	var patch dto.PatchProductRequest
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		return models.ProductPatch{}, shared_handlers.BindingError(err)
+	}
+	return patch.ToDomainPatch(), nil
+}
+
+// applyProductPatchDocument applies a JSON Patch or Merge Patch body to the
+// stored product. The result is computed from the version that was read, so
+// that version is the one expected when saving unless If-Match asked for
+// another, in which case the patch is refused straight away. The product is
+// read past any cache, which may hold a version older than the stored one.
+func (ph *ProductHandler) applyProductPatchDocument(c *gin.Context, id uuid.UUID, expectedVersion *int) (models.ProductPatch, *int, error) {
+	patch, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return models.ProductPatch{}, nil, shared_handlers.ErrInvalidPayload
+	}
+
+	product, err := ph.getOneProductUseCase.Execute(request_context.WithUncachedReads(c.Request.Context()), id)
+	if err != nil {
+		return models.ProductPatch{}, nil, err
+	}
+	if product == nil {
+		return models.ProductPatch{}, nil, shared_handlers.ErrNotFound
+	}
+	if expectedVersion != nil && *expectedVersion != product.Version() {
+		return models.ProductPatch{}, nil, shared_handlers.ErrPreconditionFailed
+	}
+
+	changes, err := patchProduct(product, c.ContentType(), patch)
+	if err != nil {
+		return models.ProductPatch{}, nil, err
+	}
+	readVersion := product.Version()
+	return changes, &readVersion, nil
+}
+
+// patchProduct applies patch to the product as GET returns it and returns
+// the fields that changed. Read-only fields must come out as they went in.
+func patchProduct(product models.Product, contentType string, patch []byte) (models.ProductPatch, error) {
+	current := dto.NewProductResponseFromDomainModel(product)
+	document, err := json.Marshal(current)
+	if err != nil {
+		return models.ProductPatch{}, err
+	}
+
+	apply := jsonpatch.Apply
+	if contentType == mergePatchContentType {
+		apply = jsonpatch.MergePatch
+	}
+	patched, err := apply(document, patch)
+	if errors.Is(err, jsonpatch.ErrMalformedPatch) {
+		return models.ProductPatch{}, shared_handlers.ErrInvalidPayload
+	}
+	if err != nil {
+		return models.ProductPatch{}, shared_handlers.ErrInvalidPatch
+	}
+
+	var result dto.ProductResponse
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&result); err != nil {
+		return models.ProductPatch{}, shared_handlers.ErrInvalidPatch
+	}
+	if result.ID != current.ID || result.Version != current.Version || !sameTime(result.DeletedAt, current.DeletedAt) {
+		return models.ProductPatch{}, shared_handlers.ErrPatchReadOnlyField
+	}
+
+	var changes models.ProductPatch
+	if result.Sku != current.Sku {
+		changes.Sku = &result.Sku
+	}
+	if result.Name != current.Name {
+		changes.Name = &result.Name
+	}
+	if result.Category != current.Category {
+		changes.Category = &result.Category
+	}
+	if !result.Price.Equal(current.Price) {
+		changes.Price = &result.Price
+	}
+	return changes, nil
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"maps"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/dto"
+	"github.com/Akiles94/go-test-api/contexts/product/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var productFilterParams = []string{"category", "name", "min_price", "max_price"}
+
+const maxSearchTextLength = 256
+
+// parseProductQuery reads filters, sort, cursor and limit from the query
+// string. A cursor carries the filters and sort it was issued for; explicit
+// parameters sent along with it must match them, and a cursor issued for the
+// trash cannot be used on the live listing or the other way round.
+func (ph *ProductHandler) parseProductQuery(c *gin.Context, trashed bool) (models.ProductQuery, error) {
+	sort, err := dto.ParseProductSort(c.Query("sort"))
+	if err != nil {
+		return models.ProductQuery{}, shared_handlers.ErrInvalidSort
+	}
+
+	rawFilter, filter, err := parseProductFilter(c)
+	if err != nil {
+		return models.ProductQuery{}, err
+	}
+	filter.Trashed = trashed
+
+	query := models.ProductQuery{Filter: filter, Sort: sort}
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		cursor, err := ph.cursorCodec.Decode(cursorStr)
+		if err != nil {
+			return models.ProductQuery{}, shared_handlers.ErrInvalidCursor
+		}
+		cursorQuery, err := dto.ProductQueryFromPaginationCursor(cursor)
+		if err != nil {
+			return models.ProductQuery{}, shared_handlers.ErrInvalidCursor
+		}
+		if cursorQuery.Filter.Trashed != trashed {
+			return models.ProductQuery{}, shared_handlers.ErrInvalidCursor
+		}
+		if c.Query("sort") != "" && sort != cursorQuery.Sort {
+			return models.ProductQuery{}, shared_handlers.ErrInvalidCursor
+		}
+		if len(rawFilter) > 0 && !maps.Equal(dto.ProductFilterToMap(filter), dto.ProductFilterToMap(cursorQuery.Filter)) {
+			return models.ProductQuery{}, shared_handlers.ErrInvalidCursor
+		}
+		query = cursorQuery
+	}
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		return models.ProductQuery{}, err
+	}
+	query.Limit = limit
+
+	return query, nil
+}
+
+// parseProductSearchQuery reads the search text, filters, cursor and limit
+// from the query string. As with listings, a cursor carries the text and
+// filters it was issued for and explicit parameters must match them.
+func (ph *ProductHandler) parseProductSearchQuery(c *gin.Context) (models.ProductSearchQuery, error) {
+	text := strings.TrimSpace(c.Query("q"))
+	if utf8.RuneCountInString(text) > maxSearchTextLength {
+		return models.ProductSearchQuery{}, shared_handlers.ErrInvalidSearchQuery
+	}
+
+	rawFilter, filter, err := parseProductFilter(c)
+	if err != nil {
+		return models.ProductSearchQuery{}, err
+	}
+
+	query := models.ProductSearchQuery{Text: text, Filter: filter}
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		cursor, err := ph.cursorCodec.Decode(cursorStr)
+		if err != nil {
+			return models.ProductSearchQuery{}, shared_handlers.ErrInvalidCursor
+		}
+		cursorQuery, err := dto.ProductSearchQueryFromPaginationCursor(cursor)
+		if err != nil {
+			return models.ProductSearchQuery{}, shared_handlers.ErrInvalidCursor
+		}
+		if text != "" && text != cursorQuery.Text {
+			return models.ProductSearchQuery{}, shared_handlers.ErrInvalidCursor
+		}
+		if len(rawFilter) > 0 && !maps.Equal(dto.ProductFilterToMap(filter), dto.ProductFilterToMap(cursorQuery.Filter)) {
+			return models.ProductSearchQuery{}, shared_handlers.ErrInvalidCursor
+		}
+		query = cursorQuery
+	}
+	if query.Text == "" {
+		return models.ProductSearchQuery{}, shared_handlers.ErrInvalidSearchQuery
+	}
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		return models.ProductSearchQuery{}, err
+	}
+	query.Limit = limit
+
+	return query, nil
+}
+
+// parseProductExportQuery reads filters and sort from the query string.
+// Exports are not paged, so there is no cursor or limit.
+func parseProductExportQuery(c *gin.Context) (models.ProductExportQuery, error) {
+	sort, err := dto.ParseProductSort(c.Query("sort"))
+	if err != nil {
+		return models.ProductExportQuery{}, shared_handlers.ErrInvalidSort
+	}
+	_, filter, err := parseProductFilter(c)
+	if err != nil {
+		return models.ProductExportQuery{}, err
+	}
+	return models.ProductExportQuery{Filter: filter, Sort: sort}, nil
+}
+
+// parseProductFilter returns the filter parameters that were sent, as given,
+// and the filter they describe.
+func parseProductFilter(c *gin.Context) (map[string]string, models.ProductFilter, error) {
+	rawFilter := make(map[string]string)
+	for _, param := range productFilterParams {
+		if value := c.Query(param); value != "" {
+			rawFilter[param] = value
+		}
+	}
+	filter, err := dto.ProductFilterFromMap(rawFilter)
+	if err != nil {
+		return nil, models.ProductFilter{}, shared_handlers.ErrInvalidFilter
+	}
+	return rawFilter, filter, nil
+}
+
+// parseProductHistoryQuery reads cursor and limit for the history of the
+// product with the given id.
+func (ph *ProductHandler) parseProductHistoryQuery(c *gin.Context, productID uuid.UUID) (models.ProductAuditQuery, error) {
+	query := models.ProductAuditQuery{ProductID: productID}
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		cursor, err := ph.cursorCodec.Decode(cursorStr)
+		if err != nil {
+			return models.ProductAuditQuery{}, shared_handlers.ErrInvalidCursor
+		}
+		position, err := dto.ProductAuditCursorFromPaginationCursor(productID, cursor)
+		if err != nil {
+			return models.ProductAuditQuery{}, shared_handlers.ErrInvalidCursor
+		}
+		query.Cursor = &position
+	}
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		return models.ProductAuditQuery{}, err
+	}
+	query.Limit = limit
+
+	return query, nil
+}
+
+func parseLimit(c *gin.Context) (*int, error) {
+	limitStr := c.Query("limit")
+	if limitStr == "" {
+		return nil, nil
+	}
+	limitValue, err := strconv.Atoi(limitStr)
+	if err != nil {
+		return nil, shared_handlers.ErrInvalidLimit
+	}
+	if limitValue < bottomLimitValue || limitValue > topLimitValue {
+		return nil, shared_handlers.ErrInvalidLimit
+	}
+	return &limitValue, nil
+}
+
+// encodeProductCursor turns a keyset position into an opaque token, or nil
+// when there is no page in that direction.
+func (ph *ProductHandler) encodeProductCursor(filter models.ProductFilter, position *models.ProductCursor) (*string, error) {
+	if position == nil {
+		return nil, nil
+	}
+	token, err := ph.cursorCodec.Encode(dto.NewProductPaginationCursor(filter, *position))
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (ph *ProductHandler) encodeProductHistoryCursor(productID uuid.UUID, position *models.ProductAuditCursor) (*string, error) {
+	if position == nil {
+		return nil, nil
+	}
+	token, err := ph.cursorCodec.Encode(dto.NewProductHistoryPaginationCursor(productID, *position))
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (ph *ProductHandler) encodeProductSearchCursor(query models.ProductSearchQuery, position *models.ProductSearchCursor) (*string, error) {
+	if position == nil {
+		return nil, nil
+	}
+	token, err := ph.cursorCodec.Encode(dto.NewProductSearchPaginationCursor(query.Text, query.Filter, *position))
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
@@ -1,41 +1,106 @@
 package modules
 
 import (
+	"net/http"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/product/application/ports/outbound"
 	"github.com/Akiles94/go-test-api/contexts/product/application/use_cases"
 	"github.com/Akiles94/go-test-api/contexts/product/infra/adapters"
 	"github.com/Akiles94/go-test-api/contexts/product/infra/handlers"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/pagination"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/middlewares"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 type ProductModule struct {
 	handler *handlers.ProductHandler
+	cache   *adapters.CachedProductRepository
 }
 
-func NewProductModule(db *gorm.DB) *ProductModule {
-	repo := adapters.NewProductRepository(db)
-	createProductUseCase := use_cases.NewCreateProductUseCase(repo)
-	updateProductUseCase := use_cases.NewUpdateProductUseCase(repo)
-	patchProductUseCase := use_cases.NewPatchProductUseCase(repo)
-	deleteProductUseCase := use_cases.NewDeleteProductUseCase(repo)
+// NewProductModule caches the products read by ID in cacheStore for cacheTTL,
+// or does not cache them when cacheStore is nil. cacheControl is what clients
+// are told about caching product reads themselves.
+func NewProductModule(db *gorm.DB, txManager interfaces.TransactionManager, outbox interfaces.EventOutbox, cursorCodec *pagination.CursorCodec, batchMaxItems int, importMaxRows int, cacheStore interfaces.CacheStore, cacheTTL time.Duration, cacheControl handlers.ProductCacheControl) *ProductModule {
+	var repo outbound.ProductRepositoryPort = adapters.NewProductRepository(db)
+	var cache *adapters.CachedProductRepository
+	if cacheStore != nil {
+		cache = adapters.NewCachedProductRepository(repo, cacheStore, cacheTTL)
+		repo = cache
+	}
+	auditRepo := adapters.NewProductAuditRepository(db)
+	createProductUseCase := use_cases.NewCreateProductUseCase(repo, auditRepo, outbox, txManager)
+	updateProductUseCase := use_cases.NewUpdateProductUseCase(repo, auditRepo, outbox, txManager)
+	patchProductUseCase := use_cases.NewPatchProductUseCase(repo, auditRepo, outbox, txManager)
+	deleteProductUseCase := use_cases.NewDeleteProductUseCase(repo, auditRepo, outbox, txManager)
 	getAllProductsUseCase := use_cases.NewGetAllProductsUseCase(repo)
 	getOneProductUseCase := use_cases.NewGetOneProductUseCase(repo)
+	restoreProductUseCase := use_cases.NewRestoreProductUseCase(repo, auditRepo, outbox, txManager)
+	purgeProductUseCase := use_cases.NewPurgeProductUseCase(repo, auditRepo, outbox, txManager)
+	getProductHistoryUseCase := use_cases.NewGetProductHistoryUseCase(auditRepo)
+	searchProductsUseCase := use_cases.NewSearchProductsUseCase(repo)
+	batchCreateProductsUseCase := use_cases.NewBatchCreateProductsUseCase(createProductUseCase, txManager)
+	batchUpdateProductsUseCase := use_cases.NewBatchUpdateProductsUseCase(updateProductUseCase, txManager)
+	batchDeleteProductsUseCase := use_cases.NewBatchDeleteProductsUseCase(deleteProductUseCase, txManager)
+	importProductsUseCase := use_cases.NewImportProductsUseCase(repo, createProductUseCase)
+	exportProductsUseCase := use_cases.NewExportProductsUseCase(repo)
 	handler := handlers.NewProductHandler(
 		createProductUseCase,
 		updateProductUseCase,
 		patchProductUseCase,
 		deleteProductUseCase,
 		getAllProductsUseCase,
-		getOneProductUseCase)
+		getOneProductUseCase,
+		restoreProductUseCase,
+		purgeProductUseCase,
+		getProductHistoryUseCase,
+		searchProductsUseCase,
+		batchCreateProductsUseCase,
+		batchUpdateProductsUseCase,
+		batchDeleteProductsUseCase,
+		importProductsUseCase,
+		exportProductsUseCase,
+		batchMaxItems,
+		importMaxRows,
+		cursorCodec,
+		cacheControl)
+
+	return &ProductModule{handler: handler, cache: cache}
+}
 
-	return &ProductModule{handler: handler}
+// CacheStats returns the statistics of the product cache, and false when
+// products are not cached.
+func (pm *ProductModule) CacheStats() (adapters.ProductCacheStats, bool) {
+	if pm.cache == nil {
+		return adapters.ProductCacheStats{}, false
+	}
+	return pm.cache.Stats(), true
 }
 
 func (pm *ProductModule) RegisterRoutes(router *gin.RouterGroup) {
-	router.GET("", pm.handler.GetPaginated)
-	router.GET("/:id", pm.handler.GetByID)
-	router.POST("", pm.handler.Create)
-	router.PUT("/:id", pm.handler.Update)
-	router.PATCH("/:id", pm.handler.Patch)
-	router.DELETE("/:id", pm.handler.Delete)
+	read := shared_models.PermissionProductsRead
+	write := shared_models.PermissionProductsWrite
+	remove := shared_models.PermissionProductsDelete
+
+	middlewares.RegisterRoutes(router, []middlewares.Route{
+		{Method: http.MethodGet, Path: "", Permission: read, Handler: pm.handler.GetPaginated},
+		{Method: http.MethodGet, Path: "/search", Permission: read, Handler: pm.handler.Search},
+		{Method: http.MethodGet, Path: "/trash", Permission: read, Handler: pm.handler.GetTrashPaginated},
+		{Method: http.MethodPost, Path: "/import", Permission: write, Handler: pm.handler.Import},
+		{Method: http.MethodGet, Path: "/export", Permission: read, Handler: pm.handler.Export},
+		{Method: http.MethodPost, Path: "/batch/create", Permission: write, Handler: pm.handler.BatchCreate},
+		{Method: http.MethodPost, Path: "/batch/update", Permission: write, Handler: pm.handler.BatchUpdate},
+		{Method: http.MethodPost, Path: "/batch/delete", Permission: remove, Handler: pm.handler.BatchDelete},
+		{Method: http.MethodPost, Path: "/trash/:id/restore", Permission: write, Handler: pm.handler.Restore},
+		{Method: http.MethodDelete, Path: "/trash/:id", Permission: remove, Handler: pm.handler.Purge},
+		{Method: http.MethodGet, Path: "/:id", Permission: read, Handler: pm.handler.GetByID},
+		{Method: http.MethodGet, Path: "/:id/history", Permission: read, Handler: pm.handler.GetHistory},
+		{Method: http.MethodPost, Path: "", Permission: write, Handler: pm.handler.Create},
+		{Method: http.MethodPut, Path: "/:id", Permission: write, Handler: pm.handler.Update},
+		{Method: http.MethodPatch, Path: "/:id", Permission: write, Handler: pm.handler.Patch},
+		{Method: http.MethodDelete, Path: "/:id", Permission: remove, Handler: pm.handler.Delete},
+	})
 }
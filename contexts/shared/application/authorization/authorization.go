@@ -0,0 +1,25 @@
+package authorization
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+)
+
+// Authorize checks that the caller of ctx holds permission. It fails with
+// ErrUnauthenticated when nobody was identified and ErrForbidden when the
+// caller lacks the permission.
+func Authorize(ctx context.Context, permission models.Permission) error {
+	principal, ok := request_context.PrincipalFrom(ctx)
+	if !ok {
+		return shared_handlers.ErrUnauthenticated
+	}
+	if !principal.HasPermission(permission) {
+		err := shared_handlers.ErrForbidden
+		err.Details = map[string]interface{}{"required_permission": permission}
+		return err
+	}
+	return nil
+}
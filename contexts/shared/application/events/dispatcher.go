@@ -0,0 +1,67 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message is a published domain event as handlers receive it. Payload is the
+// JSON encoding of the event, and TenantID the tenant whose data raised it.
+type Message struct {
+	ID          uuid.UUID
+	TenantID    string
+	EventName   string
+	AggregateID uuid.UUID
+	Payload     []byte
+	OccurredAt  time.Time
+}
+
+// Handler reacts to a message. Delivery is at-least-once, so handlers must
+// tolerate seeing the same message ID more than once.
+type Handler func(ctx context.Context, message Message) error
+
+// Dispatcher routes messages to the in-process handlers subscribed to their
+// event name, and to the handlers subscribed to every event.
+type Dispatcher struct {
+	mu          sync.RWMutex
+	handlers    map[string][]Handler
+	allHandlers []Handler
+}
+
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{handlers: make(map[string][]Handler)}
+}
+
+func (d *Dispatcher) Subscribe(eventName string, handler Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[eventName] = append(d.handlers[eventName], handler)
+}
+
+// SubscribeAll registers a handler for every event, whatever its name.
+func (d *Dispatcher) SubscribeAll(handler Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.allHandlers = append(d.allHandlers, handler)
+}
+
+// Dispatch runs every handler subscribed to the message's event, even when
+// some of them fail, and returns the failures joined together.
+func (d *Dispatcher) Dispatch(ctx context.Context, message Message) error {
+	d.mu.RLock()
+	handlers := append(slices.Clone(d.handlers[message.EventName]), d.allHandlers...)
+	d.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, message); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,86 @@
+package events_tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatcher(t *testing.T) {
+	t.Run("should deliver messages only to handlers of their event", func(t *testing.T) {
+		// Arrange
+		dispatcher := events.NewDispatcher()
+		var received []string
+		dispatcher.Subscribe("product.created", func(ctx context.Context, message events.Message) error {
+			received = append(received, "created")
+			return nil
+		})
+		dispatcher.Subscribe("product.deleted", func(ctx context.Context, message events.Message) error {
+			received = append(received, "deleted")
+			return nil
+		})
+
+		// Act
+		err := dispatcher.Dispatch(context.Background(), events.Message{EventName: "product.created"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"created"}, received)
+	})
+
+	t.Run("should run every handler and report failures", func(t *testing.T) {
+		// Arrange
+		dispatcher := events.NewDispatcher()
+		handlerErr := errors.New("handler failed")
+		calls := 0
+		dispatcher.Subscribe("product.created", func(ctx context.Context, message events.Message) error {
+			calls++
+			return handlerErr
+		})
+		dispatcher.Subscribe("product.created", func(ctx context.Context, message events.Message) error {
+			calls++
+			return nil
+		})
+
+		// Act
+		err := dispatcher.Dispatch(context.Background(), events.Message{EventName: "product.created"})
+
+		// Assert
+		assert.ErrorIs(t, err, handlerErr)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("should deliver every message to handlers subscribed to all events", func(t *testing.T) {
+		// Arrange
+		dispatcher := events.NewDispatcher()
+		var received []string
+		dispatcher.Subscribe("product.created", func(ctx context.Context, message events.Message) error {
+			received = append(received, "created")
+			return nil
+		})
+		dispatcher.SubscribeAll(func(ctx context.Context, message events.Message) error {
+			received = append(received, "all:"+message.EventName)
+			return nil
+		})
+
+		// Act
+		errCreated := dispatcher.Dispatch(context.Background(), events.Message{EventName: "product.created"})
+		errDeleted := dispatcher.Dispatch(context.Background(), events.Message{EventName: "product.deleted"})
+
+		// Assert
+		assert.NoError(t, errCreated)
+		assert.NoError(t, errDeleted)
+		assert.Equal(t, []string{"created", "all:product.created", "all:product.deleted"}, received)
+	})
+
+	t.Run("should accept messages nobody subscribed to", func(t *testing.T) {
+		// Act
+		err := events.NewDispatcher().Dispatch(context.Background(), events.Message{EventName: "unknown"})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+}
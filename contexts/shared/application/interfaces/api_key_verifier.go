@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+)
+
+// APIKeyVerifier checks an API key and returns the client it was issued to.
+type APIKeyVerifier interface {
+	Verify(ctx context.Context, key string) (request_context.Principal, error)
+}
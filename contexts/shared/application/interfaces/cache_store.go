@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// CacheStore keeps values by key for a while. Values are opaque bytes so that
+// a store shared by several instances, e.g. Redis, can hold them as well.
+type CacheStore interface {
+	// Get returns the value stored for key, and false when there is none or
+	// it has expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value for key until ttl has passed.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete forgets keys. Keys that are not stored are ignored.
+	Delete(ctx context.Context, keys ...string) error
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+)
+
+// EventOutbox stores domain events next to the change that raised them. Call
+// it inside the same transaction so events are kept only if the change is.
+type EventOutbox interface {
+	Save(ctx context.Context, events []shared_models.DomainEvent) error
+}
@@ -0,0 +1,37 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotentResponse is the response given to the first request made with an
+// idempotency key, replayed to the requests that repeat it.
+type IdempotentResponse struct {
+	StatusCode int
+	Header     map[string][]string
+	Body       []byte
+}
+
+// IdempotencyRecord is what is stored for an idempotency key. Response is nil
+// while the first request is still being processed.
+type IdempotencyRecord struct {
+	Fingerprint string
+	Response    *IdempotentResponse
+}
+
+// IdempotencyStore keeps idempotency keys until they expire. A key belongs to
+// the tenant and actor of ctx: the same key sent by another caller, or in
+// another tenant, is another key.
+type IdempotencyStore interface {
+	// Reserve claims key for a request with the given fingerprint until
+	// expiresAt, and holds it for that request until lockedUntil. It returns
+	// nil when the key was free or had expired, or when the request holding
+	// it neither completed nor released it in time, and the record stored for
+	// it otherwise.
+	Reserve(ctx context.Context, key string, fingerprint string, lockedUntil time.Time, expiresAt time.Time) (*IdempotencyRecord, error)
+	// Complete stores the response of the request that reserved key.
+	Complete(ctx context.Context, key string, response IdempotentResponse) error
+	// Release frees key so that the request can be retried from scratch.
+	Release(ctx context.Context, key string) error
+}
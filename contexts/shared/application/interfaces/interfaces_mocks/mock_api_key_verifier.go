@@ -0,0 +1,29 @@
+package interfaces_mocks
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAPIKeyVerifier struct {
+	mock.Mock
+}
+
+func NewMockAPIKeyVerifier() *MockAPIKeyVerifier {
+	return &MockAPIKeyVerifier{}
+}
+
+func (m *MockAPIKeyVerifier) Verify(ctx context.Context, key string) (request_context.Principal, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(request_context.Principal), args.Error(1)
+}
+
+func (m *MockAPIKeyVerifier) SetupVerifySuccess(key string, principal request_context.Principal) *mock.Call {
+	return m.On("Verify", mock.Anything, key).Return(principal, nil)
+}
+
+func (m *MockAPIKeyVerifier) SetupVerifyError(key string, err error) *mock.Call {
+	return m.On("Verify", mock.Anything, key).Return(request_context.Principal{}, err)
+}
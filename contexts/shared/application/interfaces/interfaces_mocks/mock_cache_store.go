@@ -0,0 +1,32 @@
+package interfaces_mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCacheStore struct {
+	mock.Mock
+}
+
+func NewMockCacheStore() *MockCacheStore {
+	return &MockCacheStore{}
+}
+
+func (m *MockCacheStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	args := m.Called(ctx, key)
+	value, _ := args.Get(0).([]byte)
+	return value, args.Bool(1), args.Error(2)
+}
+
+func (m *MockCacheStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := m.Called(ctx, key, value, ttl)
+	return args.Error(0)
+}
+
+func (m *MockCacheStore) Delete(ctx context.Context, keys ...string) error {
+	args := m.Called(ctx, keys)
+	return args.Error(0)
+}
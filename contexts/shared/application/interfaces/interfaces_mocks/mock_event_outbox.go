@@ -0,0 +1,41 @@
+package interfaces_mocks
+
+import (
+	"context"
+
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEventOutbox struct {
+	mock.Mock
+}
+
+func NewMockEventOutbox() *MockEventOutbox {
+	return &MockEventOutbox{}
+}
+
+func (m *MockEventOutbox) Save(ctx context.Context, events []shared_models.DomainEvent) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func (m *MockEventOutbox) SetupSaveSuccess() *mock.Call {
+	return m.On("Save", mock.Anything, mock.Anything).Return(nil)
+}
+
+// SetupSaveEventNames expects the events to be saved with exactly these names,
+// in order.
+func (m *MockEventOutbox) SetupSaveEventNames(names ...string) *mock.Call {
+	return m.On("Save", mock.Anything, mock.MatchedBy(func(events []shared_models.DomainEvent) bool {
+		if len(events) != len(names) {
+			return false
+		}
+		for i, event := range events {
+			if event.EventName() != names[i] {
+				return false
+			}
+		}
+		return true
+	})).Return(nil)
+}
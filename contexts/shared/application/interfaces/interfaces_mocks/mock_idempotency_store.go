@@ -0,0 +1,43 @@
+package interfaces_mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIdempotencyStore struct {
+	mock.Mock
+}
+
+func NewMockIdempotencyStore() *MockIdempotencyStore {
+	return &MockIdempotencyStore{}
+}
+
+func (m *MockIdempotencyStore) Reserve(ctx context.Context, key string, fingerprint string, lockedUntil time.Time, expiresAt time.Time) (*interfaces.IdempotencyRecord, error) {
+	args := m.Called(ctx, key, fingerprint, lockedUntil, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.IdempotencyRecord), args.Error(1)
+}
+
+func (m *MockIdempotencyStore) Complete(ctx context.Context, key string, response interfaces.IdempotentResponse) error {
+	args := m.Called(ctx, key, response)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyStore) Release(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyStore) SetupReserveFree(key string) *mock.Call {
+	return m.On("Reserve", mock.Anything, key, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+}
+
+func (m *MockIdempotencyStore) SetupReserveTaken(key string, record interfaces.IdempotencyRecord) *mock.Call {
+	return m.On("Reserve", mock.Anything, key, mock.Anything, mock.Anything, mock.Anything).Return(&record, nil)
+}
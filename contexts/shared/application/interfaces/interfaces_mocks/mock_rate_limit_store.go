@@ -0,0 +1,21 @@
+package interfaces_mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRateLimitStore struct {
+	mock.Mock
+}
+
+func NewMockRateLimitStore() *MockRateLimitStore {
+	return &MockRateLimitStore{}
+}
+
+func (m *MockRateLimitStore) Increment(ctx context.Context, key string, window time.Duration) (int, time.Time, error) {
+	args := m.Called(ctx, key, window)
+	return args.Int(0), args.Get(1).(time.Time), args.Error(2)
+}
@@ -0,0 +1,27 @@
+package interfaces_mocks
+
+import (
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTokenVerifier struct {
+	mock.Mock
+}
+
+func NewMockTokenVerifier() *MockTokenVerifier {
+	return &MockTokenVerifier{}
+}
+
+func (m *MockTokenVerifier) Verify(token string) (request_context.Principal, error) {
+	args := m.Called(token)
+	return args.Get(0).(request_context.Principal), args.Error(1)
+}
+
+func (m *MockTokenVerifier) SetupVerifySuccess(token string, principal request_context.Principal) *mock.Call {
+	return m.On("Verify", token).Return(principal, nil)
+}
+
+func (m *MockTokenVerifier) SetupVerifyError(token string, err error) *mock.Call {
+	return m.On("Verify", token).Return(request_context.Principal{}, err)
+}
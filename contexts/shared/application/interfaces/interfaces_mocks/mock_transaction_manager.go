@@ -0,0 +1,24 @@
+package interfaces_mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTransactionManager runs the unit of work directly, without a
+// transaction, and records the call.
+type MockTransactionManager struct {
+	mock.Mock
+}
+
+func NewMockTransactionManager() *MockTransactionManager {
+	m := &MockTransactionManager{}
+	m.On("WithinTransaction", mock.Anything, mock.Anything).Maybe()
+	return m
+}
+
+func (m *MockTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.Called(ctx, fn)
+	return fn(ctx)
+}
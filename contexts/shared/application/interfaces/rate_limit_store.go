@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimitStore counts requests per key in fixed windows of time.
+type RateLimitStore interface {
+	// Increment counts a request for key in the current window of length
+	// window, and returns how many requests the window has counted so far,
+	// this one included, and when it ends. Windows are aligned on multiples
+	// of window, so that every instance sharing a store agrees on them.
+	Increment(ctx context.Context, key string, window time.Duration) (int, time.Time, error)
+}
@@ -0,0 +1,8 @@
+package interfaces
+
+import "github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+
+// TokenVerifier checks a bearer token and returns the caller it was issued to.
+type TokenVerifier interface {
+	Verify(token string) (request_context.Principal, error)
+}
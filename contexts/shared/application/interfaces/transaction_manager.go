@@ -0,0 +1,9 @@
+package interfaces
+
+import "context"
+
+// TransactionManager runs a unit of work atomically. Repositories called with
+// the context handed to fn take part in the same transaction.
+type TransactionManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
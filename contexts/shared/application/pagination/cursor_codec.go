@@ -0,0 +1,86 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+const signatureSeparator = "."
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type Direction string
+
+const (
+	DirectionNext Direction = "next"
+	DirectionPrev Direction = "prev"
+)
+
+// Cursor is the decoded content of an opaque pagination token. Key holds the
+// keyset position (sort value and id), Filters the listing criteria the
+// cursor was issued for, so a page can be fetched from the token alone.
+type Cursor struct {
+	Direction Direction         `json:"d"`
+	Sort      string            `json:"s,omitempty"`
+	Key       map[string]string `json:"k"`
+	Filters   map[string]string `json:"f,omitempty"`
+}
+
+// CursorCodec turns cursors into signed base64 tokens and back. Clients can
+// pass tokens around but cannot read or forge them without the secret.
+type CursorCodec struct {
+	secret []byte
+}
+
+func NewCursorCodec(secret []byte) *CursorCodec {
+	if len(secret) == 0 {
+		secret = make([]byte, sha256.Size)
+		if _, err := rand.Read(secret); err != nil {
+			panic("pagination: cannot generate cursor secret: " + err.Error())
+		}
+	}
+	return &CursorCodec{secret: secret}
+}
+
+func (cc *CursorCodec) Encode(cursor Cursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	return encodedPayload + signatureSeparator + cc.sign(encodedPayload), nil
+}
+
+func (cc *CursorCodec) Decode(token string) (Cursor, error) {
+	encodedPayload, signature, found := strings.Cut(token, signatureSeparator)
+	if !found {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if !hmac.Equal([]byte(signature), []byte(cc.sign(encodedPayload))) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if cursor.Direction != DirectionNext && cursor.Direction != DirectionPrev {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+func (cc *CursorCodec) sign(encodedPayload string) string {
+	mac := hmac.New(sha256.New, cc.secret)
+	mac.Write([]byte(encodedPayload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
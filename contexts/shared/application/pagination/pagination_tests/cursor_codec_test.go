@@ -0,0 +1,67 @@
+package pagination_tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorCodec_EncodeDecode(t *testing.T) {
+	t.Run("should round trip a cursor", func(t *testing.T) {
+		// Arrange
+		codec := pagination.NewCursorCodec([]byte("secret"))
+		cursor := pagination.Cursor{
+			Direction: pagination.DirectionPrev,
+			Sort:      "price:desc",
+			Key:       map[string]string{"v": "10.5", "id": "abc"},
+			Filters:   map[string]string{"category": "Electronics"},
+		}
+
+		// Act
+		token, err := codec.Encode(cursor)
+		require.NoError(t, err)
+		decoded, err := codec.Decode(token)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, cursor, decoded)
+		assert.NotContains(t, token, "Electronics")
+	})
+
+	t.Run("should reject cursor signed with another secret", func(t *testing.T) {
+		// Arrange
+		token, err := pagination.NewCursorCodec([]byte("secret")).Encode(pagination.Cursor{Direction: pagination.DirectionNext})
+		require.NoError(t, err)
+
+		// Act
+		_, err = pagination.NewCursorCodec([]byte("other")).Decode(token)
+
+		// Assert
+		assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+	})
+
+	t.Run("should reject cursor with modified payload", func(t *testing.T) {
+		// Arrange
+		codec := pagination.NewCursorCodec([]byte("secret"))
+		token, err := codec.Encode(pagination.Cursor{Direction: pagination.DirectionNext})
+		require.NoError(t, err)
+		payload, signature, _ := strings.Cut(token, ".")
+
+		// Act
+		_, err = codec.Decode(payload + "x." + signature)
+
+		// Assert
+		assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+	})
+
+	t.Run("should reject malformed cursor", func(t *testing.T) {
+		// Act
+		_, err := pagination.NewCursorCodec([]byte("secret")).Decode("not-a-cursor")
+
+		// Assert
+		assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+	})
+}
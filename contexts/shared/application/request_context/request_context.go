@@ -0,0 +1,128 @@
+package request_context
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+)
+
+// AnonymousActor is reported for requests that carry no authenticated caller.
+const AnonymousActor = "anonymous"
+
+// DefaultTenant owns the data of requests that name no tenant, so a
+// deployment serving a single catalogue needs no tenant configuration.
+const DefaultTenant = "default"
+
+// TenantClaim is the claim that binds a principal to a tenant.
+const TenantClaim = "tenant_id"
+
+// tenantPattern accepts tenants that are also valid DNS labels, so any tenant
+// can be reached through a subdomain.
+var tenantPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+type requestIDKey struct{}
+type actorKey struct{}
+type principalKey struct{}
+type tenantKey struct{}
+type uncachedReadsKey struct{}
+
+// Principal is the authenticated caller of a request, as stated by the token
+// or API key it presented.
+type Principal struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	ExpiresAt time.Time
+	Roles     []string
+	Scopes    []string
+	Claims    map[string]interface{}
+}
+
+// HasPermission reports whether one of the principal's roles grants
+// permission or the principal holds it as a scope.
+func (p Principal) HasPermission(permission models.Permission) bool {
+	if slices.Contains(p.Scopes, string(permission)) {
+		return true
+	}
+	return slices.ContainsFunc(p.Roles, func(role string) bool {
+		return models.Role(role).Grants(permission)
+	})
+}
+
+// Tenant returns the tenant the principal is bound to, or "" when its
+// credentials do not name one.
+func (p Principal) Tenant() string {
+	tenant, _ := p.Claims[TenantClaim].(string)
+	return tenant
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+func RequestIDFrom(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns who is performing the request, or AnonymousActor when
+// nobody has been identified.
+func ActorFrom(ctx context.Context) string {
+	actor, ok := ctx.Value(actorKey{}).(string)
+	if !ok || actor == "" {
+		return AnonymousActor
+	}
+	return actor
+}
+
+// WithPrincipal stores the authenticated caller, who is also the actor of the
+// request from then on.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	ctx = context.WithValue(ctx, principalKey{}, principal)
+	return WithActor(ctx, principal.Subject)
+}
+
+// PrincipalFrom returns the authenticated caller, if any.
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// IsValidTenant reports whether tenant is 1 to 63 lowercase letters, digits
+// or inner hyphens.
+func IsValidTenant(tenant string) bool {
+	return tenantPattern.MatchString(tenant)
+}
+
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFrom returns the tenant whose data the request works on, or
+// DefaultTenant when none was resolved.
+func TenantFrom(ctx context.Context) string {
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	if !ok || tenant == "" {
+		return DefaultTenant
+	}
+	return tenant
+}
+
+// WithUncachedReads asks repositories to read from their store rather than a
+// cache, for reads whose result a write is about to be based on.
+func WithUncachedReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, uncachedReadsKey{}, true)
+}
+
+// UncachedReads reports whether WithUncachedReads was asked for.
+func UncachedReads(ctx context.Context) bool {
+	uncached, _ := ctx.Value(uncachedReadsKey{}).(bool)
+	return uncached
+}
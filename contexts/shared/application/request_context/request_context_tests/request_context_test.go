@@ -0,0 +1,55 @@
+package request_context_tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestContext(t *testing.T) {
+	t.Run("should carry request id and actor", func(t *testing.T) {
+		// Arrange
+		ctx := request_context.WithRequestID(context.Background(), "req-1")
+		ctx = request_context.WithActor(ctx, "alice")
+
+		// Act & Assert
+		assert.Equal(t, "req-1", request_context.RequestIDFrom(ctx))
+		assert.Equal(t, "alice", request_context.ActorFrom(ctx))
+	})
+
+	t.Run("should default to anonymous actor", func(t *testing.T) {
+		// Act & Assert
+		assert.Equal(t, "", request_context.RequestIDFrom(context.Background()))
+		assert.Equal(t, request_context.AnonymousActor, request_context.ActorFrom(context.Background()))
+	})
+
+	t.Run("should carry the principal as actor", func(t *testing.T) {
+		// Arrange
+		principal := request_context.Principal{Subject: "user-42", Issuer: "https://issuer.example"}
+		ctx := request_context.WithPrincipal(context.Background(), principal)
+
+		// Act
+		stored, ok := request_context.PrincipalFrom(ctx)
+
+		// Assert
+		assert.True(t, ok)
+		assert.Equal(t, principal, stored)
+		assert.Equal(t, "user-42", request_context.ActorFrom(ctx))
+	})
+
+	t.Run("should report no principal for anonymous requests", func(t *testing.T) {
+		// Act
+		_, ok := request_context.PrincipalFrom(context.Background())
+
+		// Assert
+		assert.False(t, ok)
+	})
+
+	t.Run("should read through caches unless asked otherwise", func(t *testing.T) {
+		// Act & Assert
+		assert.False(t, request_context.UncachedReads(context.Background()))
+		assert.True(t, request_context.UncachedReads(request_context.WithUncachedReads(context.Background())))
+	})
+}
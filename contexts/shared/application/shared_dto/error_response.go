@@ -11,6 +11,15 @@ type ErrorResponse struct {
 	Details map[string]interface{} `json:"details,omitempty"`
 }
 
+// FromError renders domain and infra errors with their own code and message,
+// and any other error as an internal error.
+func FromError(err error) ErrorResponse {
+	if _, ok := err.(shared_handlers.InfraError); ok {
+		return FromInfraError(err)
+	}
+	return FromDomainError(err)
+}
+
 func FromDomainError(domainErr error) ErrorResponse {
 	if de, ok := domainErr.(models.DomainError); ok {
 		return ErrorResponse{
@@ -31,7 +40,7 @@ func FromInfraError(infraErr error) ErrorResponse {
 		return ErrorResponse{
 			Error:   string(ie.Code),
 			Message: ie.Message,
-			Details: nil,
+			Details: ie.Details,
 		}
 	}
 
@@ -3,12 +3,14 @@ package shared_dto
 type PaginatedResult[T any] struct {
 	Items      []T     `json:"items"`
 	NextCursor *string `json:"next_cursor,omitempty"`
+	PrevCursor *string `json:"prev_cursor,omitempty"`
 }
 
-func NewPaginatedResult[T any](items []T, nextCursor *string) PaginatedResult[T] {
+func NewPaginatedResult[T any](items []T, nextCursor, prevCursor *string) PaginatedResult[T] {
 	return PaginatedResult[T]{
 		Items:      items,
 		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
 	}
 }
 
@@ -16,6 +18,10 @@ func (pr *PaginatedResult[T]) HasMore() bool {
 	return pr.NextCursor != nil
 }
 
+func (pr *PaginatedResult[T]) HasPrevious() bool {
+	return pr.PrevCursor != nil
+}
+
 func (pr *PaginatedResult[T]) Count() int {
 	return len(pr.Items)
 }
@@ -0,0 +1,38 @@
+package shared_dto
+
+import (
+	"net/http"
+	"strings"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is the RFC 7807 rendering of an error. Besides the standard
+// members it carries the request ID, the same error code ErrorResponse has
+// and, when the error has them, its details.
+type ProblemDetails struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail,omitempty"`
+	Instance  string                 `json:"instance,omitempty"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Code      string                 `json:"code"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+// NewProblemDetails turns an error response sent with status for the request
+// to instance into a problem. The problem type is derived from the error code,
+// e.g. /problems/not-found for NOT_FOUND.
+func NewProblemDetails(response ErrorResponse, status int, instance, requestID string) ProblemDetails {
+	return ProblemDetails{
+		Type:      "/problems/" + strings.ToLower(strings.ReplaceAll(response.Error, "_", "-")),
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    response.Message,
+		Instance:  instance,
+		RequestID: requestID,
+		Code:      response.Error,
+		Details:   response.Details,
+	}
+}
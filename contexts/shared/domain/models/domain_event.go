@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DomainEvent is something that happened to an aggregate that other parts of
+// the system may react to. Events are serialized to JSON when published.
+type DomainEvent interface {
+	EventName() string
+	AggregateID() uuid.UUID
+	OccurredAt() time.Time
+}
@@ -9,3 +9,17 @@ type DomainError struct {
 func (e DomainError) Error() string {
 	return e.Message
 }
+
+// Is matches domain errors by code, so an error carrying details still
+// matches the error it was made from.
+func (e DomainError) Is(target error) bool {
+	domainErr, ok := target.(DomainError)
+	return ok && domainErr.Code == e.Code
+}
+
+// WithFieldViolations returns a copy of the error that reports the invalid
+// fields in its details.
+func (e DomainError) WithFieldViolations(violations ...FieldViolation) DomainError {
+	e.Details = FieldViolationDetails(violations)
+	return e
+}
@@ -0,0 +1,19 @@
+package models
+
+// FieldViolation describes why the value sent for one field was rejected.
+type FieldViolation struct {
+	Field   string      `json:"field"`
+	Rule    string      `json:"rule"`
+	Value   interface{} `json:"value"`
+	Message string      `json:"message"`
+}
+
+// FieldViolationDetails keys violations by field, the shape error details use
+// to report invalid fields so that clients can point at each of them.
+func FieldViolationDetails(violations []FieldViolation) map[string]interface{} {
+	details := make(map[string]interface{}, len(violations))
+	for _, violation := range violations {
+		details[violation.Field] = violation
+	}
+	return details
+}
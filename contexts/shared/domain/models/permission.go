@@ -0,0 +1,63 @@
+package models
+
+import "slices"
+
+// Permission is an action a caller may be allowed to perform. Tokens grant
+// permissions through roles, or directly as scopes named after them.
+type Permission string
+
+const (
+	PermissionProductsRead   Permission = "products:read"
+	PermissionProductsWrite  Permission = "products:write"
+	PermissionProductsDelete Permission = "products:delete"
+	PermissionAPIKeysManage  Permission = "api_keys:manage"
+	PermissionWebhooksManage Permission = "webhooks:manage"
+	PermissionUsersManage    Permission = "users:manage"
+	PermissionMetricsRead    Permission = "metrics:read"
+)
+
+var knownPermissions = []Permission{
+	PermissionProductsRead,
+	PermissionProductsWrite,
+	PermissionProductsDelete,
+	PermissionAPIKeysManage,
+	PermissionWebhooksManage,
+	PermissionUsersManage,
+	PermissionMetricsRead,
+}
+
+// IsKnown reports whether the permission is one the API checks.
+func (p Permission) IsKnown() bool {
+	return slices.Contains(knownPermissions, p)
+}
+
+// Role is a named set of permissions.
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleViewer: {PermissionProductsRead},
+	RoleEditor: {PermissionProductsRead, PermissionProductsWrite},
+	RoleAdmin:  {PermissionProductsRead, PermissionProductsWrite, PermissionProductsDelete, PermissionAPIKeysManage, PermissionWebhooksManage, PermissionUsersManage, PermissionMetricsRead},
+}
+
+// Permissions returns what the role allows. Unknown roles allow nothing.
+func (r Role) Permissions() []Permission {
+	return rolePermissions[r]
+}
+
+// Grants reports whether the role allows permission.
+func (r Role) Grants(permission Permission) bool {
+	return slices.Contains(rolePermissions[r], permission)
+}
+
+// IsKnown reports whether the role is one the API grants permissions to.
+func (r Role) IsKnown() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
@@ -0,0 +1,99 @@
+package cache_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCacheStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return what was stored", func(t *testing.T) {
+		// Arrange
+		store := cache.NewMemoryCacheStore(10)
+		require.NoError(t, store.Set(ctx, "a", []byte("1"), time.Hour))
+
+		// Act
+		value, found, err := store.Get(ctx, "a")
+		_, missing, _ := store.Get(ctx, "b")
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, []byte("1"), value)
+		assert.False(t, missing)
+	})
+
+	t.Run("should forget expired entries", func(t *testing.T) {
+		// Arrange
+		store := cache.NewMemoryCacheStore(10)
+		store.Set(ctx, "a", []byte("1"), time.Millisecond)
+		time.Sleep(2 * time.Millisecond)
+
+		// Act
+		_, found, err := store.Get(ctx, "a")
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, found)
+		assert.Zero(t, store.Len())
+	})
+
+	t.Run("should evict the least recently used entry when full", func(t *testing.T) {
+		// Arrange
+		store := cache.NewMemoryCacheStore(2)
+		store.Set(ctx, "a", []byte("1"), time.Hour)
+		store.Set(ctx, "b", []byte("2"), time.Hour)
+		store.Get(ctx, "a")
+
+		// Act
+		store.Set(ctx, "c", []byte("3"), time.Hour)
+
+		// Assert
+		_, hasA, _ := store.Get(ctx, "a")
+		_, hasB, _ := store.Get(ctx, "b")
+		_, hasC, _ := store.Get(ctx, "c")
+		assert.True(t, hasA)
+		assert.False(t, hasB)
+		assert.True(t, hasC)
+		assert.Equal(t, 2, store.Len())
+	})
+
+	t.Run("should delete entries", func(t *testing.T) {
+		// Arrange
+		store := cache.NewMemoryCacheStore(10)
+		store.Set(ctx, "a", []byte("1"), time.Hour)
+		store.Set(ctx, "b", []byte("2"), time.Hour)
+
+		// Act
+		err := store.Delete(ctx, "a", "missing")
+
+		// Assert
+		require.NoError(t, err)
+		_, hasA, _ := store.Get(ctx, "a")
+		_, hasB, _ := store.Get(ctx, "b")
+		assert.False(t, hasA)
+		assert.True(t, hasB)
+	})
+
+	t.Run("should not share stored values with callers", func(t *testing.T) {
+		// Arrange
+		store := cache.NewMemoryCacheStore(10)
+		value := []byte("1")
+		store.Set(ctx, "a", value, time.Hour)
+		value[0] = '2'
+
+		// Act
+		stored, _, _ := store.Get(ctx, "a")
+		stored[0] = '3'
+		again, _, _ := store.Get(ctx, "a")
+
+		// Assert
+		assert.Equal(t, []byte("1"), again)
+	})
+}
@@ -0,0 +1,133 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// reserveAttempts covers a key released by another request between the
+// failed insert and the read of the row that blocked it.
+const reserveAttempts = 2
+
+type GormIdempotencyStore struct {
+	db *gorm.DB
+}
+
+func NewGormIdempotencyStore(db *gorm.DB) *GormIdempotencyStore {
+	return &GormIdempotencyStore{db: db}
+}
+
+// Reserve inserts the key, or takes over an expired or abandoned row for it,
+// in a single statement so that concurrent requests with the same key cannot
+// both win. A row is abandoned when it has no response past its LockedUntil,
+// e.g. because the instance processing its request died; rows from before
+// LockedUntil existed are only taken over once they expire.
+func (s *GormIdempotencyStore) Reserve(ctx context.Context, key string, fingerprint string, lockedUntil time.Time, expiresAt time.Time) (*interfaces.IdempotencyRecord, error) {
+	for range reserveAttempts {
+		now := time.Now().UTC()
+		lockedUntil := lockedUntil.UTC()
+		entity := IdempotencyKeyEntity{
+			TenantID:    request_context.TenantFrom(ctx),
+			Actor:       request_context.ActorFrom(ctx),
+			Key:         key,
+			Fingerprint: fingerprint,
+			CreatedAt:   now,
+			LockedUntil: &lockedUntil,
+			ExpiresAt:   expiresAt.UTC(),
+		}
+		result := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "actor"}, {Name: "key"}},
+			DoUpdates: clause.AssignmentColumns([]string{"fingerprint", "status_code", "header", "body", "created_at", "completed_at", "locked_until", "expires_at"}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{
+					SQL:  "idempotency_key_entities.expires_at <= ? OR (idempotency_key_entities.completed_at IS NULL AND idempotency_key_entities.locked_until <= ?)",
+					Vars: []interface{}{now, now},
+				},
+			}},
+		}).Create(&entity)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			return nil, nil
+		}
+
+		var stored IdempotencyKeyEntity
+		err := s.owned(ctx, key).Take(&stored).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return stored.toRecord()
+	}
+	return nil, errors.New("idempotency key was released while being reserved")
+}
+
+func (s *GormIdempotencyStore) Complete(ctx context.Context, key string, response interfaces.IdempotentResponse) error {
+	header, err := json.Marshal(response.Header)
+	if err != nil {
+		return err
+	}
+	return s.owned(ctx, key).Model(&IdempotencyKeyEntity{}).Updates(map[string]interface{}{
+		"status_code":  response.StatusCode,
+		"header":       header,
+		"body":         response.Body,
+		"completed_at": time.Now().UTC(),
+	}).Error
+}
+
+func (s *GormIdempotencyStore) Release(ctx context.Context, key string) error {
+	return s.owned(ctx, key).Delete(&IdempotencyKeyEntity{}).Error
+}
+
+// owned selects key among the keys of the tenant and actor of ctx.
+func (s *GormIdempotencyStore) owned(ctx context.Context, key string) *gorm.DB {
+	return s.db.WithContext(ctx).Where("tenant_id = ? AND actor = ? AND key = ?", request_context.TenantFrom(ctx), request_context.ActorFrom(ctx), key)
+}
+
+// PurgeExpired deletes the keys that have expired and returns how many.
+func (s *GormIdempotencyStore) PurgeExpired(ctx context.Context) (int64, error) {
+	result := s.db.WithContext(ctx).Where("expires_at <= ?", time.Now().UTC()).Delete(&IdempotencyKeyEntity{})
+	return result.RowsAffected, result.Error
+}
+
+// RunPurge deletes expired keys every interval until ctx is cancelled.
+// Expired keys are already ignored by Reserve; purging only reclaims space.
+func (s *GormIdempotencyStore) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.PurgeExpired(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("⚠️  Idempotency key purge failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *IdempotencyKeyEntity) toRecord() (*interfaces.IdempotencyRecord, error) {
+	record := &interfaces.IdempotencyRecord{Fingerprint: e.Fingerprint}
+	if e.CompletedAt == nil {
+		return record, nil
+	}
+	response := &interfaces.IdempotentResponse{StatusCode: e.StatusCode, Body: e.Body}
+	if err := json.Unmarshal(e.Header, &response.Header); err != nil {
+		return nil, err
+	}
+	record.Response = response
+	return record, nil
+}
@@ -0,0 +1,21 @@
+package idempotency
+
+import "time"
+
+// IdempotencyKeyEntity is a key sent with a request and, once the request
+// completed, the response it got. The row is kept until ExpiresAt, but one
+// that has no response by LockedUntil may be taken over. Keys are chosen by
+// clients, so each tenant and actor has keys of their own.
+type IdempotencyKeyEntity struct {
+	TenantID    string `gorm:"primaryKey"`
+	Actor       string `gorm:"primaryKey"`
+	Key         string `gorm:"primaryKey"`
+	Fingerprint string `gorm:"not null"`
+	StatusCode  int
+	Header      []byte `gorm:"type:jsonb"`
+	Body        []byte
+	CreatedAt   time.Time `gorm:"not null"`
+	CompletedAt *time.Time
+	LockedUntil *time.Time
+	ExpiresAt   time.Time `gorm:"not null;index"`
+}
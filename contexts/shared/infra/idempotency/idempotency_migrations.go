@@ -0,0 +1,18 @@
+package idempotency
+
+import "gorm.io/gorm"
+
+// MigrateIdempotencyKeys creates or updates the idempotency key table. A table
+// from before keys were kept per tenant and actor has the key alone as its
+// primary key, which AutoMigrate cannot change, so it is dropped: its keys
+// would expire soon anyway, and losing them only means that a retry arriving
+// during the upgrade is processed again.
+func MigrateIdempotencyKeys(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if migrator.HasTable(&IdempotencyKeyEntity{}) && !migrator.HasColumn(&IdempotencyKeyEntity{}, "TenantID") {
+		if err := migrator.DropTable(&IdempotencyKeyEntity{}); err != nil {
+			return err
+		}
+	}
+	return db.AutoMigrate(&IdempotencyKeyEntity{})
+}
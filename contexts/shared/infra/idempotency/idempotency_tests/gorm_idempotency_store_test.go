@@ -0,0 +1,193 @@
+package idempotency_tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/idempotency"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgres_driver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type GormIdempotencyStoreTestSuite struct {
+	suite.Suite
+	container *postgres.PostgresContainer
+	db        *gorm.DB
+	store     *idempotency.GormIdempotencyStore
+	ctx       context.Context
+}
+
+func (suite *GormIdempotencyStoreTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	container, err := postgres.Run(suite.ctx,
+		"postgres:15-alpine",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	connStr, err := container.ConnectionString(suite.ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	db, err := gorm.Open(postgres_driver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(idempotency.MigrateIdempotencyKeys(db))
+
+	suite.db = db
+	suite.store = idempotency.NewGormIdempotencyStore(db)
+}
+
+func (suite *GormIdempotencyStoreTestSuite) TearDownSuite() {
+	if suite.container != nil {
+		suite.container.Terminate(suite.ctx)
+	}
+}
+
+func (suite *GormIdempotencyStoreTestSuite) TearDownTest() {
+	if suite.db != nil {
+		suite.db.Exec("TRUNCATE TABLE idempotency_key_entities")
+	}
+}
+
+func (suite *GormIdempotencyStoreTestSuite) TestReserveAndComplete() {
+	// Arrange
+	lockedUntil := time.Now().Add(time.Minute)
+	expiresAt := time.Now().Add(time.Hour)
+	response := interfaces.IdempotentResponse{
+		StatusCode: http.StatusCreated,
+		Header:     map[string][]string{"Content-Type": {"application/json"}},
+		Body:       []byte(`{"sku":"TEST-001"}`),
+	}
+
+	// Act
+	first, err := suite.store.Reserve(suite.ctx, "key-1", "fingerprint", lockedUntil, expiresAt)
+	suite.Require().NoError(err)
+	inProgress, err := suite.store.Reserve(suite.ctx, "key-1", "fingerprint", lockedUntil, expiresAt)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.store.Complete(suite.ctx, "key-1", response))
+	completed, err := suite.store.Reserve(suite.ctx, "key-1", "other fingerprint", lockedUntil, expiresAt)
+	suite.Require().NoError(err)
+
+	// Assert
+	suite.Nil(first)
+	suite.Require().NotNil(inProgress)
+	suite.Equal("fingerprint", inProgress.Fingerprint)
+	suite.Nil(inProgress.Response)
+	suite.Require().NotNil(completed)
+	suite.Equal("fingerprint", completed.Fingerprint)
+	suite.Equal(&response, completed.Response)
+}
+
+func (suite *GormIdempotencyStoreTestSuite) TestReserveTakesOverExpiredAndReleasedKeys() {
+	// Arrange
+	_, err := suite.store.Reserve(suite.ctx, "expired", "old", time.Now().Add(time.Minute), time.Now().Add(-time.Second))
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.store.Complete(suite.ctx, "expired", interfaces.IdempotentResponse{StatusCode: http.StatusCreated}))
+	_, err = suite.store.Reserve(suite.ctx, "released", "old", time.Now().Add(time.Minute), time.Now().Add(time.Hour))
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.store.Release(suite.ctx, "released"))
+
+	// Act
+	expired, err := suite.store.Reserve(suite.ctx, "expired", "new", time.Now().Add(time.Minute), time.Now().Add(time.Hour))
+	suite.Require().NoError(err)
+	released, err := suite.store.Reserve(suite.ctx, "released", "new", time.Now().Add(time.Minute), time.Now().Add(time.Hour))
+	suite.Require().NoError(err)
+	again, err := suite.store.Reserve(suite.ctx, "expired", "new", time.Now().Add(time.Minute), time.Now().Add(time.Hour))
+	suite.Require().NoError(err)
+
+	// Assert
+	suite.Nil(expired)
+	suite.Nil(released)
+	suite.Require().NotNil(again)
+	suite.Equal("new", again.Fingerprint)
+	suite.Nil(again.Response)
+}
+
+func (suite *GormIdempotencyStoreTestSuite) TestReserveTakesOverAbandonedKeys() {
+	// Arrange
+	expiresAt := time.Now().Add(time.Hour)
+	_, err := suite.store.Reserve(suite.ctx, "abandoned", "old", time.Now().Add(-time.Second), expiresAt)
+	suite.Require().NoError(err)
+	_, err = suite.store.Reserve(suite.ctx, "completed", "old", time.Now().Add(-time.Second), expiresAt)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.store.Complete(suite.ctx, "completed", interfaces.IdempotentResponse{StatusCode: http.StatusCreated}))
+
+	// Act
+	abandoned, err := suite.store.Reserve(suite.ctx, "abandoned", "new", time.Now().Add(time.Minute), expiresAt)
+	suite.Require().NoError(err)
+	again, err := suite.store.Reserve(suite.ctx, "abandoned", "new", time.Now().Add(time.Minute), expiresAt)
+	suite.Require().NoError(err)
+	completed, err := suite.store.Reserve(suite.ctx, "completed", "new", time.Now().Add(time.Minute), expiresAt)
+	suite.Require().NoError(err)
+
+	// Assert
+	suite.Nil(abandoned)
+	suite.Require().NotNil(again)
+	suite.Equal("new", again.Fingerprint)
+	suite.Nil(again.Response)
+	suite.Require().NotNil(completed)
+	suite.Equal("old", completed.Fingerprint)
+	suite.NotNil(completed.Response)
+}
+
+func (suite *GormIdempotencyStoreTestSuite) TestKeysBelongToTheirTenantAndActor() {
+	// Arrange
+	lockedUntil := time.Now().Add(time.Minute)
+	expiresAt := time.Now().Add(time.Hour)
+	jane := request_context.WithTenant(request_context.WithActor(suite.ctx, "jane"), "acme")
+	john := request_context.WithTenant(request_context.WithActor(suite.ctx, "john"), "acme")
+	janeElsewhere := request_context.WithTenant(request_context.WithActor(suite.ctx, "jane"), "globex")
+	_, err := suite.store.Reserve(jane, "key-1", "fingerprint", lockedUntil, expiresAt)
+	suite.Require().NoError(err)
+
+	// Act
+	byJohn, err := suite.store.Reserve(john, "key-1", "other", lockedUntil, expiresAt)
+	suite.Require().NoError(err)
+	inGlobex, err := suite.store.Reserve(janeElsewhere, "key-1", "other", lockedUntil, expiresAt)
+	suite.Require().NoError(err)
+	again, err := suite.store.Reserve(jane, "key-1", "fingerprint", lockedUntil, expiresAt)
+	suite.Require().NoError(err)
+
+	// Assert
+	suite.Nil(byJohn)
+	suite.Nil(inGlobex)
+	suite.Require().NotNil(again)
+	suite.Equal("fingerprint", again.Fingerprint)
+}
+
+func (suite *GormIdempotencyStoreTestSuite) TestPurgeExpired() {
+	// Arrange
+	_, err := suite.store.Reserve(suite.ctx, "expired", "fingerprint", time.Now().Add(time.Minute), time.Now().Add(-time.Second))
+	suite.Require().NoError(err)
+	_, err = suite.store.Reserve(suite.ctx, "live", "fingerprint", time.Now().Add(time.Minute), time.Now().Add(time.Hour))
+	suite.Require().NoError(err)
+
+	// Act
+	purged, err := suite.store.PurgeExpired(suite.ctx)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), purged)
+	var remaining []string
+	suite.Require().NoError(suite.db.Model(&idempotency.IdempotencyKeyEntity{}).Pluck("key", &remaining).Error)
+	suite.Equal([]string{"live"}, remaining)
+}
+
+func TestGormIdempotencyStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(GormIdempotencyStoreTestSuite))
+}
@@ -0,0 +1,102 @@
+package jsonpatch
+
+import (
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/jsonpatch"
+	"github.com/stretchr/testify/suite"
+)
+
+type JSONPatchTestSuite struct {
+	suite.Suite
+}
+
+const testDocument = `{"name":"Mouse","price":19.90,"tags":["a","b"],"dimensions":{"width":5}}`
+
+func (suite *JSONPatchTestSuite) TestApply() {
+	cases := []struct {
+		name     string
+		patch    string
+		expected string
+	}{
+		{"replace member", `[{"op":"replace","path":"/name","value":"Keyboard"}]`, `{"name":"Keyboard","price":19.90,"tags":["a","b"],"dimensions":{"width":5}}`},
+		{"add member", `[{"op":"add","path":"/dimensions/height","value":2}]`, `{"name":"Mouse","price":19.90,"tags":["a","b"],"dimensions":{"width":5,"height":2}}`},
+		{"insert into array", `[{"op":"add","path":"/tags/1","value":"c"}]`, `{"name":"Mouse","price":19.90,"tags":["a","c","b"],"dimensions":{"width":5}}`},
+		{"append to array", `[{"op":"add","path":"/tags/-","value":"c"}]`, `{"name":"Mouse","price":19.90,"tags":["a","b","c"],"dimensions":{"width":5}}`},
+		{"remove array element", `[{"op":"remove","path":"/tags/0"}]`, `{"name":"Mouse","price":19.90,"tags":["b"],"dimensions":{"width":5}}`},
+		{"move member", `[{"op":"move","from":"/dimensions/width","path":"/width"}]`, `{"name":"Mouse","price":19.90,"tags":["a","b"],"dimensions":{},"width":5}`},
+		{"copy member", `[{"op":"copy","from":"/tags","path":"/labels"}]`, `{"name":"Mouse","price":19.90,"tags":["a","b"],"labels":["a","b"],"dimensions":{"width":5}}`},
+		{"test then replace", `[{"op":"test","path":"/price","value":19.9},{"op":"replace","path":"/price","value":24.5}]`, `{"name":"Mouse","price":24.5,"tags":["a","b"],"dimensions":{"width":5}}`},
+		{"escaped pointer", `[{"op":"add","path":"/a~1b~0c","value":1}]`, `{"name":"Mouse","price":19.90,"tags":["a","b"],"dimensions":{"width":5},"a/b~c":1}`},
+	}
+
+	for _, tc := range cases {
+		suite.Run("should "+tc.name, func() {
+			// Act
+			patched, err := jsonpatch.Apply([]byte(testDocument), []byte(tc.patch))
+
+			// Assert
+			suite.NoError(err)
+			suite.JSONEq(tc.expected, string(patched))
+		})
+	}
+}
+
+func (suite *JSONPatchTestSuite) TestApplyErrors() {
+	cases := []struct {
+		name     string
+		patch    string
+		expected error
+	}{
+		{"reject a failed test", `[{"op":"test","path":"/name","value":"Keyboard"},{"op":"replace","path":"/name","value":"x"}]`, jsonpatch.ErrInvalidPatch},
+		{"reject a missing path", `[{"op":"replace","path":"/color","value":"red"}]`, jsonpatch.ErrInvalidPatch},
+		{"reject an index out of range", `[{"op":"add","path":"/tags/5","value":"c"}]`, jsonpatch.ErrInvalidPatch},
+		{"reject moving a value into itself", `[{"op":"move","from":"/dimensions","path":"/dimensions/inner"}]`, jsonpatch.ErrInvalidPatch},
+		{"reject an unknown operation", `[{"op":"increment","path":"/price","value":1}]`, jsonpatch.ErrMalformedPatch},
+		{"reject an operation without value", `[{"op":"add","path":"/name"}]`, jsonpatch.ErrMalformedPatch},
+		{"reject a pointer without leading slash", `[{"op":"remove","path":"name"}]`, jsonpatch.ErrMalformedPatch},
+		{"reject a patch that is not a list", `{"op":"remove","path":"/name"}`, jsonpatch.ErrMalformedPatch},
+	}
+
+	for _, tc := range cases {
+		suite.Run("should "+tc.name, func() {
+			// Act
+			_, err := jsonpatch.Apply([]byte(testDocument), []byte(tc.patch))
+
+			// Assert
+			suite.ErrorIs(err, tc.expected)
+		})
+	}
+}
+
+func (suite *JSONPatchTestSuite) TestMergePatch() {
+	suite.Run("should merge members and remove nulls", func() {
+		// Act
+		patched, err := jsonpatch.MergePatch([]byte(testDocument), []byte(`{"name":"Keyboard","tags":null,"dimensions":{"height":2}}`))
+
+		// Assert
+		suite.NoError(err)
+		suite.JSONEq(`{"name":"Keyboard","price":19.90,"dimensions":{"width":5,"height":2}}`, string(patched))
+	})
+
+	suite.Run("should keep numbers as written", func() {
+		// Act
+		patched, err := jsonpatch.MergePatch([]byte(`{"price":0.1}`), []byte(`{"price":1234567890.123456789}`))
+
+		// Assert
+		suite.NoError(err)
+		suite.Equal(`{"price":1234567890.123456789}`, string(patched))
+	})
+
+	suite.Run("should reject a patch that is not JSON", func() {
+		// Act
+		_, err := jsonpatch.MergePatch([]byte(testDocument), []byte(`{"name":`))
+
+		// Assert
+		suite.ErrorIs(err, jsonpatch.ErrMalformedPatch)
+	})
+}
+
+func TestJSONPatchTestSuite(t *testing.T) {
+	suite.Run(t, new(JSONPatchTestSuite))
+}
@@ -0,0 +1,275 @@
+package jwt_tests
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/jwt"
+	"github.com/stretchr/testify/suite"
+)
+
+const testSecret = "test-secret"
+
+type VerifierTestSuite struct {
+	suite.Suite
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+}
+
+func (suite *VerifierTestSuite) SetupSuite() {
+	var err error
+	suite.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	suite.Require().NoError(err)
+	suite.ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	suite.Require().NoError(err)
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"sub": "user-42",
+		"iss": "https://issuer.example",
+		"aud": []string{"go-test-api"},
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func (suite *VerifierTestSuite) sign(header map[string]interface{}, claims map[string]interface{}) string {
+	headerJSON, err := json.Marshal(header)
+	suite.Require().NoError(err)
+	claimsJSON, err := json.Marshal(claims)
+	suite.Require().NoError(err)
+	signed := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch header["alg"] {
+	case jwt.HS256:
+		mac := hmac.New(sha256.New, []byte(testSecret))
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	case jwt.RS256:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, suite.rsaKey, crypto.SHA256, digest[:])
+		suite.Require().NoError(err)
+	case jwt.ES256:
+		r, s, err := ecdsa.Sign(rand.Reader, suite.ecKey, digest[:])
+		suite.Require().NoError(err)
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (suite *VerifierTestSuite) publicKeyPEM(public crypto.PublicKey) []byte {
+	der, err := x509.MarshalPKIXPublicKey(public)
+	suite.Require().NoError(err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func (suite *VerifierTestSuite) verifier(config jwt.Config) *jwt.Verifier {
+	rsaKey, err := jwt.ParsePublicKeyPEM("rsa-1", suite.publicKeyPEM(&suite.rsaKey.PublicKey))
+	suite.Require().NoError(err)
+	ecKey, err := jwt.ParsePublicKeyPEM("", suite.publicKeyPEM(&suite.ecKey.PublicKey))
+	suite.Require().NoError(err)
+	return jwt.NewVerifier([]jwt.Key{jwt.NewHMACKey("", []byte(testSecret)), rsaKey, ecKey}, config)
+}
+
+func (suite *VerifierTestSuite) TestVerify() {
+	headers := []map[string]interface{}{
+		{"alg": jwt.HS256, "typ": "JWT"},
+		{"alg": jwt.RS256, "kid": "rsa-1"},
+		{"alg": jwt.ES256},
+	}
+
+	for _, header := range headers {
+		suite.Run(fmt.Sprintf("should accept a valid %s token", header["alg"]), func() {
+			// Arrange
+			token := suite.sign(header, validClaims())
+
+			// Act
+			principal, err := suite.verifier(jwt.Config{Issuer: "https://issuer.example", Audience: "go-test-api"}).Verify(token)
+
+			// Assert
+			suite.NoError(err)
+			suite.Equal("user-42", principal.Subject)
+			suite.Equal("https://issuer.example", principal.Issuer)
+			suite.Equal([]string{"go-test-api"}, principal.Audience)
+		})
+	}
+}
+
+func (suite *VerifierTestSuite) TestVerifyGrants() {
+	suite.Run("should read roles and space-delimited scopes", func() {
+		// Arrange
+		claims := validClaims()
+		claims["roles"] = []string{"editor"}
+		claims["scope"] = "products:read products:delete"
+
+		// Act
+		principal, err := suite.verifier(jwt.Config{}).Verify(suite.sign(map[string]interface{}{"alg": jwt.HS256}, claims))
+
+		// Assert
+		suite.NoError(err)
+		suite.Equal([]string{"editor"}, principal.Roles)
+		suite.Equal([]string{"products:read", "products:delete"}, principal.Scopes)
+	})
+
+	suite.Run("should read scopes sent as a list in scp", func() {
+		// Arrange
+		claims := validClaims()
+		claims["scp"] = []string{"products:read"}
+
+		// Act
+		principal, err := suite.verifier(jwt.Config{}).Verify(suite.sign(map[string]interface{}{"alg": jwt.HS256}, claims))
+
+		// Assert
+		suite.NoError(err)
+		suite.Equal([]string{"products:read"}, principal.Scopes)
+	})
+}
+
+func (suite *VerifierTestSuite) TestVerifyErrors() {
+	withClaim := func(name string, value interface{}) map[string]interface{} {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+		return claims
+	}
+	hs256 := map[string]interface{}{"alg": jwt.HS256}
+
+	cases := []struct {
+		name     string
+		token    func() string
+		expected error
+	}{
+		{"reject an expired token", func() string {
+			return suite.sign(hs256, withClaim("exp", time.Now().Add(-time.Minute).Unix()))
+		}, jwt.ErrTokenExpired},
+		{"reject a token used before nbf", func() string {
+			return suite.sign(hs256, withClaim("nbf", time.Now().Add(time.Hour).Unix()))
+		}, jwt.ErrTokenNotYetValid},
+		{"reject another issuer", func() string {
+			return suite.sign(hs256, withClaim("iss", "https://evil.example"))
+		}, jwt.ErrInvalidIssuer},
+		{"reject another audience", func() string {
+			return suite.sign(hs256, withClaim("aud", "another-api"))
+		}, jwt.ErrInvalidAudience},
+		{"reject a token without exp", func() string {
+			return suite.sign(hs256, withClaim("exp", nil))
+		}, jwt.ErrMalformedToken},
+		{"reject a token without sub", func() string {
+			return suite.sign(hs256, withClaim("sub", nil))
+		}, jwt.ErrMalformedToken},
+		{"reject the none algorithm", func() string {
+			return suite.sign(map[string]interface{}{"alg": "none"}, validClaims())
+		}, jwt.ErrUnsupportedAlgorithm},
+		{"reject a tampered payload", func() string {
+			token := suite.sign(hs256, validClaims())
+			other := suite.sign(hs256, withClaim("sub", "admin"))
+			return token[:len(token)-10] + other[len(other)-10:]
+		}, jwt.ErrInvalidSignature},
+		{"reject a key id that is not configured", func() string {
+			return suite.sign(map[string]interface{}{"alg": jwt.RS256, "kid": "rsa-2"}, validClaims())
+		}, jwt.ErrInvalidSignature},
+		{"reject a token that is not a JWT", func() string {
+			return "not-a-token"
+		}, jwt.ErrMalformedToken},
+	}
+
+	for _, tc := range cases {
+		suite.Run("should "+tc.name, func() {
+			// Act
+			_, err := suite.verifier(jwt.Config{Issuer: "https://issuer.example", Audience: "go-test-api"}).Verify(tc.token())
+
+			// Assert
+			suite.ErrorIs(err, tc.expected)
+		})
+	}
+
+	suite.Run("should allow for clock skew within the leeway", func() {
+		// Arrange
+		token := suite.sign(hs256, withClaim("exp", time.Now().Add(-10*time.Second).Unix()))
+
+		// Act
+		_, err := suite.verifier(jwt.Config{Leeway: time.Minute}).Verify(token)
+
+		// Assert
+		suite.NoError(err)
+	})
+}
+
+func (suite *VerifierTestSuite) TestParseJWKS() {
+	suite.Run("should verify tokens with the keys of a JWKS file", func() {
+		// Arrange
+		public := suite.rsaKey.PublicKey
+		jwks := fmt.Sprintf(`{"keys":[{"kty":"RSA","kid":"rsa-1","alg":"RS256","use":"sig","n":%q,"e":%q},{"kty":"RSA","use":"enc","n":"AQ","e":"AQ"}]}`,
+			base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			base64.RawURLEncoding.EncodeToString([]byte{1, 0, 1}))
+		path := filepath.Join(suite.T().TempDir(), "jwks.json")
+		suite.Require().NoError(os.WriteFile(path, []byte(jwks), 0o600))
+
+		// Act
+		keys, err := jwt.LoadKeys("", "", path)
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Len(keys, 1)
+		_, err = jwt.NewVerifier(keys, jwt.Config{}).Verify(suite.sign(map[string]interface{}{"alg": jwt.RS256, "kid": "rsa-1"}, validClaims()))
+		suite.NoError(err)
+	})
+
+	suite.Run("should refuse a configuration without keys", func() {
+		// Act
+		_, err := jwt.LoadKeys("", "", "")
+
+		// Assert
+		suite.ErrorIs(err, jwt.ErrNoKeys)
+	})
+}
+
+func (suite *VerifierTestSuite) TestSign() {
+	suite.Run("should issue tokens the verifier accepts", func() {
+		// Arrange
+		claims := validClaims()
+		claims["roles"] = []string{"viewer"}
+
+		// Act
+		token, err := jwt.NewSigner([]byte(testSecret)).Sign(claims)
+
+		// Assert
+		suite.Require().NoError(err)
+		principal, err := suite.verifier(jwt.Config{}).Verify(token)
+		suite.NoError(err)
+		suite.Equal("user-42", principal.Subject)
+		suite.Equal([]string{"viewer"}, principal.Roles)
+	})
+
+	suite.Run("should refuse to sign without a secret", func() {
+		// Act
+		_, err := jwt.NewSigner(nil).Sign(validClaims())
+
+		// Assert
+		suite.ErrorIs(err, jwt.ErrNoSigningKey)
+	})
+}
+
+func TestVerifierTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifierTestSuite))
+}
@@ -0,0 +1,200 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+	ES256 = "ES256"
+)
+
+// ErrNoKeys means no verification key was configured, so no token could ever
+// be accepted.
+var ErrNoKeys = errors.New("no token verification key configured")
+
+// Key verifies the signature of tokens signed with Algorithm. A key with an
+// ID only verifies tokens whose kid header names it; a key without one
+// verifies any token of its algorithm.
+type Key struct {
+	ID        string
+	Algorithm string
+	secret    []byte
+	public    crypto.PublicKey
+}
+
+func NewHMACKey(id string, secret []byte) Key {
+	return Key{ID: id, Algorithm: HS256, secret: secret}
+}
+
+// ParsePublicKeyPEM reads an RSA or P-256 public key, either bare or in a
+// certificate. RSA keys verify RS256 tokens and P-256 keys ES256 tokens.
+func ParsePublicKeyPEM(id string, data []byte) (Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, errors.New("no PEM block found")
+	}
+
+	var public crypto.PublicKey
+	switch block.Type {
+	case "CERTIFICATE":
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return Key{}, err
+		}
+		public = certificate.PublicKey
+	case "RSA PUBLIC KEY":
+		key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return Key{}, err
+		}
+		public = key
+	default:
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return Key{}, err
+		}
+		public = key
+	}
+	return publicKey(id, public)
+}
+
+func publicKey(id string, public crypto.PublicKey) (Key, error) {
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		return Key{ID: id, Algorithm: RS256, public: key}, nil
+	case *ecdsa.PublicKey:
+		if key.Curve != elliptic.P256() {
+			return Key{}, fmt.Errorf("unsupported curve %s", key.Curve.Params().Name)
+		}
+		return Key{ID: id, Algorithm: ES256, public: key}, nil
+	default:
+		return Key{}, fmt.Errorf("unsupported public key type %T", public)
+	}
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// ParseJWKS reads the keys of a JSON Web Key Set. RSA, P-256 and symmetric
+// keys are supported; keys meant for encryption are skipped.
+func ParseJWKS(data []byte) ([]Key, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make([]Key, 0, len(set.Keys))
+	for i, jwk := range set.Keys {
+		if jwk.Use == "enc" {
+			continue
+		}
+		key, err := jwk.toKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %d: %w", i, err)
+		}
+		if jwk.Alg != "" && jwk.Alg != key.Algorithm {
+			return nil, fmt.Errorf("key %d: algorithm %s does not match key type %s", i, jwk.Alg, jwk.Kty)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (jwk jsonWebKey) toKey() (Key, error) {
+	switch jwk.Kty {
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(jwk.K)
+		if err != nil || len(secret) == 0 {
+			return Key{}, errors.New("invalid symmetric key")
+		}
+		return NewHMACKey(jwk.Kid, secret), nil
+	case "RSA":
+		n, errN := decodeBigInt(jwk.N)
+		e, errE := decodeBigInt(jwk.E)
+		if errN != nil || errE != nil || !e.IsInt64() {
+			return Key{}, errors.New("invalid RSA key")
+		}
+		return publicKey(jwk.Kid, &rsa.PublicKey{N: n, E: int(e.Int64())})
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return Key{}, fmt.Errorf("unsupported curve %s", jwk.Crv)
+		}
+		x, errX := decodeBigInt(jwk.X)
+		y, errY := decodeBigInt(jwk.Y)
+		if errX != nil || errY != nil || !elliptic.P256().IsOnCurve(x, y) {
+			return Key{}, errors.New("invalid EC key")
+		}
+		return publicKey(jwk.Kid, &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y})
+	default:
+		return Key{}, fmt.Errorf("unsupported key type %q", jwk.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("invalid number")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// LoadKeys gathers the keys configured for the API: a shared HS256 secret, a
+// PEM public key file and a JWKS file, each optional. At least one must be
+// given.
+func LoadKeys(secret string, publicKeyFile string, jwksFile string) ([]Key, error) {
+	var keys []Key
+	if secret != "" {
+		keys = append(keys, NewHMACKey("", []byte(secret)))
+	}
+	if publicKeyFile != "" {
+		data, err := os.ReadFile(publicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ParsePublicKeyPEM("", data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", publicKeyFile, err)
+		}
+		keys = append(keys, key)
+	}
+	if jwksFile != "" {
+		data, err := os.ReadFile(jwksFile)
+		if err != nil {
+			return nil, err
+		}
+		set, err := ParseJWKS(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", jwksFile, err)
+		}
+		keys = append(keys, set...)
+	}
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+	return keys, nil
+}
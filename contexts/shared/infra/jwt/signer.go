@@ -0,0 +1,42 @@
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrNoSigningKey means no secret was configured to sign tokens with.
+var ErrNoSigningKey = errors.New("no token signing key configured")
+
+// Signer issues HS256 tokens, which a Verifier given the same secret accepts.
+type Signer struct {
+	secret []byte
+}
+
+func NewSigner(secret []byte) *Signer {
+	return &Signer{secret: secret}
+}
+
+// Sign returns a compact token carrying claims. Claims are not checked; the
+// caller sets sub, exp and whatever else the token must state.
+func (s *Signer) Sign(claims map[string]interface{}) (string, error) {
+	if len(s.secret) == 0 {
+		return "", ErrNoSigningKey
+	}
+	headerJSON, err := json.Marshal(map[string]string{"alg": HS256, "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
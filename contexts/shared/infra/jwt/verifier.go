@@ -0,0 +1,238 @@
+// Package jwt verifies compact JSON Web Tokens signed with HS256, RS256 or
+// ES256 and turns their claims into the principal of a request.
+package jwt
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+)
+
+var (
+	ErrMalformedToken       = errors.New("token is malformed")
+	ErrUnsupportedAlgorithm = errors.New("token algorithm is not supported")
+	ErrInvalidSignature     = errors.New("token signature is invalid")
+	ErrTokenExpired         = errors.New("token has expired")
+	ErrTokenNotYetValid     = errors.New("token is not valid yet")
+	ErrInvalidIssuer        = errors.New("token issuer is not accepted")
+	ErrInvalidAudience      = errors.New("token audience is not accepted")
+)
+
+// Config lists what a token must state besides a valid signature. An empty
+// Issuer or Audience accepts any. Leeway allows for clock skew between the
+// issuer and the API when checking exp and nbf.
+type Config struct {
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+}
+
+type Verifier struct {
+	keys   []Key
+	config Config
+}
+
+func NewVerifier(keys []Key, config Config) *Verifier {
+	return &Verifier{keys: keys, config: config}
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verify checks the signature of token and the exp, nbf, iss and aud claims,
+// and returns the principal it describes, with the roles and scopes it was
+// granted. Tokens must carry sub and exp.
+func (v *Verifier) Verify(token string) (request_context.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return request_context.Principal{}, ErrMalformedToken
+	}
+
+	var head header
+	if err := decodeSegment(parts[0], &head); err != nil {
+		return request_context.Principal{}, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return request_context.Principal{}, ErrMalformedToken
+	}
+	if err := v.verifySignature(head, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return request_context.Principal{}, err
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return request_context.Principal{}, err
+	}
+	return v.principal(claims)
+}
+
+func (v *Verifier) verifySignature(head header, signed, signature []byte) error {
+	if head.Alg != HS256 && head.Alg != RS256 && head.Alg != ES256 {
+		return fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, head.Alg)
+	}
+	// The algorithm of the key decides how the signature is checked, so a
+	// token cannot pass a public key off as an HMAC secret.
+	for _, key := range v.keys {
+		if key.Algorithm != head.Alg || (key.ID != "" && key.ID != head.Kid) {
+			continue
+		}
+		if key.verify(signed, signature) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+func (k Key) verify(signed, signature []byte) bool {
+	digest := sha256.Sum256(signed)
+	switch k.Algorithm {
+	case HS256:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(signed)
+		return hmac.Equal(mac.Sum(nil), signature)
+	case RS256:
+		return rsa.VerifyPKCS1v15(k.public.(*rsa.PublicKey), crypto.SHA256, digest[:], signature) == nil
+	case ES256:
+		if len(signature) != 64 {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		return ecdsa.Verify(k.public.(*ecdsa.PublicKey), digest[:], r, s)
+	default:
+		return false
+	}
+}
+
+func (v *Verifier) principal(claims map[string]interface{}) (request_context.Principal, error) {
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return request_context.Principal{}, fmt.Errorf("%w: missing sub", ErrMalformedToken)
+	}
+	expiresAt, ok, err := numericDate(claims, "exp")
+	if err != nil || !ok {
+		return request_context.Principal{}, fmt.Errorf("%w: missing or invalid exp", ErrMalformedToken)
+	}
+	notBefore, hasNotBefore, err := numericDate(claims, "nbf")
+	if err != nil {
+		return request_context.Principal{}, fmt.Errorf("%w: invalid nbf", ErrMalformedToken)
+	}
+	audience, err := stringListClaim(claims, "aud")
+	if err != nil {
+		return request_context.Principal{}, err
+	}
+	roles, err := stringListClaim(claims, "roles")
+	if err != nil {
+		return request_context.Principal{}, err
+	}
+	scopes, err := scopeClaim(claims)
+	if err != nil {
+		return request_context.Principal{}, err
+	}
+	issuer, _ := claims["iss"].(string)
+
+	now := time.Now()
+	if !now.Before(expiresAt.Add(v.config.Leeway)) {
+		return request_context.Principal{}, ErrTokenExpired
+	}
+	if hasNotBefore && now.Add(v.config.Leeway).Before(notBefore) {
+		return request_context.Principal{}, ErrTokenNotYetValid
+	}
+	if v.config.Issuer != "" && issuer != v.config.Issuer {
+		return request_context.Principal{}, ErrInvalidIssuer
+	}
+	if v.config.Audience != "" && !slices.Contains(audience, v.config.Audience) {
+		return request_context.Principal{}, ErrInvalidAudience
+	}
+
+	return request_context.Principal{
+		Subject:   subject,
+		Issuer:    issuer,
+		Audience:  audience,
+		ExpiresAt: expiresAt,
+		Roles:     roles,
+		Scopes:    scopes,
+		Claims:    claims,
+	}, nil
+}
+
+func numericDate(claims map[string]interface{}, name string) (time.Time, bool, error) {
+	value, ok := claims[name]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	number, ok := value.(json.Number)
+	if !ok {
+		return time.Time{}, false, ErrMalformedToken
+	}
+	seconds, err := number.Float64()
+	if err != nil {
+		return time.Time{}, false, ErrMalformedToken
+	}
+	return time.Unix(int64(seconds), 0), true, nil
+}
+
+// stringListClaim reads a claim that may be a single string or a list of
+// them, such as aud.
+func stringListClaim(claims map[string]interface{}, name string) ([]string, error) {
+	switch value := claims[name].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{value}, nil
+	case []interface{}:
+		list := make([]string, len(value))
+		for i, item := range value {
+			text, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: invalid %s", ErrMalformedToken, name)
+			}
+			list[i] = text
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("%w: invalid %s", ErrMalformedToken, name)
+	}
+}
+
+// scopeClaim reads the OAuth scope claim, a space-delimited string, or scp,
+// the list some issuers send instead.
+func scopeClaim(claims map[string]interface{}) ([]string, error) {
+	if scope, ok := claims["scope"]; ok {
+		text, ok := scope.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid scope", ErrMalformedToken)
+		}
+		return strings.Fields(text), nil
+	}
+	return stringListClaim(claims, "scp")
+}
+
+func decodeSegment(segment string, target interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return ErrMalformedToken
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(target); err != nil {
+		return ErrMalformedToken
+	}
+	return nil
+}
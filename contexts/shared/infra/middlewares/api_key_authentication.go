@@ -0,0 +1,39 @@
+package middlewares
+
+import (
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticationMiddleware identifies server-to-server clients from an
+// X-API-Key header, the same way AuthenticationMiddleware does for bearer
+// tokens. A request with an invalid key, or with both a key and a token, is
+// refused; one without a key is left to the other middlewares.
+//
+// It must run after AuthenticationMiddleware and before IdempotencyMiddleware.
+func APIKeyAuthenticationMiddleware(verifier interfaces.APIKeyVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if _, ok := request_context.PrincipalFrom(c.Request.Context()); ok {
+			handleErrorResponse(c, shared_handlers.ErrConflictingCredentials)
+			return
+		}
+
+		principal, err := verifier.Verify(c.Request.Context(), key)
+		if err != nil {
+			handleErrorResponse(c, err)
+			return
+		}
+
+		c.Request = c.Request.WithContext(request_context.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
@@ -0,0 +1,59 @@
+package middlewares
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin"
+)
+
+const bearerScheme = "Bearer"
+
+// AuthenticationMiddleware identifies the caller from an Authorization: Bearer
+// header and stores the principal in the request context. A request with an
+// invalid token is refused; one without credentials goes on anonymously, so
+// RequireAuthentication decides which routes need a caller.
+//
+// It must run before IdempotencyMiddleware so that idempotency keys are kept
+// per caller.
+func AuthenticationMiddleware(verifier interfaces.TokenVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authorization := c.GetHeader("Authorization")
+		if authorization == "" {
+			c.Next()
+			return
+		}
+
+		scheme, token, found := strings.Cut(authorization, " ")
+		if !found || !strings.EqualFold(scheme, bearerScheme) || token == "" {
+			c.Header("WWW-Authenticate", fmt.Sprintf(`%s error="invalid_request"`, bearerScheme))
+			handleErrorResponse(c, shared_handlers.ErrInvalidToken)
+			return
+		}
+		principal, err := verifier.Verify(strings.TrimSpace(token))
+		if err != nil {
+			c.Header("WWW-Authenticate", fmt.Sprintf(`%s error="invalid_token", error_description=%q`, bearerScheme, err.Error()))
+			handleErrorResponse(c, shared_handlers.ErrInvalidToken)
+			return
+		}
+
+		c.Request = c.Request.WithContext(request_context.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// RequireAuthentication refuses requests that AuthenticationMiddleware did not
+// identify a caller for.
+func RequireAuthentication() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := request_context.PrincipalFrom(c.Request.Context()); !ok {
+			c.Header("WWW-Authenticate", bearerScheme)
+			handleErrorResponse(c, shared_handlers.ErrUnauthenticated)
+			return
+		}
+		c.Next()
+	}
+}
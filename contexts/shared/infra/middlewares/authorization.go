@@ -0,0 +1,35 @@
+package middlewares
+
+import (
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	"github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission refuses requests whose caller does not hold permission,
+// with 401 when nobody was identified and 403 otherwise.
+func RequirePermission(permission models.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := authorization.Authorize(c.Request.Context(), permission); err != nil {
+			handleErrorResponse(c, err)
+			return
+		}
+		c.Next()
+	}
+}
+
+// Route is an endpoint together with the permission needed to call it.
+type Route struct {
+	Method     string
+	Path       string
+	Permission models.Permission
+	Handler    gin.HandlerFunc
+}
+
+// RegisterRoutes adds routes to router, each behind RequirePermission for
+// its permission.
+func RegisterRoutes(router gin.IRoutes, routes []Route) {
+	for _, route := range routes {
+		router.Handle(route.Method, route.Path, RequirePermission(route.Permission), route.Handler)
+	}
+}
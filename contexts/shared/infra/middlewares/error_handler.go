@@ -1,8 +1,6 @@
 package middlewares
 
 import (
-	"net/http"
-
 	"github.com/Akiles94/go-test-api/contexts/shared/application/shared_dto"
 	"github.com/Akiles94/go-test-api/contexts/shared/domain/models"
 	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
@@ -11,8 +9,6 @@ import (
 
 const ErrorKey = "middleware_error"
 
-const defaultDomainErrorStatus = http.StatusBadRequest
-
 func ErrorHandlerMiddleware() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
 		c.Next()
@@ -43,30 +39,21 @@ func ErrorHandlerMiddleware() gin.HandlerFunc {
 	})
 }
 
+// handleErrorResponse writes err as the response and aborts the request. Every
+// error response of the API goes through it. Clients that accept
+// application/problem+json get an RFC 7807 problem; the rest keep getting an
+// ErrorResponse.
 func handleErrorResponse(c *gin.Context, err error) {
-	statusCode := getStatusCodeFromError(err)
-	var errorResponse shared_dto.ErrorResponse
-
-	if _, ok := err.(models.DomainError); ok {
-		errorResponse = shared_dto.FromDomainError(err)
+	statusCode := shared_handlers.StatusCodeFromError(err)
+	errorResponse := shared_dto.FromError(err)
+
+	if c.NegotiateFormat(gin.MIMEJSON, shared_dto.ProblemContentType) == shared_dto.ProblemContentType {
+		problem := shared_dto.NewProblemDetails(errorResponse, statusCode, c.Request.URL.RequestURI(), c.GetString("request_id"))
+		c.Header("Content-Type", shared_dto.ProblemContentType)
+		c.JSON(statusCode, problem)
+	} else {
+		c.Header("Content-Type", "application/json")
+		c.JSON(statusCode, errorResponse)
 	}
-	if _, ok := err.(shared_handlers.InfraError); ok {
-		errorResponse = shared_dto.FromInfraError(err)
-	}
-
-	c.Header("Content-Type", "application/json")
-	c.JSON(statusCode, errorResponse)
 	c.Abort()
 }
-
-func getStatusCodeFromError(err error) int {
-	if _, ok := err.(models.DomainError); ok {
-		return defaultDomainErrorStatus
-	}
-	if infraErr, ok := err.(shared_handlers.InfraError); ok {
-		if statusCode, exists := shared_handlers.InfraErrorStatusMap[shared_handlers.ErrorCode(infraErr.Code)]; exists {
-			return statusCode
-		}
-	}
-	return http.StatusInternalServerError
-}
@@ -0,0 +1,150 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	maxIdempotentRequestBytes = 16 << 20
+)
+
+// IdempotencyMiddleware makes POST requests that carry an Idempotency-Key
+// header safe to retry. The first request with a key is processed and its
+// response stored; later requests with the same key and the same method, URL
+// and body get that response replayed instead of being processed again. A
+// key reused for a different request is refused, and so is a repeat that
+// arrives while the first request is still running. Server errors are not
+// stored, so the request can be retried. Keys expire ttl after their first
+// use. A key whose request has not finished lockTimeout after it started,
+// e.g. because the instance running it died, is given to the next request
+// with it, so lockTimeout should exceed the longest request.
+//
+// Responses are stored verbatim, so routes under any of secretRoutes, whose
+// responses carry secrets such as API keys or tokens, ignore the header and
+// are processed every time.
+//
+// It must run before ErrorHandlerMiddleware so that the error responses that
+// middleware writes are stored too.
+func IdempotencyMiddleware(store interfaces.IdempotencyStore, ttl time.Duration, lockTimeout time.Duration, secretRoutes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" || slices.ContainsFunc(secretRoutes, func(prefix string) bool {
+			return matchesRoutePrefix(c.Request.URL.Path, prefix)
+		}) {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			handleErrorResponse(c, shared_handlers.ErrInvalidIdempotencyKey)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentRequestBytes))
+		if err != nil {
+			handleErrorResponse(c, shared_handlers.ErrInvalidPayload)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := requestFingerprint(c.Request, body)
+
+		now := time.Now()
+		record, err := store.Reserve(c.Request.Context(), key, fingerprint, now.Add(lockTimeout), now.Add(ttl))
+		if err != nil {
+			handleErrorResponse(c, err)
+			return
+		}
+		if record != nil {
+			switch {
+			case record.Fingerprint != fingerprint:
+				handleErrorResponse(c, shared_handlers.ErrIdempotencyKeyReused)
+			case record.Response == nil:
+				handleErrorResponse(c, shared_handlers.ErrIdempotencyKeyInProgress)
+			default:
+				replayResponse(c, *record.Response)
+			}
+			return
+		}
+
+		writer := &recordingResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		// The outcome is stored even if the client went away, which is
+		// precisely when it will retry.
+		ctx := context.WithoutCancel(c.Request.Context())
+		if writer.Status() >= http.StatusInternalServerError {
+			if err := store.Release(ctx, key); err != nil {
+				log.Printf("⚠️  Idempotency key release failed: %v", err)
+			}
+			return
+		}
+		header := writer.Header().Clone()
+		header.Del("X-Request-ID")
+		response := interfaces.IdempotentResponse{
+			StatusCode: writer.Status(),
+			Header:     header,
+			Body:       writer.body.Bytes(),
+		}
+		if err := store.Complete(ctx, key, response); err != nil {
+			log.Printf("⚠️  Idempotent response could not be stored: %v", err)
+			if err := store.Release(ctx, key); err != nil {
+				log.Printf("⚠️  Idempotency key release failed: %v", err)
+			}
+		}
+	}
+}
+
+// requestFingerprint identifies a request by caller, tenant, method, URL and
+// body, so that a key cannot be replayed by someone else, in another tenant,
+// against another endpoint or with another payload.
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(request_context.ActorFrom(r.Context()) + "\n"))
+	hash.Write([]byte(request_context.TenantFrom(r.Context()) + "\n"))
+	hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func replayResponse(c *gin.Context, response interfaces.IdempotentResponse) {
+	for name, values := range response.Header {
+		c.Writer.Header()[name] = values
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Status(response.StatusCode)
+	c.Writer.WriteHeaderNow()
+	_, _ = c.Writer.Write(response.Body)
+	c.Abort()
+}
+
+// recordingResponseWriter keeps a copy of the body written to the client.
+type recordingResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingResponseWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingResponseWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,98 @@
+package middlewares_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/shared_dto"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/middlewares"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type APIKeyAuthenticationMiddlewareTestSuite struct {
+	suite.Suite
+	tokenVerifier  *interfaces_mocks.MockTokenVerifier
+	apiKeyVerifier *interfaces_mocks.MockAPIKeyVerifier
+	router         *gin.Engine
+}
+
+func (suite *APIKeyAuthenticationMiddlewareTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *APIKeyAuthenticationMiddlewareTestSuite) SetupTest() {
+	suite.tokenVerifier = interfaces_mocks.NewMockTokenVerifier()
+	suite.apiKeyVerifier = interfaces_mocks.NewMockAPIKeyVerifier()
+	suite.router = gin.New()
+	suite.router.Use(middlewares.ErrorHandlerMiddleware())
+	suite.router.Use(middlewares.AuthenticationMiddleware(suite.tokenVerifier))
+	suite.router.Use(middlewares.APIKeyAuthenticationMiddleware(suite.apiKeyVerifier))
+	suite.router.GET("/private", middlewares.RequireAuthentication(), func(c *gin.Context) {
+		c.String(http.StatusOK, request_context.ActorFrom(c.Request.Context()))
+	})
+}
+
+func (suite *APIKeyAuthenticationMiddlewareTestSuite) get(apiKey, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/private", nil)
+	if apiKey != "" {
+		req.Header.Set(middlewares.APIKeyHeader, apiKey)
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *APIKeyAuthenticationMiddlewareTestSuite) TestAPIKeyAuthentication() {
+	suite.Run("should place the principal of the key in the request context", func() {
+		// Arrange
+		suite.apiKeyVerifier.SetupVerifySuccess("gta_good", request_context.Principal{Subject: "api-key:1"})
+
+		// Act
+		w := suite.get("gta_good", "")
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Equal("api-key:1", w.Body.String())
+	})
+
+	suite.Run("should refuse an invalid key", func() {
+		// Arrange
+		suite.apiKeyVerifier.SetupVerifyError("gta_revoked", shared_handlers.ErrInvalidAPIKey)
+
+		// Act
+		w := suite.get("gta_revoked", "")
+
+		// Assert
+		suite.Equal(http.StatusUnauthorized, w.Code)
+		var response shared_dto.ErrorResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal("UNAUTHORIZED", response.Error)
+		suite.Equal(shared_handlers.ErrInvalidAPIKey.Message, response.Message)
+	})
+
+	suite.Run("should refuse a request with both a key and a token", func() {
+		// Arrange
+		suite.tokenVerifier.SetupVerifySuccess("good-token", request_context.Principal{Subject: "user-42"})
+
+		// Act
+		w := suite.get("gta_other", "Bearer good-token")
+
+		// Assert
+		suite.Equal(http.StatusUnauthorized, w.Code)
+		suite.apiKeyVerifier.AssertNotCalled(suite.T(), "Verify", mock.Anything, "gta_other")
+	})
+}
+
+func TestAPIKeyAuthenticationMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyAuthenticationMiddlewareTestSuite))
+}
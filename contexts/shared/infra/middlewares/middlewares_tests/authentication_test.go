@@ -0,0 +1,109 @@
+package middlewares_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/shared_dto"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/jwt"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/middlewares"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthenticationMiddlewareTestSuite struct {
+	suite.Suite
+	verifier *interfaces_mocks.MockTokenVerifier
+	router   *gin.Engine
+}
+
+func (suite *AuthenticationMiddlewareTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *AuthenticationMiddlewareTestSuite) SetupTest() {
+	suite.verifier = interfaces_mocks.NewMockTokenVerifier()
+	suite.router = gin.New()
+	suite.router.Use(middlewares.ErrorHandlerMiddleware())
+	suite.router.Use(middlewares.AuthenticationMiddleware(suite.verifier))
+	suite.router.GET("/public", func(c *gin.Context) {
+		c.String(http.StatusOK, request_context.ActorFrom(c.Request.Context()))
+	})
+	suite.router.GET("/private", middlewares.RequireAuthentication(), func(c *gin.Context) {
+		c.String(http.StatusOK, request_context.ActorFrom(c.Request.Context()))
+	})
+}
+
+func (suite *AuthenticationMiddlewareTestSuite) get(path, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *AuthenticationMiddlewareTestSuite) TestAuthentication() {
+	suite.Run("should place the principal in the request context", func() {
+		// Arrange
+		suite.verifier.SetupVerifySuccess("good-token", request_context.Principal{Subject: "user-42"})
+
+		// Act
+		w := suite.get("/private", "Bearer good-token")
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Equal("user-42", w.Body.String())
+	})
+
+	suite.Run("should refuse an invalid token", func() {
+		// Arrange
+		suite.verifier.SetupVerifyError("expired-token", jwt.ErrTokenExpired)
+
+		// Act
+		w := suite.get("/public", "Bearer expired-token")
+
+		// Assert
+		suite.Equal(http.StatusUnauthorized, w.Code)
+		suite.Contains(w.Header().Get("WWW-Authenticate"), `error="invalid_token"`)
+		var response shared_dto.ErrorResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal("UNAUTHORIZED", response.Error)
+	})
+
+	suite.Run("should refuse credentials of another scheme", func() {
+		// Act
+		w := suite.get("/public", "Basic dXNlcjpwYXNz")
+
+		// Assert
+		suite.Equal(http.StatusUnauthorized, w.Code)
+		suite.verifier.AssertNotCalled(suite.T(), "Verify", "dXNlcjpwYXNz")
+	})
+
+	suite.Run("should let anonymous requests through to public routes", func() {
+		// Act
+		w := suite.get("/public", "")
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Equal(request_context.AnonymousActor, w.Body.String())
+	})
+
+	suite.Run("should refuse anonymous requests to private routes", func() {
+		// Act
+		w := suite.get("/private", "")
+
+		// Assert
+		suite.Equal(http.StatusUnauthorized, w.Code)
+		suite.Equal("Bearer", w.Header().Get("WWW-Authenticate"))
+	})
+}
+
+func TestAuthenticationMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthenticationMiddlewareTestSuite))
+}
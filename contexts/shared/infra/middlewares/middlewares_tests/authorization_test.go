@@ -0,0 +1,94 @@
+package middlewares_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/shared_dto"
+	"github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/middlewares"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthorizationMiddlewareTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func (suite *AuthorizationMiddlewareTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *AuthorizationMiddlewareTestSuite) SetupTest() {
+	suite.router = gin.New()
+	suite.router.Use(middlewares.ErrorHandlerMiddleware())
+	suite.router.Use(func(c *gin.Context) {
+		if role := c.GetHeader("X-Test-Role"); role != "" {
+			principal := request_context.Principal{Subject: "tester", Roles: []string{role}}
+			c.Request = c.Request.WithContext(request_context.WithPrincipal(c.Request.Context(), principal))
+		}
+		c.Next()
+	})
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	middlewares.RegisterRoutes(suite.router, []middlewares.Route{
+		{Method: http.MethodGet, Path: "/products", Permission: models.PermissionProductsRead, Handler: ok},
+		{Method: http.MethodPost, Path: "/products", Permission: models.PermissionProductsWrite, Handler: ok},
+		{Method: http.MethodDelete, Path: "/products", Permission: models.PermissionProductsDelete, Handler: ok},
+	})
+}
+
+func (suite *AuthorizationMiddlewareTestSuite) request(method string, role models.Role) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/products", nil)
+	if role != "" {
+		req.Header.Set("X-Test-Role", string(role))
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *AuthorizationMiddlewareTestSuite) TestRegisterRoutes() {
+	cases := []struct {
+		name     string
+		method   string
+		role     models.Role
+		expected int
+	}{
+		{"let viewers read", http.MethodGet, models.RoleViewer, http.StatusNoContent},
+		{"forbid viewers to write", http.MethodPost, models.RoleViewer, http.StatusForbidden},
+		{"let editors write", http.MethodPost, models.RoleEditor, http.StatusNoContent},
+		{"forbid editors to delete", http.MethodDelete, models.RoleEditor, http.StatusForbidden},
+		{"let admins delete", http.MethodDelete, models.RoleAdmin, http.StatusNoContent},
+		{"forbid unknown roles to read", http.MethodGet, models.Role("guest"), http.StatusForbidden},
+		{"refuse anonymous callers", http.MethodGet, "", http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		suite.Run("should "+tc.name, func() {
+			// Act
+			w := suite.request(tc.method, tc.role)
+
+			// Assert
+			suite.Equal(tc.expected, w.Code)
+		})
+	}
+
+	suite.Run("should name the missing permission", func() {
+		// Act
+		w := suite.request(http.MethodDelete, models.RoleViewer)
+
+		// Assert
+		var response shared_dto.ErrorResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal("FORBIDDEN", response.Error)
+		suite.Equal("products:delete", response.Details["required_permission"])
+	})
+}
+
+func TestAuthorizationMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthorizationMiddlewareTestSuite))
+}
@@ -0,0 +1,148 @@
+package middlewares_tests
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/shared_dto"
+	"github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/middlewares"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrorHandlerMiddlewareTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func (suite *ErrorHandlerMiddlewareTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *ErrorHandlerMiddlewareTestSuite) SetupTest() {
+	suite.router = gin.New()
+	suite.router.Use(middlewares.RequestIDMiddleware())
+	suite.router.Use(middlewares.RecoveryMiddleware())
+	suite.router.Use(middlewares.ErrorHandlerMiddleware())
+	suite.router.GET("/missing", func(c *gin.Context) {
+		c.Error(shared_handlers.ErrNotFound)
+	})
+	suite.router.GET("/invalid", func(c *gin.Context) {
+		c.Error(shared_handlers.ErrInvalidPayload.WithFieldViolations(models.FieldViolation{
+			Field:   "name",
+			Rule:    "required",
+			Value:   "",
+			Message: "name is required",
+		}))
+	})
+	suite.router.GET("/failing", func(c *gin.Context) {
+		c.Error(errors.New("connection refused"))
+	})
+	suite.router.GET("/panicking", func(c *gin.Context) {
+		panic("boom")
+	})
+	suite.router.GET("/aborting", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+		panic(http.ErrAbortHandler)
+	})
+}
+
+func (suite *ErrorHandlerMiddlewareTestSuite) get(path, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	req.Header.Set("X-Request-ID", "req-1")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *ErrorHandlerMiddlewareTestSuite) TestLegacyFormat() {
+	suite.Run("should keep ErrorResponse for clients that do not ask for problems", func() {
+		// Act
+		w := suite.get("/missing", "application/json")
+
+		// Assert
+		suite.Equal(http.StatusNotFound, w.Code)
+		suite.Equal("application/json", w.Header().Get("Content-Type"))
+		var response shared_dto.ErrorResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(shared_dto.ErrorResponse{Error: "NOT_FOUND", Message: "Resource not found"}, response)
+	})
+
+	suite.Run("should hide the message of unexpected errors", func() {
+		// Act
+		w := suite.get("/failing", "")
+
+		// Assert
+		suite.Equal(http.StatusInternalServerError, w.Code)
+		var response shared_dto.ErrorResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal("INTERNAL_ERROR", response.Error)
+		suite.NotContains(w.Body.String(), "connection refused")
+	})
+}
+
+func (suite *ErrorHandlerMiddlewareTestSuite) TestProblemFormat() {
+	suite.Run("should render a problem when the client accepts one", func() {
+		// Act
+		w := suite.get("/missing?verbose=1", "application/problem+json")
+
+		// Assert
+		suite.Equal(http.StatusNotFound, w.Code)
+		suite.Equal(shared_dto.ProblemContentType, w.Header().Get("Content-Type"))
+		var problem shared_dto.ProblemDetails
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &problem))
+		suite.Equal(shared_dto.ProblemDetails{
+			Type:      "/problems/not-found",
+			Title:     "Not Found",
+			Status:    http.StatusNotFound,
+			Detail:    "Resource not found",
+			Instance:  "/missing?verbose=1",
+			RequestID: "req-1",
+			Code:      "NOT_FOUND",
+		}, problem)
+	})
+
+	suite.Run("should carry the error details as an extension", func() {
+		// Act
+		w := suite.get("/invalid", "application/problem+json")
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+		var problem shared_dto.ProblemDetails
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &problem))
+		suite.Equal("/problems/bad-request", problem.Type)
+		suite.Contains(problem.Details, "name")
+	})
+
+	suite.Run("should render recovered panics as problems", func() {
+		// Act
+		w := suite.get("/panicking", "application/problem+json")
+
+		// Assert
+		suite.Equal(http.StatusInternalServerError, w.Code)
+		var problem shared_dto.ProblemDetails
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &problem))
+		suite.Equal("INTERNAL_ERROR", problem.Code)
+		suite.Equal("req-1", problem.RequestID)
+	})
+
+	suite.Run("should let net/http drop the connection of aborted handlers", func() {
+		// Act
+		get := func() { suite.get("/aborting", "") }
+
+		// Assert
+		suite.PanicsWithValue(http.ErrAbortHandler, get)
+	})
+}
+
+func TestErrorHandlerMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(ErrorHandlerMiddlewareTestSuite))
+}
@@ -0,0 +1,243 @@
+package middlewares_tests
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/shared_dto"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/middlewares"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const testIdempotencyTTL = time.Hour
+const testIdempotencyLockTimeout = time.Minute
+
+type IdempotencyMiddlewareTestSuite struct {
+	suite.Suite
+	store  *interfaces_mocks.MockIdempotencyStore
+	router *gin.Engine
+	calls  int
+	// fingerprint is the one sent by the last request that reached Reserve
+	fingerprint string
+}
+
+func (suite *IdempotencyMiddlewareTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *IdempotencyMiddlewareTestSuite) SetupTest() {
+	suite.store = interfaces_mocks.NewMockIdempotencyStore()
+	suite.calls = 0
+	suite.router = gin.New()
+	suite.router.Use(middlewares.IdempotencyMiddleware(suite.store, testIdempotencyTTL, testIdempotencyLockTimeout, "/api-keys"))
+	suite.router.Use(middlewares.ErrorHandlerMiddleware())
+	suite.router.POST("/products", func(c *gin.Context) {
+		suite.calls++
+		c.Header("ETag", `"1"`)
+		c.JSON(http.StatusCreated, gin.H{"sku": "TEST-001"})
+	})
+	suite.router.POST("/api-keys/:id/rotate", func(c *gin.Context) {
+		suite.calls++
+		c.JSON(http.StatusOK, gin.H{"key": "gta_secret"})
+	})
+	suite.router.POST("/failing", func(c *gin.Context) {
+		suite.calls++
+		c.Error(errors.New("database is down"))
+	})
+	suite.router.POST("/invalid", func(c *gin.Context) {
+		suite.calls++
+		c.Error(shared_handlers.ErrInvalidPayload)
+	})
+}
+
+func (suite *IdempotencyMiddlewareTestSuite) post(path string, key string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(middlewares.IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+// recordFingerprint makes the fingerprint passed to Reserve available to the
+// test, so that a stored record can claim to come from the same request.
+func (suite *IdempotencyMiddlewareTestSuite) recordFingerprint(call *mock.Call) *mock.Call {
+	return call.Run(func(args mock.Arguments) {
+		suite.fingerprint = args.String(2)
+	})
+}
+
+func (suite *IdempotencyMiddlewareTestSuite) TestIdempotencyMiddleware() {
+	suite.Run("should process a new key and store the response", func() {
+		// Arrange
+		suite.SetupTest()
+		before := time.Now()
+		suite.store.On("Reserve", mock.Anything, "key-1", mock.Anything, mock.MatchedBy(func(lockedUntil time.Time) bool {
+			return !lockedUntil.Before(before.Add(testIdempotencyLockTimeout)) && lockedUntil.Before(before.Add(testIdempotencyTTL))
+		}), mock.MatchedBy(func(expiresAt time.Time) bool {
+			return !expiresAt.Before(before.Add(testIdempotencyTTL))
+		})).Return(nil, nil).Once()
+		suite.store.On("Complete", mock.Anything, "key-1", mock.MatchedBy(func(response interfaces.IdempotentResponse) bool {
+			return response.StatusCode == http.StatusCreated &&
+				string(response.Body) == `{"sku":"TEST-001"}` &&
+				response.Header["Etag"][0] == `"1"`
+		})).Return(nil).Once()
+
+		// Act
+		w := suite.post("/products", "key-1", `{"sku":"TEST-001"}`)
+
+		// Assert
+		suite.Equal(http.StatusCreated, w.Code)
+		suite.Equal(1, suite.calls)
+		suite.Empty(w.Header().Get(middlewares.IdempotentReplayedHeader))
+		suite.store.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should replay the stored response for a repeated request", func() {
+		// Arrange
+		suite.SetupTest()
+		suite.recordFingerprint(suite.store.SetupReserveFree("key-1")).Once()
+		suite.store.On("Complete", mock.Anything, "key-1", mock.Anything).Return(nil).Once()
+		first := suite.post("/products", "key-1", `{"sku":"TEST-001"}`)
+		suite.store.SetupReserveTaken("key-1", interfaces.IdempotencyRecord{
+			Fingerprint: suite.fingerprint,
+			Response: &interfaces.IdempotentResponse{
+				StatusCode: first.Code,
+				Header:     first.Header(),
+				Body:       first.Body.Bytes(),
+			},
+		}).Once()
+
+		// Act
+		w := suite.post("/products", "key-1", `{"sku":"TEST-001"}`)
+
+		// Assert
+		suite.Equal(http.StatusCreated, w.Code)
+		suite.Equal(1, suite.calls)
+		suite.Equal(`{"sku":"TEST-001"}`, w.Body.String())
+		suite.Equal(`"1"`, w.Header().Get("ETag"))
+		suite.Equal("true", w.Header().Get(middlewares.IdempotentReplayedHeader))
+	})
+
+	suite.Run("should refuse a key reused with a different body", func() {
+		// Arrange
+		suite.SetupTest()
+		suite.store.SetupReserveTaken("key-1", interfaces.IdempotencyRecord{
+			Fingerprint: "another request",
+			Response:    &interfaces.IdempotentResponse{StatusCode: http.StatusCreated},
+		})
+
+		// Act
+		w := suite.post("/products", "key-1", `{"sku":"TEST-002"}`)
+
+		// Assert
+		suite.Equal(http.StatusUnprocessableEntity, w.Code)
+		var response shared_dto.ErrorResponse
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(shared_handlers.ErrIdempotencyKeyReused.Message, response.Message)
+		suite.Equal(0, suite.calls)
+	})
+
+	suite.Run("should refuse a repeat while the first request is running", func() {
+		// Arrange
+		suite.SetupTest()
+		suite.recordFingerprint(suite.store.SetupReserveFree("key-1")).Once()
+		suite.store.On("Complete", mock.Anything, "key-1", mock.Anything).Return(nil).Once()
+		suite.post("/products", "key-1", `{}`)
+		suite.store.SetupReserveTaken("key-1", interfaces.IdempotencyRecord{Fingerprint: suite.fingerprint}).Once()
+
+		// Act
+		w := suite.post("/products", "key-1", `{}`)
+
+		// Assert
+		suite.Equal(http.StatusConflict, w.Code)
+		suite.Equal(1, suite.calls)
+	})
+
+	suite.Run("should store client errors raised through the error handler", func() {
+		// Arrange
+		suite.SetupTest()
+		suite.store.SetupReserveFree("key-1")
+		suite.store.On("Complete", mock.Anything, "key-1", mock.MatchedBy(func(response interfaces.IdempotentResponse) bool {
+			return response.StatusCode == http.StatusBadRequest && strings.Contains(string(response.Body), shared_handlers.ErrInvalidPayload.Message)
+		})).Return(nil).Once()
+
+		// Act
+		w := suite.post("/invalid", "key-1", `{}`)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+		suite.store.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should release the key after a server error", func() {
+		// Arrange
+		suite.SetupTest()
+		suite.store.SetupReserveFree("key-1")
+		suite.store.On("Release", mock.Anything, "key-1").Return(nil).Once()
+
+		// Act
+		w := suite.post("/failing", "key-1", `{}`)
+
+		// Assert
+		suite.Equal(http.StatusInternalServerError, w.Code)
+		suite.store.AssertExpectations(suite.T())
+		suite.store.AssertNotCalled(suite.T(), "Complete", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	suite.Run("should pass requests without a key through", func() {
+		// Arrange
+		suite.SetupTest()
+
+		// Act
+		w := suite.post("/products", "", `{}`)
+
+		// Assert
+		suite.Equal(http.StatusCreated, w.Code)
+		suite.store.AssertNotCalled(suite.T(), "Reserve", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	suite.Run("should never store the responses of secret routes", func() {
+		// Arrange
+		suite.SetupTest()
+
+		// Act
+		first := suite.post("/api-keys/42/rotate", "key-1", `{}`)
+		second := suite.post("/api-keys/42/rotate", "key-1", `{}`)
+
+		// Assert
+		suite.Equal(http.StatusOK, first.Code)
+		suite.Equal(http.StatusOK, second.Code)
+		suite.Empty(second.Header().Get(middlewares.IdempotentReplayedHeader))
+		suite.Equal(2, suite.calls)
+		suite.store.AssertNotCalled(suite.T(), "Reserve", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	suite.Run("should refuse a key that is too long", func() {
+		// Arrange
+		suite.SetupTest()
+
+		// Act
+		w := suite.post("/products", strings.Repeat("k", 256), `{}`)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+		suite.Equal(0, suite.calls)
+	})
+}
+
+func TestIdempotencyMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyMiddlewareTestSuite))
+}
@@ -0,0 +1,232 @@
+package middlewares_tests
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/middlewares"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func rateLimitRouter(store interfaces.RateLimitStore, config middlewares.RateLimitConfig, principal *request_context.Principal) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.ErrorHandlerMiddleware())
+	if principal != nil {
+		router.Use(func(c *gin.Context) {
+			c.Request = c.Request.WithContext(request_context.WithPrincipal(c.Request.Context(), *principal))
+		})
+	}
+	router.Use(middlewares.RateLimitMiddleware(store, config))
+	router.NoRoute(func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func sendRequests(router *gin.Engine, path string, n int) *httptest.ResponseRecorder {
+	var w *httptest.ResponseRecorder
+	for i := 0; i < n; i++ {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	return w
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	config := middlewares.RateLimitConfig{
+		Default: middlewares.RateLimitPolicy{Limit: 3, Window: time.Hour},
+		Routes: map[string]middlewares.RateLimitPolicy{
+			"/api/v1/auth":       {Limit: 1, Window: time.Hour},
+			"/api/v1/auth/login": {Limit: 2, Window: time.Hour},
+		},
+		Callers: map[string]middlewares.RateLimitPolicy{
+			"api-key:batch": {Limit: 5, Window: time.Hour},
+		},
+	}
+
+	t.Run("should state the policy and what is left of it", func(t *testing.T) {
+		// Arrange
+		router := rateLimitRouter(ratelimit.NewMemoryRateLimitStore(), config, nil)
+
+		// Act
+		w := sendRequests(router, "/api/v1/products", 1)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "3", w.Header().Get(middlewares.RateLimitLimitHeader))
+		assert.Equal(t, "2", w.Header().Get(middlewares.RateLimitRemainingHeader))
+		assert.NotEmpty(t, w.Header().Get(middlewares.RateLimitResetHeader))
+	})
+
+	t.Run("should refuse requests over the limit with Retry-After", func(t *testing.T) {
+		// Arrange
+		router := rateLimitRouter(ratelimit.NewMemoryRateLimitStore(), config, nil)
+
+		// Act
+		w := sendRequests(router, "/api/v1/products", 4)
+
+		// Assert
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "0", w.Header().Get(middlewares.RateLimitRemainingHeader))
+		assert.Equal(t, w.Header().Get(middlewares.RateLimitResetHeader), w.Header().Get("Retry-After"))
+	})
+
+	t.Run("should apply the policy of the longest matching route prefix", func(t *testing.T) {
+		// Arrange
+		router := rateLimitRouter(ratelimit.NewMemoryRateLimitStore(), config, nil)
+
+		// Act
+		login := sendRequests(router, "/api/v1/auth/login", 2)
+		register := sendRequests(router, "/api/v1/auth/register", 2)
+		lookalike := sendRequests(router, "/api/v1/authors", 1)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, login.Code)
+		assert.Equal(t, "2", login.Header().Get(middlewares.RateLimitLimitHeader))
+		assert.Equal(t, http.StatusTooManyRequests, register.Code)
+		assert.Equal(t, "3", lookalike.Header().Get(middlewares.RateLimitLimitHeader))
+	})
+
+	t.Run("should count each route prefix separately", func(t *testing.T) {
+		// Arrange
+		router := rateLimitRouter(ratelimit.NewMemoryRateLimitStore(), config, nil)
+		sendRequests(router, "/api/v1/auth/register", 2)
+
+		// Act
+		w := sendRequests(router, "/api/v1/products", 1)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get(middlewares.RateLimitRemainingHeader))
+	})
+
+	t.Run("should apply the policy of a listed caller on every route", func(t *testing.T) {
+		// Arrange
+		principal := &request_context.Principal{Subject: "api-key:batch"}
+		router := rateLimitRouter(ratelimit.NewMemoryRateLimitStore(), config, principal)
+
+		// Act
+		w := sendRequests(router, "/api/v1/auth/login", 5)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "5", w.Header().Get(middlewares.RateLimitLimitHeader))
+		assert.Equal(t, "0", w.Header().Get(middlewares.RateLimitRemainingHeader))
+	})
+
+	t.Run("should count authenticated callers by subject", func(t *testing.T) {
+		// Arrange
+		store := interfaces_mocks.NewMockRateLimitStore()
+		store.On("Increment", mock.Anything, "default|sub:user-42", time.Hour).
+			Return(1, time.Now().Add(time.Hour), nil)
+		router := rateLimitRouter(store, config, &request_context.Principal{Subject: "user-42"})
+
+		// Act
+		w := sendRequests(router, "/api/v1/products", 1)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		store.AssertExpectations(t)
+	})
+
+	t.Run("should let requests through when the store fails", func(t *testing.T) {
+		// Arrange
+		store := interfaces_mocks.NewMockRateLimitStore()
+		store.On("Increment", mock.Anything, mock.Anything, mock.Anything).
+			Return(0, time.Time{}, errors.New("connection refused"))
+		router := rateLimitRouter(store, config, nil)
+
+		// Act
+		w := sendRequests(router, "/api/v1/products", 1)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get(middlewares.RateLimitLimitHeader))
+	})
+}
+
+func TestIPRateLimitMiddleware(t *testing.T) {
+	policy := middlewares.RateLimitPolicy{Limit: 2, Window: time.Hour}
+
+	t.Run("should count requests that authentication refuses", func(t *testing.T) {
+		// Arrange
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.Use(middlewares.IPRateLimitMiddleware(ratelimit.NewMemoryRateLimitStore(), policy))
+		router.Use(func(c *gin.Context) {
+			c.AbortWithStatus(http.StatusUnauthorized)
+		})
+
+		// Act
+		refused := sendRequests(router, "/api/v1/products", 2)
+		limited := sendRequests(router, "/api/v1/products", 1)
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, refused.Code)
+		assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	})
+
+	t.Run("should not trust X-Forwarded-For from clients that are not trusted proxies", func(t *testing.T) {
+		// Arrange
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		require.NoError(t, router.SetTrustedProxies(nil))
+		router.Use(middlewares.IPRateLimitMiddleware(ratelimit.NewMemoryRateLimitStore(), policy))
+		router.NoRoute(func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		// Act
+		var w *httptest.ResponseRecorder
+		for i := 0; i < 3; i++ {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil)
+			req.Header.Set("X-Forwarded-For", fmt.Sprintf("203.0.113.%d", i))
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+		}
+
+		// Assert
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+}
+
+func TestParseRateLimitPolicies(t *testing.T) {
+	t.Run("should read every policy", func(t *testing.T) {
+		// Act
+		policies, err := middlewares.ParseRateLimitPolicies(" /api/v1/auth=10/1m, /api/v1/products/import=5/30s,")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, map[string]middlewares.RateLimitPolicy{
+			"/api/v1/auth":            {Limit: 10, Window: time.Minute},
+			"/api/v1/products/import": {Limit: 5, Window: 30 * time.Second},
+		}, policies)
+	})
+
+	for name, spec := range map[string]string{
+		"missing name":     "=10/1m",
+		"missing window":   "/api/v1/auth=10",
+		"zero limit":       "/api/v1/auth=0/1m",
+		"too short window": "/api/v1/auth=10/10ms",
+	} {
+		t.Run("should reject a "+name, func(t *testing.T) {
+			// Act
+			_, err := middlewares.ParseRateLimitPolicies(spec)
+
+			// Assert
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package middlewares_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/middlewares"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func tenantRouter(principal *request_context.Principal) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.ErrorHandlerMiddleware())
+	if principal != nil {
+		router.Use(func(c *gin.Context) {
+			c.Request = c.Request.WithContext(request_context.WithPrincipal(c.Request.Context(), *principal))
+		})
+	}
+	router.Use(middlewares.TenantMiddleware("api.example.com"))
+	router.GET("/tenant", func(c *gin.Context) {
+		c.String(http.StatusOK, request_context.TenantFrom(c.Request.Context()))
+	})
+	return router
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	boundToAcme := &request_context.Principal{
+		Subject: "user-42",
+		Claims:  map[string]interface{}{request_context.TenantClaim: "acme"},
+	}
+	unbound := &request_context.Principal{Subject: "user-7"}
+
+	cases := []struct {
+		name      string
+		principal *request_context.Principal
+		host      string
+		header    string
+		status    int
+		tenant    string
+	}{
+		{"use the default tenant when none is named", nil, "localhost:8080", "", http.StatusOK, request_context.DefaultTenant},
+		{"use the tenant header", nil, "localhost:8080", "Globex", http.StatusOK, "globex"},
+		{"use the subdomain of the base domain", nil, "initech.api.example.com:443", "", http.StatusOK, "initech"},
+		{"ignore hosts outside the base domain", nil, "initech.example.org", "", http.StatusOK, request_context.DefaultTenant},
+		{"prefer the header over the subdomain", nil, "initech.api.example.com", "globex", http.StatusOK, "globex"},
+		{"use the tenant of the principal", boundToAcme, "localhost:8080", "", http.StatusOK, "acme"},
+		{"accept a header naming the principal's tenant", boundToAcme, "localhost:8080", "acme", http.StatusOK, "acme"},
+		{"refuse a header naming another tenant", boundToAcme, "localhost:8080", "globex", http.StatusForbidden, ""},
+		{"refuse a subdomain of another tenant", boundToAcme, "globex.api.example.com", "", http.StatusForbidden, ""},
+		{"pin a principal without a tenant to the default tenant", unbound, "localhost:8080", "", http.StatusOK, request_context.DefaultTenant},
+		{"accept a principal without a tenant naming the default tenant", unbound, "localhost:8080", "default", http.StatusOK, request_context.DefaultTenant},
+		{"refuse a principal without a tenant naming another tenant", unbound, "localhost:8080", "globex", http.StatusForbidden, ""},
+		{"refuse an invalid tenant", nil, "localhost:8080", "acme_corp", http.StatusBadRequest, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run("should "+tc.name, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(http.MethodGet, "/tenant", nil)
+			req.Host = tc.host
+			if tc.header != "" {
+				req.Header.Set(middlewares.TenantHeader, tc.header)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			tenantRouter(tc.principal).ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tc.status, w.Code)
+			if tc.status == http.StatusOK {
+				assert.Equal(t, tc.tenant, w.Body.String())
+			}
+		})
+	}
+}
@@ -1,63 +1,170 @@
 package middlewares
 
 import (
-	"net/http"
-	"sync"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/Akiles94/go-test-api/config"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
 	"github.com/gin-gonic/gin"
-	"golang.org/x/time/rate"
 )
 
-type IPRateLimiter struct {
-	ips           map[string]*rate.Limiter
-	mu            *sync.RWMutex
-	rateTime      rate.Limit
-	requestsCount int
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+)
+
+// RateLimitPolicy lets a caller make Limit requests per Window.
+type RateLimitPolicy struct {
+	Limit  int
+	Window time.Duration
+}
+
+// ParseRateLimitPolicy reads a policy written as <limit>/<window>, e.g.
+// 100/1m.
+func ParseRateLimitPolicy(spec string) (RateLimitPolicy, error) {
+	limit, window, found := strings.Cut(strings.TrimSpace(spec), "/")
+	if !found {
+		return RateLimitPolicy{}, fmt.Errorf("rate limit %q must be <limit>/<window>", spec)
+	}
+	policy := RateLimitPolicy{}
+	var err error
+	if policy.Limit, err = strconv.Atoi(limit); err != nil || policy.Limit <= 0 {
+		return RateLimitPolicy{}, fmt.Errorf("rate limit %q needs a positive limit", spec)
+	}
+	if policy.Window, err = time.ParseDuration(window); err != nil || policy.Window < time.Second {
+		return RateLimitPolicy{}, fmt.Errorf("rate limit %q needs a window of a second or more", spec)
+	}
+	return policy, nil
 }
 
-func NewIPRateLimiter(rateTime rate.Limit, requestsCount int) *IPRateLimiter {
-	return &IPRateLimiter{
-		ips:           make(map[string]*rate.Limiter),
-		mu:            &sync.RWMutex{},
-		rateTime:      rateTime,
-		requestsCount: requestsCount,
+// ParseRateLimitPolicies reads a comma-separated list of <name>=<policy>, e.g.
+// /api/v1/auth=10/1m,/api/v1/products/import=5/1m. An empty spec has no
+// policies.
+func ParseRateLimitPolicies(spec string) (map[string]RateLimitPolicy, error) {
+	policies := make(map[string]RateLimitPolicy)
+	for _, entry := range strings.Split(spec, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		name, policySpec, found := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("rate limit %q must be <name>=<limit>/<window>", entry)
+		}
+		policy, err := ParseRateLimitPolicy(policySpec)
+		if err != nil {
+			return nil, err
+		}
+		policies[name] = policy
 	}
+	return policies, nil
 }
 
-func (rl *IPRateLimiter) AddIP(ip string) *rate.Limiter {
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
-	limiter := rate.NewLimiter(rl.rateTime, rl.requestsCount)
-	rl.ips[ip] = limiter
-	return limiter
+// RateLimitConfig says which policy applies to a request. A caller listed in
+// Callers, by the subject it authenticated as, gets its own policy on every
+// route. Other callers get the policy of the longest route prefix in Routes
+// that the request matches, or Default. IP is the policy of
+// IPRateLimitMiddleware.
+type RateLimitConfig struct {
+	Default RateLimitPolicy
+	Routes  map[string]RateLimitPolicy
+	Callers map[string]RateLimitPolicy
+	IP      RateLimitPolicy
 }
 
-func (rl *IPRateLimiter) GetLimiter(ip string) *rate.Limiter {
-	rl.mu.Lock()
-	limiter, exists := rl.ips[ip]
-	if !exists {
-		rl.mu.Unlock()
-		return rl.AddIP(ip)
+// RateLimitMiddleware limits the requests of each caller, identified by the
+// subject it authenticated as or else by its IP. Every response states the
+// policy applied with the RateLimit-Limit, RateLimit-Remaining and
+// RateLimit-Reset headers, and a refused request gets 429 with a Retry-After
+// header. Counters are kept separately per route prefix. If store fails, the
+// request is let through: the limiter protects the API, it must not take it
+// down.
+//
+// It must run after AuthenticationMiddleware and
+// APIKeyAuthenticationMiddleware so that callers are known. Requests those
+// refuse never get here, which is what IPRateLimitMiddleware is for.
+func RateLimitMiddleware(store interfaces.RateLimitStore, config RateLimitConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scope, policy := config.policyFor(c)
+		caller := "ip:" + c.ClientIP()
+		if principal, ok := request_context.PrincipalFrom(c.Request.Context()); ok {
+			caller = "sub:" + principal.Subject
+		}
+		if limitRequest(c, store, scope+"|"+caller, policy) {
+			c.Next()
+		}
 	}
-	rl.mu.Unlock()
-	return limiter
 }
 
-func RateLimitMiddleware() gin.HandlerFunc {
-	limiter := NewIPRateLimiter(rate.Every(time.Minute), config.Env.RateLimitCount)
-
+// IPRateLimitMiddleware limits the requests of each client IP to policy,
+// whoever they authenticate as. It must run before AuthenticationMiddleware
+// and APIKeyAuthenticationMiddleware, so that requests with a bad token or a
+// guessed API key are counted too. The client IP is only taken from
+// forwarding headers sent by the router's trusted proxies.
+func IPRateLimitMiddleware(store interfaces.RateLimitStore, policy RateLimitPolicy) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ip := c.ClientIP()
-		if !limiter.GetLimiter(ip).Allow() {
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error":       "rate limit exceeded",
-				"retry_after": "60s",
-			})
-			c.Abort()
-			return
+		if limitRequest(c, store, "ip|ip:"+c.ClientIP(), policy) {
+			c.Next()
 		}
-		c.Next()
 	}
 }
+
+// limitRequest counts the request against key and reports whether it may go
+// on. It states the policy in the response headers, and refuses the request
+// itself when it is over the limit.
+func limitRequest(c *gin.Context, store interfaces.RateLimitStore, key string, policy RateLimitPolicy) bool {
+	count, resetAt, err := store.Increment(c.Request.Context(), key, policy.Window)
+	if err != nil {
+		log.Printf("⚠️  Rate limit check failed, letting the request through: %v", err)
+		return true
+	}
+
+	reset := int(math.Ceil(time.Until(resetAt).Seconds()))
+	if reset < 1 {
+		reset = 1
+	}
+	c.Header(RateLimitLimitHeader, strconv.Itoa(policy.Limit))
+	c.Header(RateLimitRemainingHeader, strconv.Itoa(max(policy.Limit-count, 0)))
+	c.Header(RateLimitResetHeader, strconv.Itoa(reset))
+	if count > policy.Limit {
+		err := shared_handlers.ErrRateLimitExceeded
+		err.Details = map[string]interface{}{"retry_after": fmt.Sprintf("%ds", reset)}
+		c.Header("Retry-After", strconv.Itoa(reset))
+		handleErrorResponse(c, err)
+		return false
+	}
+	return true
+}
+
+// policyFor returns the policy of the request and the scope its counters are
+// kept in.
+func (config RateLimitConfig) policyFor(c *gin.Context) (string, RateLimitPolicy) {
+	if principal, ok := request_context.PrincipalFrom(c.Request.Context()); ok {
+		if policy, ok := config.Callers[principal.Subject]; ok {
+			return "caller", policy
+		}
+	}
+	path := c.Request.URL.Path
+	scope, policy := "default", config.Default
+	longest := -1
+	for prefix, routePolicy := range config.Routes {
+		if matchesRoutePrefix(path, prefix) && len(prefix) > longest {
+			scope, policy, longest = "route:"+prefix, routePolicy, len(prefix)
+		}
+	}
+	return scope, policy
+}
+
+// matchesRoutePrefix reports whether path is prefix or one of the paths under
+// it, so /api/v1/products does not match /api/v1/products-archive.
+func matchesRoutePrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
@@ -4,23 +4,35 @@ import (
 	"net/http"
 	"runtime/debug"
 
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 )
 
+// RecoveryMiddleware turns panics into 500 responses. http.ErrAbortHandler is
+// passed on untouched to net/http, which drops the connection without logging
+// anything: handlers use it to give up on a response they have already
+// started. gin.Recovery would log it with a stack first, so it is not used.
 func RecoveryMiddleware() gin.HandlerFunc {
-	return gin.RecoveryWithWriter(gin.DefaultWriter, func(c *gin.Context, recovered interface{}) {
-		logrus.WithFields(logrus.Fields{
-			"panic":      recovered,
-			"stack":      string(debug.Stack()),
-			"path":       c.Request.URL.Path,
-			"method":     c.Request.Method,
-			"request_id": c.GetHeader("X-Request-ID"),
-		}).Error("Panic recovered")
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+			logrus.WithFields(logrus.Fields{
+				"panic":      recovered,
+				"stack":      string(debug.Stack()),
+				"path":       c.Request.URL.Path,
+				"method":     c.Request.Method,
+				"request_id": c.GetHeader("X-Request-ID"),
+			}).Error("Panic recovered")
 
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":      "internal server error",
-			"request_id": c.GetHeader("X-Request-ID"),
-		})
-	})
+			handleErrorResponse(c, shared_handlers.ErrInternal)
+		}()
+		c.Next()
+	}
 }
@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -14,6 +15,7 @@ func RequestIDMiddleware() gin.HandlerFunc {
 
 		c.Header("X-Request-ID", requestID)
 		c.Set("request_id", requestID)
+		c.Request = c.Request.WithContext(request_context.WithRequestID(c.Request.Context(), requestID))
 		c.Next()
 	}
 }
@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"net"
+	"strings"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin"
+)
+
+const TenantHeader = "X-Tenant-ID"
+
+// TenantMiddleware resolves the tenant whose catalogue a request works on and
+// stores it in the request context. A principal always gets the tenant of its
+// tenant_id claim, or DefaultTenant when its credentials name none, so that
+// they cannot pick another one. Anonymous requests get the tenant of the
+// X-Tenant-ID header, then the subdomain of baseDomain when one is configured,
+// then DefaultTenant. A request naming a tenant other than its principal's is
+// refused.
+//
+// It must run after the authentication middlewares and before
+// IdempotencyMiddleware, so that idempotency keys are kept per tenant.
+func TenantMiddleware(baseDomain string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requested := strings.ToLower(strings.TrimSpace(c.GetHeader(TenantHeader)))
+		if requested == "" {
+			requested = subdomainTenant(c.Request.Host, baseDomain)
+		}
+
+		tenant := requested
+		if principal, ok := request_context.PrincipalFrom(c.Request.Context()); ok {
+			bound := principal.Tenant()
+			if bound == "" {
+				bound = request_context.DefaultTenant
+			}
+			if requested != "" && requested != bound {
+				handleErrorResponse(c, shared_handlers.ErrTenantMismatch)
+				return
+			}
+			tenant = bound
+		}
+		if tenant == "" {
+			tenant = request_context.DefaultTenant
+		}
+		if !request_context.IsValidTenant(tenant) {
+			handleErrorResponse(c, shared_handlers.ErrInvalidTenant)
+			return
+		}
+
+		c.Request = c.Request.WithContext(request_context.WithTenant(c.Request.Context(), tenant))
+		c.Next()
+	}
+}
+
+// subdomainTenant returns the label host has directly below baseDomain, e.g.
+// acme for acme.api.example.com, or "" when host is not such a subdomain.
+func subdomainTenant(host, baseDomain string) string {
+	if baseDomain == "" {
+		return ""
+	}
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	label, found := strings.CutSuffix(strings.ToLower(host), "."+strings.ToLower(baseDomain))
+	if !found || strings.Contains(label, ".") {
+		return ""
+	}
+	return label
+}
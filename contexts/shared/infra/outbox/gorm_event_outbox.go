@@ -0,0 +1,50 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_adapters"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type GormEventOutbox struct {
+	db *gorm.DB
+}
+
+func NewGormEventOutbox(db *gorm.DB) *GormEventOutbox {
+	return &GormEventOutbox{db: db}
+}
+
+// Save writes the events with the transaction open in ctx, if any, so they
+// are only relayed once the change that raised them is committed. They belong
+// to the tenant of ctx.
+func (o *GormEventOutbox) Save(ctx context.Context, events []shared_models.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	tenant := request_context.TenantFrom(ctx)
+	messages := make([]OutboxMessageEntity, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, OutboxMessageEntity{
+			ID:            uuid.New(),
+			TenantID:      tenant,
+			EventName:     event.EventName(),
+			AggregateID:   event.AggregateID(),
+			Payload:       payload,
+			OccurredAt:    event.OccurredAt(),
+			NextAttemptAt: now,
+		})
+	}
+	return shared_adapters.DBFromContext(ctx, o.db).Create(&messages).Error
+}
@@ -0,0 +1,35 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/events"
+	"github.com/google/uuid"
+)
+
+// OutboxMessageEntity is a domain event waiting to be, or already, delivered.
+// Sequence keeps the order in which events were stored.
+type OutboxMessageEntity struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Sequence      int64     `gorm:"autoIncrement;uniqueIndex"`
+	TenantID      string    `gorm:"not null;default:default"`
+	EventName     string    `gorm:"not null;index"`
+	AggregateID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	Payload       []byte    `gorm:"type:jsonb;not null"`
+	OccurredAt    time.Time `gorm:"not null"`
+	Attempts      int       `gorm:"not null;default:0"`
+	LastError     string
+	NextAttemptAt time.Time  `gorm:"not null;index:idx_outbox_pending,priority:2"`
+	ProcessedAt   *time.Time `gorm:"index:idx_outbox_pending,priority:1"`
+}
+
+func (e *OutboxMessageEntity) ToMessage() events.Message {
+	return events.Message{
+		ID:          e.ID,
+		TenantID:    e.TenantID,
+		EventName:   e.EventName,
+		AggregateID: e.AggregateID,
+		Payload:     e.Payload,
+		OccurredAt:  e.OccurredAt,
+	}
+}
@@ -0,0 +1,183 @@
+package outbox_tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/events"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/outbox"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_adapters"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgres_driver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type testEvent struct {
+	ID   uuid.UUID `json:"id"`
+	Note string    `json:"note"`
+}
+
+func (e testEvent) EventName() string      { return "test.happened" }
+func (e testEvent) AggregateID() uuid.UUID { return e.ID }
+func (e testEvent) OccurredAt() time.Time  { return time.Now() }
+
+type RelayTestSuite struct {
+	suite.Suite
+	container  *postgres.PostgresContainer
+	db         *gorm.DB
+	outbox     *outbox.GormEventOutbox
+	txManager  *shared_adapters.GormTransactionManager
+	dispatcher *events.Dispatcher
+	relay      *outbox.Relay
+	ctx        context.Context
+}
+
+func (suite *RelayTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	container, err := postgres.Run(suite.ctx,
+		"postgres:15-alpine",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	connStr, err := container.ConnectionString(suite.ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	db, err := gorm.Open(postgres_driver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(&outbox.OutboxMessageEntity{}))
+
+	suite.db = db
+	suite.outbox = outbox.NewGormEventOutbox(db)
+	suite.txManager = shared_adapters.NewGormTransactionManager(db)
+}
+
+func (suite *RelayTestSuite) SetupTest() {
+	// No backoff, so failed messages are due again straight away
+	suite.dispatcher = events.NewDispatcher()
+	suite.relay = outbox.NewRelay(suite.db, suite.dispatcher, outbox.RelayConfig{
+		PollInterval: 10 * time.Millisecond,
+		BatchSize:    10,
+	})
+}
+
+func (suite *RelayTestSuite) TearDownSuite() {
+	if suite.container != nil {
+		suite.container.Terminate(suite.ctx)
+	}
+}
+
+func (suite *RelayTestSuite) TearDownTest() {
+	if suite.db != nil {
+		suite.db.Exec("TRUNCATE TABLE outbox_message_entities")
+	}
+}
+
+func (suite *RelayTestSuite) TestDeliversSavedEventsOnce() {
+	// Arrange
+	event := testEvent{ID: uuid.New(), Note: "hello"}
+	suite.Require().NoError(suite.outbox.Save(suite.ctx, []shared_models.DomainEvent{event}))
+	var received []events.Message
+	suite.dispatcher.Subscribe("test.happened", func(ctx context.Context, message events.Message) error {
+		received = append(received, message)
+		return nil
+	})
+
+	// Act
+	delivered, err := suite.relay.ProcessBatch(suite.ctx)
+	suite.Require().NoError(err)
+	again, err := suite.relay.ProcessBatch(suite.ctx)
+	suite.Require().NoError(err)
+
+	// Assert
+	suite.Equal(1, delivered)
+	suite.Equal(0, again)
+	suite.Require().Len(received, 1)
+	suite.Equal(event.ID, received[0].AggregateID)
+	var payload testEvent
+	suite.Require().NoError(json.Unmarshal(received[0].Payload, &payload))
+	suite.Equal(event, payload)
+}
+
+func (suite *RelayTestSuite) TestKeepsTheTenantOfSavedEvents() {
+	// Arrange
+	ctx := request_context.WithTenant(suite.ctx, "acme")
+	suite.Require().NoError(suite.outbox.Save(ctx, []shared_models.DomainEvent{testEvent{ID: uuid.New()}}))
+	var received []events.Message
+	suite.dispatcher.Subscribe("test.happened", func(ctx context.Context, message events.Message) error {
+		received = append(received, message)
+		return nil
+	})
+
+	// Act
+	_, err := suite.relay.ProcessBatch(suite.ctx)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().Len(received, 1)
+	suite.Equal("acme", received[0].TenantID)
+}
+
+func (suite *RelayTestSuite) TestRetriesUntilHandlersSucceed() {
+	// Arrange
+	suite.Require().NoError(suite.outbox.Save(suite.ctx, []shared_models.DomainEvent{testEvent{ID: uuid.New()}}))
+	calls := 0
+	suite.dispatcher.Subscribe("test.happened", func(ctx context.Context, message events.Message) error {
+		calls++
+		if calls == 1 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	// Act
+	first, err := suite.relay.ProcessBatch(suite.ctx)
+	suite.Require().NoError(err)
+	second, err := suite.relay.ProcessBatch(suite.ctx)
+	suite.Require().NoError(err)
+
+	// Assert
+	suite.Equal(0, first)
+	suite.Equal(1, second)
+	suite.Equal(2, calls)
+}
+
+func (suite *RelayTestSuite) TestSkipsEventsOfRolledBackTransactions() {
+	// Arrange
+	rollback := errors.New("rollback")
+	err := suite.txManager.WithinTransaction(suite.ctx, func(ctx context.Context) error {
+		if err := suite.outbox.Save(ctx, []shared_models.DomainEvent{testEvent{ID: uuid.New()}}); err != nil {
+			return err
+		}
+		return rollback
+	})
+	suite.Require().ErrorIs(err, rollback)
+
+	// Act
+	delivered, err := suite.relay.ProcessBatch(suite.ctx)
+
+	// Assert
+	suite.NoError(err)
+	suite.Equal(0, delivered)
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, new(RelayTestSuite))
+}
@@ -0,0 +1,103 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/events"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// A failed message is retried after BaseBackoff, doubling on every
+	// further failure up to MaxBackoff.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+func DefaultRelayConfig() RelayConfig {
+	return RelayConfig{
+		PollInterval: time.Second,
+		BatchSize:    100,
+		BaseBackoff:  time.Second,
+		MaxBackoff:   5 * time.Minute,
+	}
+}
+
+// Relay delivers stored events to the dispatcher. A message is marked as
+// processed only after every handler succeeded, so delivery is at-least-once.
+// Several relays can run side by side: rows being delivered are locked and
+// skipped by the others.
+type Relay struct {
+	db         *gorm.DB
+	dispatcher *events.Dispatcher
+	config     RelayConfig
+}
+
+func NewRelay(db *gorm.DB, dispatcher *events.Dispatcher, config RelayConfig) *Relay {
+	return &Relay{db: db, dispatcher: dispatcher, config: config}
+}
+
+// Run polls for pending messages until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.ProcessBatch(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("⚠️  Outbox relay failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessBatch delivers the messages that are due and returns how many were
+// delivered successfully.
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	delivered := 0
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UTC()
+		var messages []OutboxMessageEntity
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("processed_at IS NULL AND next_attempt_at <= ?", now).
+			Order("sequence").
+			Limit(r.config.BatchSize).
+			Find(&messages).Error
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			updates := map[string]interface{}{"attempts": message.Attempts + 1}
+			if err := r.dispatcher.Dispatch(ctx, message.ToMessage()); err != nil {
+				updates["last_error"] = err.Error()
+				updates["next_attempt_at"] = now.Add(r.backoff(message.Attempts + 1))
+			} else {
+				updates["last_error"] = ""
+				updates["processed_at"] = now
+				delivered++
+			}
+			if err := tx.Model(&OutboxMessageEntity{}).Where("id = ?", message.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return delivered, err
+}
+
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.config.BaseBackoff
+	for i := 1; i < attempts && delay < r.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, r.config.MaxBackoff)
+}
@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormRateLimitStore keeps counters in Postgres, so that instances behind a
+// load balancer share their limits.
+type GormRateLimitStore struct {
+	db *gorm.DB
+}
+
+func NewGormRateLimitStore(db *gorm.DB) *GormRateLimitStore {
+	return &GormRateLimitStore{db: db}
+}
+
+// Increment counts the request with a single upsert, which starts a new
+// window when the stored one has ended, so concurrent requests are all
+// counted.
+func (s *GormRateLimitStore) Increment(ctx context.Context, key string, window time.Duration) (int, time.Time, error) {
+	now := time.Now().UTC()
+	var counter RateLimitCounterEntity
+	err := s.db.WithContext(ctx).Raw(`
+		INSERT INTO rate_limit_counter_entities (key, count, reset_at) VALUES (?, 1, ?)
+		ON CONFLICT (key) DO UPDATE SET
+			count = CASE WHEN rate_limit_counter_entities.reset_at <= ? THEN 1 ELSE rate_limit_counter_entities.count + 1 END,
+			reset_at = CASE WHEN rate_limit_counter_entities.reset_at <= ? THEN excluded.reset_at ELSE rate_limit_counter_entities.reset_at END
+		RETURNING key, count, reset_at`,
+		key, windowEnd(now, window), now, now).Scan(&counter).Error
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return counter.Count, counter.ResetAt, nil
+}
+
+// PurgeExpired deletes the counters whose window has ended and returns how
+// many.
+func (s *GormRateLimitStore) PurgeExpired(ctx context.Context) (int64, error) {
+	result := s.db.WithContext(ctx).Where("reset_at <= ?", time.Now().UTC()).Delete(&RateLimitCounterEntity{})
+	return result.RowsAffected, result.Error
+}
+
+// RunPurge deletes expired counters every interval until ctx is cancelled.
+// Expired counters are already restarted by Increment; purging only reclaims
+// space.
+func (s *GormRateLimitStore) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.PurgeExpired(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("⚠️  Rate limit counter purge failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
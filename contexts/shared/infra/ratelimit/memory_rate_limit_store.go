@@ -0,0 +1,77 @@
+// Package ratelimit keeps the request counters of the rate limiter, in memory
+// for a single instance or in Postgres for instances sharing their limits.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryCounter struct {
+	count   int
+	resetAt time.Time
+}
+
+// MemoryRateLimitStore keeps counters in the memory of the instance. Counters
+// whose window has ended are dropped by EvictExpired, so memory only grows
+// with the callers active in the current windows.
+type MemoryRateLimitStore struct {
+	mu       sync.Mutex
+	counters map[string]*memoryCounter
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{counters: make(map[string]*memoryCounter)}
+}
+
+func (s *MemoryRateLimitStore) Increment(ctx context.Context, key string, window time.Duration) (int, time.Time, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter, ok := s.counters[key]
+	if !ok || !now.Before(counter.resetAt) {
+		counter = &memoryCounter{resetAt: windowEnd(now, window)}
+		s.counters[key] = counter
+	}
+	counter.count++
+	return counter.count, counter.resetAt, nil
+}
+
+// EvictExpired drops the counters whose window has ended and returns how
+// many.
+func (s *MemoryRateLimitStore) EvictExpired() int {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	evicted := 0
+	for key, counter := range s.counters {
+		if !now.Before(counter.resetAt) {
+			delete(s.counters, key)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+// RunEviction drops expired counters every interval until ctx is cancelled.
+func (s *MemoryRateLimitStore) RunEviction(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.EvictExpired()
+		}
+	}
+}
+
+// windowEnd is when the window of length window that contains now ends.
+func windowEnd(now time.Time, window time.Duration) time.Time {
+	return now.Truncate(window).Add(window)
+}
@@ -0,0 +1,11 @@
+package ratelimit
+
+import "time"
+
+// RateLimitCounterEntity is the number of requests counted for a key in the
+// window that ends at ResetAt.
+type RateLimitCounterEntity struct {
+	Key     string    `gorm:"primaryKey"`
+	Count   int       `gorm:"not null"`
+	ResetAt time.Time `gorm:"not null;index"`
+}
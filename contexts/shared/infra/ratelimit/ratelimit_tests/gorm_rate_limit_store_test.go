@@ -0,0 +1,140 @@
+package ratelimit_tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/ratelimit"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgres_driver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type GormRateLimitStoreTestSuite struct {
+	suite.Suite
+	container *postgres.PostgresContainer
+	db        *gorm.DB
+	store     *ratelimit.GormRateLimitStore
+	ctx       context.Context
+}
+
+func (suite *GormRateLimitStoreTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	container, err := postgres.Run(suite.ctx,
+		"postgres:15-alpine",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	connStr, err := container.ConnectionString(suite.ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	db, err := gorm.Open(postgres_driver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(&ratelimit.RateLimitCounterEntity{}))
+
+	suite.db = db
+	suite.store = ratelimit.NewGormRateLimitStore(db)
+}
+
+func (suite *GormRateLimitStoreTestSuite) TearDownSuite() {
+	if suite.container != nil {
+		suite.container.Terminate(suite.ctx)
+	}
+}
+
+func (suite *GormRateLimitStoreTestSuite) TearDownTest() {
+	if suite.db != nil {
+		suite.db.Exec("TRUNCATE TABLE rate_limit_counter_entities")
+	}
+}
+
+func (suite *GormRateLimitStoreTestSuite) TestIncrement() {
+	// Act
+	first, resetAt, err := suite.store.Increment(suite.ctx, "a", time.Hour)
+	suite.Require().NoError(err)
+	second, secondResetAt, err := suite.store.Increment(suite.ctx, "a", time.Hour)
+	suite.Require().NoError(err)
+	other, _, err := suite.store.Increment(suite.ctx, "b", time.Hour)
+	suite.Require().NoError(err)
+
+	// Assert
+	suite.Equal(1, first)
+	suite.Equal(2, second)
+	suite.Equal(1, other)
+	suite.True(resetAt.Equal(secondResetAt))
+	suite.True(resetAt.After(time.Now()))
+}
+
+func (suite *GormRateLimitStoreTestSuite) TestIncrementCountsConcurrentRequests() {
+	// Arrange
+	const requests = 20
+	var wg sync.WaitGroup
+
+	// Act
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := suite.store.Increment(suite.ctx, "a", time.Hour)
+			suite.NoError(err)
+		}()
+	}
+	wg.Wait()
+	count, _, err := suite.store.Increment(suite.ctx, "a", time.Hour)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Equal(requests+1, count)
+}
+
+func (suite *GormRateLimitStoreTestSuite) TestIncrementRestartsEndedWindows() {
+	// Arrange
+	suite.Require().NoError(suite.db.Create(&ratelimit.RateLimitCounterEntity{
+		Key: "a", Count: 10, ResetAt: time.Now().Add(-time.Second),
+	}).Error)
+
+	// Act
+	count, resetAt, err := suite.store.Increment(suite.ctx, "a", time.Hour)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Equal(1, count)
+	suite.True(resetAt.After(time.Now()))
+}
+
+func (suite *GormRateLimitStoreTestSuite) TestPurgeExpired() {
+	// Arrange
+	suite.Require().NoError(suite.db.Create(&ratelimit.RateLimitCounterEntity{
+		Key: "expired", Count: 1, ResetAt: time.Now().Add(-time.Second),
+	}).Error)
+	_, _, err := suite.store.Increment(suite.ctx, "live", time.Hour)
+	suite.Require().NoError(err)
+
+	// Act
+	purged, err := suite.store.PurgeExpired(suite.ctx)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), purged)
+	var remaining []string
+	suite.Require().NoError(suite.db.Model(&ratelimit.RateLimitCounterEntity{}).Pluck("key", &remaining).Error)
+	suite.Equal([]string{"live"}, remaining)
+}
+
+func TestGormRateLimitStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(GormRateLimitStoreTestSuite))
+}
@@ -0,0 +1,63 @@
+package ratelimit_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRateLimitStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should count requests per key within the window", func(t *testing.T) {
+		// Arrange
+		store := ratelimit.NewMemoryRateLimitStore()
+
+		// Act
+		first, resetAt, err := store.Increment(ctx, "a", time.Hour)
+		require.NoError(t, err)
+		second, _, _ := store.Increment(ctx, "a", time.Hour)
+		other, _, _ := store.Increment(ctx, "b", time.Hour)
+
+		// Assert
+		assert.Equal(t, 1, first)
+		assert.Equal(t, 2, second)
+		assert.Equal(t, 1, other)
+		assert.Equal(t, time.Now().Truncate(time.Hour).Add(time.Hour), resetAt)
+	})
+
+	t.Run("should start again once the window ended", func(t *testing.T) {
+		// Arrange
+		store := ratelimit.NewMemoryRateLimitStore()
+		_, resetAt, _ := store.Increment(ctx, "a", time.Second)
+		time.Sleep(time.Until(resetAt))
+
+		// Act
+		count, nextResetAt, err := store.Increment(ctx, "a", time.Second)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.True(t, nextResetAt.After(resetAt))
+	})
+
+	t.Run("should evict the counters of ended windows only", func(t *testing.T) {
+		// Arrange
+		store := ratelimit.NewMemoryRateLimitStore()
+		_, resetAt, _ := store.Increment(ctx, "idle", time.Second)
+		time.Sleep(time.Until(resetAt))
+		store.Increment(ctx, "active", time.Hour)
+
+		// Act
+		evicted := store.EvictExpired()
+
+		// Assert
+		assert.Equal(t, 1, evicted)
+		count, _, _ := store.Increment(ctx, "active", time.Hour)
+		assert.Equal(t, 2, count)
+	})
+}
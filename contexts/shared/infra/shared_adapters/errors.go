@@ -0,0 +1,47 @@
+package shared_adapters
+
+import (
+	"errors"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// Postgres error codes, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+// TranslateError turns the errors of the database that callers can act on
+// into infra errors: a missing row is ErrNotFound, a unique violation is
+// ErrAlreadyExists and a foreign key violation is ErrReferenceViolation. Any
+// other error is returned as it is.
+func TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return shared_handlers.ErrNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgUniqueViolation:
+			return shared_handlers.ErrAlreadyExists
+		case pgForeignKeyViolation:
+			return shared_handlers.ErrReferenceViolation
+		}
+	}
+	return err
+}
+
+// IsUniqueViolation reports whether err is a unique violation of constraint.
+// Repositories use it to tell which unique value was taken.
+func IsUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == constraint
+}
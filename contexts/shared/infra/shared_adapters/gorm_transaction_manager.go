@@ -0,0 +1,65 @@
+package shared_adapters
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type transactionKey struct{}
+
+type afterCommitKey struct{}
+
+type GormTransactionManager struct {
+	db *gorm.DB
+}
+
+func NewGormTransactionManager(db *gorm.DB) *GormTransactionManager {
+	return &GormTransactionManager{db: db}
+}
+
+// WithinTransaction opens a transaction and stores it in the context passed to
+// fn. Nested calls join the transaction that is already open.
+func (tm *GormTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if InTransaction(ctx) {
+		return fn(ctx)
+	}
+	var afterCommit []func()
+	err := tm.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txCtx := context.WithValue(ctx, transactionKey{}, tx)
+		return fn(context.WithValue(txCtx, afterCommitKey{}, &afterCommit))
+	})
+	if err != nil {
+		return err
+	}
+	for _, hook := range afterCommit {
+		hook()
+	}
+	return nil
+}
+
+// InTransaction reports whether a transaction is open in ctx.
+func InTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(transactionKey{}).(*gorm.DB)
+	return ok
+}
+
+// AfterCommit runs hook once the transaction open in ctx is committed, and not
+// at all if it is rolled back. Without a transaction, hook runs at once.
+func AfterCommit(ctx context.Context, hook func()) {
+	hooks, ok := ctx.Value(afterCommitKey{}).(*[]func())
+	if !ok {
+		hook()
+		return
+	}
+	*hooks = append(*hooks, hook)
+}
+
+// DBFromContext returns the transaction open in ctx, if any, or db otherwise.
+// Repositories use it so they take part in transactions they did not open.
+func DBFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
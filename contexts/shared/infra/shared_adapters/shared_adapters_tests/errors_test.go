@@ -0,0 +1,67 @@
+package shared_adapters_tests
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_adapters"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestTranslateError(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"missing row as not found", gorm.ErrRecordNotFound, shared_handlers.ErrNotFound},
+		{"unique violation as already exists", &pgconn.PgError{Code: "23505"}, shared_handlers.ErrAlreadyExists},
+		{"wrapped unique violation as already exists", fmt.Errorf("insert: %w", &pgconn.PgError{Code: "23505"}), shared_handlers.ErrAlreadyExists},
+		{"foreign key violation as reference violation", &pgconn.PgError{Code: "23503"}, shared_handlers.ErrReferenceViolation},
+	}
+
+	for _, tc := range cases {
+		t.Run("should translate "+tc.name, func(t *testing.T) {
+			// Act
+			err := shared_adapters.TranslateError(tc.err)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+
+	t.Run("should return other errors as they are", func(t *testing.T) {
+		// Arrange
+		original := &pgconn.PgError{Code: "57014"}
+
+		// Act
+		err := shared_adapters.TranslateError(original)
+
+		// Assert
+		assert.Same(t, original, err)
+	})
+
+	t.Run("should return nil for nil", func(t *testing.T) {
+		// Act
+		err := shared_adapters.TranslateError(nil)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	t.Run("should match the violated constraint only", func(t *testing.T) {
+		// Arrange
+		err := fmt.Errorf("insert: %w", &pgconn.PgError{Code: "23505", ConstraintName: "idx_sku"})
+
+		// Act & Assert
+		assert.True(t, shared_adapters.IsUniqueViolation(err, "idx_sku"))
+		assert.False(t, shared_adapters.IsUniqueViolation(err, "idx_other"))
+		assert.False(t, shared_adapters.IsUniqueViolation(errors.New("idx_sku"), "idx_sku"))
+	})
+}
@@ -0,0 +1,23 @@
+package shared_adapters_tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_adapters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAfterCommit(t *testing.T) {
+	t.Run("should run the hook at once outside a transaction", func(t *testing.T) {
+		// Arrange
+		ran := false
+
+		// Act
+		shared_adapters.AfterCommit(context.Background(), func() { ran = true })
+
+		// Assert
+		assert.True(t, ran)
+		assert.False(t, shared_adapters.InTransaction(context.Background()))
+	})
+}
@@ -0,0 +1,96 @@
+package shared_handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// Validation errors name fields the way clients send them.
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		engine.RegisterTagNameFunc(jsonFieldName)
+	}
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// BindingError turns the error of binding or validating a request body into
+// ErrInvalidPayload, with a violation in its details for every rejected field.
+func BindingError(err error) InfraError {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		violations := make([]models.FieldViolation, len(validationErrs))
+		for i, fieldErr := range validationErrs {
+			violations[i] = fieldViolation(fieldErr)
+		}
+		return ErrInvalidPayload.WithFieldViolations(violations...)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return ErrInvalidPayload.WithFieldViolations(models.FieldViolation{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Value:   typeErr.Value,
+			Message: fmt.Sprintf("%s must be a %s", typeErr.Field, jsonTypeName(typeErr.Type)),
+		})
+	}
+	return ErrInvalidPayload
+}
+
+func fieldViolation(fieldErr validator.FieldError) models.FieldViolation {
+	// The namespace starts with the name of the request struct, which
+	// clients never see.
+	_, field, _ := strings.Cut(fieldErr.Namespace(), ".")
+
+	var message string
+	switch fieldErr.Tag() {
+	case "required":
+		message = fmt.Sprintf("%s is required", field)
+	case "min":
+		message = fmt.Sprintf("%s must be at least %s", field, fieldErr.Param())
+	case "max":
+		message = fmt.Sprintf("%s must be at most %s", field, fieldErr.Param())
+	default:
+		message = fmt.Sprintf("%s does not satisfy %s", field, fieldErr.Tag())
+	}
+	return models.FieldViolation{
+		Field:   field,
+		Rule:    fieldErr.Tag(),
+		Value:   fieldErr.Value(),
+		Message: message,
+	}
+}
+
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "list"
+	default:
+		return "object"
+	}
+}
@@ -0,0 +1,61 @@
+package shared_handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const weakETagPrefix = "W/"
+
+// ContentETag returns a strong entity tag derived from body, for
+// representations that have no version of their own, e.g. a page of a
+// listing.
+func ContentETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return strconv.Quote(hex.EncodeToString(sum[:16]))
+}
+
+// NotModified sets the validators of the representation about to be sent,
+// etag and lastModified unless it is zero, and answers 304 Not Modified
+// instead when If-None-Match or If-Modified-Since show the client already has
+// it. It returns true when it did, and the handler must not write a body.
+// If-Modified-Since is ignored when If-None-Match is sent, as RFC 9110 asks.
+func NotModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	c.Header("ETag", etag)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" {
+		if !matchesAnyETag(ifNoneMatch, etag) {
+			return false
+		}
+	} else {
+		since, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+		// HTTP dates have no fractions of a second.
+		if err != nil || lastModified.IsZero() || lastModified.Truncate(time.Second).After(since) {
+			return false
+		}
+	}
+	c.Status(http.StatusNotModified)
+	return true
+}
+
+// matchesAnyETag reports whether the If-None-Match header lists etag or is
+// "*". If-None-Match uses weak comparison, so W/ prefixes are ignored.
+func matchesAnyETag(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, weakETagPrefix)
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == anyETagWildcard || strings.TrimPrefix(candidate, weakETagPrefix) == etag {
+			return true
+		}
+	}
+	return false
+}
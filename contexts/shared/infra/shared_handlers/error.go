@@ -2,27 +2,55 @@ package shared_handlers
 
 import (
 	"net/http"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/domain/models"
 )
 
+const defaultDomainErrorStatus = http.StatusBadRequest
+
 type InfraError struct {
 	Code    ErrorCode
 	Message string
+	Details map[string]interface{}
 }
 
 func (e InfraError) Error() string {
 	return e.Message
 }
 
+// Is matches infra errors by code and message, so an error carrying details
+// still matches the error it was made from.
+func (e InfraError) Is(target error) bool {
+	infraErr, ok := target.(InfraError)
+	return ok && infraErr.Code == e.Code && infraErr.Message == e.Message
+}
+
+// WithFieldViolations returns a copy of the error that reports the invalid
+// fields in its details.
+func (e InfraError) WithFieldViolations(violations ...models.FieldViolation) InfraError {
+	e.Details = models.FieldViolationDetails(violations)
+	return e
+}
+
 type ErrorCode string
 
 const (
-	ErrorCodeNotFound        ErrorCode = "NOT_FOUND"
-	ErrorCodeUnauthorized    ErrorCode = "UNAUTHORIZED"
-	ErrorCodeForbidden       ErrorCode = "FORBIDDEN"
-	ErrorCodeValidationError ErrorCode = "VALIDATION_ERROR"
-	ErrorCodePanicError      ErrorCode = "PANIC_ERROR"
-	ErrorCodeBadRequest      ErrorCode = "BAD_REQUEST"
-	ErrorCodeInternalError   ErrorCode = "INTERNAL_ERROR"
+	ErrorCodeNotFound           ErrorCode = "NOT_FOUND"
+	ErrorCodeUnauthorized       ErrorCode = "UNAUTHORIZED"
+	ErrorCodeForbidden          ErrorCode = "FORBIDDEN"
+	ErrorCodeValidationError    ErrorCode = "VALIDATION_ERROR"
+	ErrorCodePanicError         ErrorCode = "PANIC_ERROR"
+	ErrorCodeBadRequest         ErrorCode = "BAD_REQUEST"
+	ErrorCodeInternalError      ErrorCode = "INTERNAL_ERROR"
+	ErrorCodePreconditionFailed ErrorCode = "PRECONDITION_FAILED"
+	ErrorCodeFailedDependency   ErrorCode = "FAILED_DEPENDENCY"
+	ErrorCodeNotAcceptable      ErrorCode = "NOT_ACCEPTABLE"
+	ErrorCodeConflict           ErrorCode = "CONFLICT"
+	ErrorCodeUnprocessable      ErrorCode = "UNPROCESSABLE_ENTITY"
+	ErrorCodeUnsupportedMedia   ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+	ErrorCodeTooManyRequests    ErrorCode = "TOO_MANY_REQUESTS"
+	ErrorCodeAlreadyExists      ErrorCode = "ALREADY_EXISTS"
+	ErrorCodeReferenceViolation ErrorCode = "REFERENCE_VIOLATION"
 )
 
 var (
@@ -36,6 +64,101 @@ var (
 		Message: "Invalid limit value",
 	}
 
+	ErrInvalidSort = InfraError{
+		Code:    ErrorCodeValidationError,
+		Message: "Invalid sort value",
+	}
+
+	ErrInvalidFilter = InfraError{
+		Code:    ErrorCodeValidationError,
+		Message: "Invalid filter value",
+	}
+
+	ErrInvalidSearchQuery = InfraError{
+		Code:    ErrorCodeValidationError,
+		Message: "Search query must be between 1 and 256 characters",
+	}
+
+	ErrInvalidBatchSize = InfraError{
+		Code:    ErrorCodeValidationError,
+		Message: "Batch size is empty or exceeds the maximum allowed",
+	}
+
+	ErrInvalidBatchMode = InfraError{
+		Code:    ErrorCodeValidationError,
+		Message: "Batch mode must be all_or_nothing or best_effort",
+	}
+
+	ErrBatchItemAborted = InfraError{
+		Code:    ErrorCodeFailedDependency,
+		Message: "Not applied because another item of the batch failed",
+	}
+
+	ErrInvalidImportFile = InfraError{
+		Code:    ErrorCodeValidationError,
+		Message: "Import file must be CSV with a header naming the sku, name, category and price columns",
+	}
+
+	ErrImportFileTooLarge = InfraError{
+		Code:    ErrorCodeValidationError,
+		Message: "Import file exceeds the maximum number of rows or bytes allowed",
+	}
+
+	ErrInvalidImportOptions = InfraError{
+		Code:    ErrorCodeValidationError,
+		Message: "dry_run must be a boolean and format must be json or csv",
+	}
+
+	ErrInvalidImportRow = InfraError{
+		Code:    ErrorCodeValidationError,
+		Message: "Row is missing a value for one of the header columns",
+	}
+
+	ErrInvalidImportPrice = InfraError{
+		Code:    ErrorCodeValidationError,
+		Message: "Price is not a number",
+	}
+
+	ErrInvalidExportFormat = InfraError{
+		Code:    ErrorCodeValidationError,
+		Message: "format must be csv, ndjson or xlsx",
+	}
+
+	ErrNotAcceptable = InfraError{
+		Code:    ErrorCodeNotAcceptable,
+		Message: "None of the accepted media types can be produced",
+	}
+
+	ErrInvalidIdempotencyKey = InfraError{
+		Code:    ErrorCodeValidationError,
+		Message: "Idempotency-Key must be at most 255 characters",
+	}
+
+	ErrIdempotencyKeyReused = InfraError{
+		Code:    ErrorCodeUnprocessable,
+		Message: "Idempotency-Key was already used for a different request",
+	}
+
+	ErrIdempotencyKeyInProgress = InfraError{
+		Code:    ErrorCodeConflict,
+		Message: "A request with this Idempotency-Key is still being processed",
+	}
+
+	ErrUnsupportedPatchType = InfraError{
+		Code:    ErrorCodeUnsupportedMedia,
+		Message: "Content-Type must be application/json, application/merge-patch+json or application/json-patch+json",
+	}
+
+	ErrInvalidPatch = InfraError{
+		Code:    ErrorCodeUnprocessable,
+		Message: "Patch cannot be applied: a path does not exist, a test failed or the result is not a product",
+	}
+
+	ErrPatchReadOnlyField = InfraError{
+		Code:    ErrorCodeUnprocessable,
+		Message: "Patch changes a read-only field: id, version and deleted_at cannot be patched",
+	}
+
 	ErrInvalidUUID = InfraError{
 		Code:    ErrorCodeValidationError,
 		Message: "Invalid UUID format",
@@ -50,14 +173,128 @@ var (
 		Code:    ErrorCodeNotFound,
 		Message: "Resource not found",
 	}
+
+	ErrPreconditionFailed = InfraError{
+		Code:    ErrorCodePreconditionFailed,
+		Message: "Resource has been modified since it was last read",
+	}
+
+	ErrUnauthenticated = InfraError{
+		Code:    ErrorCodeUnauthorized,
+		Message: "Authentication required",
+	}
+
+	ErrInvalidToken = InfraError{
+		Code:    ErrorCodeUnauthorized,
+		Message: "Bearer token is invalid or has expired",
+	}
+
+	ErrInvalidAPIKey = InfraError{
+		Code:    ErrorCodeUnauthorized,
+		Message: "API key is invalid, expired or revoked",
+	}
+
+	ErrConflictingCredentials = InfraError{
+		Code:    ErrorCodeUnauthorized,
+		Message: "Send either a bearer token or an API key, not both",
+	}
+
+	ErrInvalidCredentials = InfraError{
+		Code:    ErrorCodeUnauthorized,
+		Message: "Email or password is incorrect",
+	}
+
+	ErrAccountLocked = InfraError{
+		Code:    ErrorCodeTooManyRequests,
+		Message: "Account is locked after too many failed logins, try again later",
+	}
+
+	ErrIncorrectPassword = InfraError{
+		Code:    ErrorCodeValidationError,
+		Message: "Current password is incorrect",
+	}
+
+	ErrNotAUserAccount = InfraError{
+		Code:    ErrorCodeForbidden,
+		Message: "Only user accounts can change their password",
+	}
+
+	ErrInvalidTenant = InfraError{
+		Code:    ErrorCodeBadRequest,
+		Message: "Tenant must be 1 to 63 lowercase letters, digits or hyphens",
+	}
+
+	ErrTenantMismatch = InfraError{
+		Code:    ErrorCodeForbidden,
+		Message: "Credentials belong to another tenant",
+	}
+
+	ErrForbidden = InfraError{
+		Code:    ErrorCodeForbidden,
+		Message: "You do not have permission to perform this action",
+	}
+
+	ErrAlreadyExists = InfraError{
+		Code:    ErrorCodeAlreadyExists,
+		Message: "A resource with the same unique value already exists",
+	}
+
+	ErrDuplicateSku = InfraError{
+		Code:    ErrorCodeAlreadyExists,
+		Message: "A product with this SKU already exists",
+	}
+
+	ErrDuplicateEmail = InfraError{
+		Code:    ErrorCodeAlreadyExists,
+		Message: "A user with this email already exists",
+	}
+
+	ErrReferenceViolation = InfraError{
+		Code:    ErrorCodeReferenceViolation,
+		Message: "Resource references a resource that does not exist or is still referenced",
+	}
+
+	ErrRateLimitExceeded = InfraError{
+		Code:    ErrorCodeTooManyRequests,
+		Message: "Rate limit exceeded",
+	}
+
+	ErrInternal = InfraError{
+		Code:    ErrorCodeInternalError,
+		Message: "An unexpected error occurred",
+	}
 )
 
 var InfraErrorStatusMap = map[ErrorCode]int{
-	ErrorCodeNotFound:        http.StatusNotFound,
-	ErrorCodeUnauthorized:    http.StatusUnauthorized,
-	ErrorCodeForbidden:       http.StatusForbidden,
-	ErrorCodeValidationError: http.StatusBadRequest,
-	ErrorCodePanicError:      http.StatusInternalServerError,
-	ErrorCodeBadRequest:      http.StatusBadRequest,
-	ErrorCodeInternalError:   http.StatusInternalServerError,
+	ErrorCodeNotFound:           http.StatusNotFound,
+	ErrorCodeUnauthorized:       http.StatusUnauthorized,
+	ErrorCodeForbidden:          http.StatusForbidden,
+	ErrorCodeValidationError:    http.StatusBadRequest,
+	ErrorCodePanicError:         http.StatusInternalServerError,
+	ErrorCodeBadRequest:         http.StatusBadRequest,
+	ErrorCodeInternalError:      http.StatusInternalServerError,
+	ErrorCodePreconditionFailed: http.StatusPreconditionFailed,
+	ErrorCodeFailedDependency:   http.StatusFailedDependency,
+	ErrorCodeNotAcceptable:      http.StatusNotAcceptable,
+	ErrorCodeConflict:           http.StatusConflict,
+	ErrorCodeUnprocessable:      http.StatusUnprocessableEntity,
+	ErrorCodeUnsupportedMedia:   http.StatusUnsupportedMediaType,
+	ErrorCodeTooManyRequests:    http.StatusTooManyRequests,
+	ErrorCodeAlreadyExists:      http.StatusConflict,
+	ErrorCodeReferenceViolation: http.StatusConflict,
+}
+
+// StatusCodeFromError is the HTTP status an error is reported with: domain
+// errors are bad requests, infra errors follow InfraErrorStatusMap and
+// anything else is an internal error.
+func StatusCodeFromError(err error) int {
+	if _, ok := err.(models.DomainError); ok {
+		return defaultDomainErrorStatus
+	}
+	if infraErr, ok := err.(InfraError); ok {
+		if statusCode, exists := InfraErrorStatusMap[infraErr.Code]; exists {
+			return statusCode
+		}
+	}
+	return http.StatusInternalServerError
 }
@@ -0,0 +1,34 @@
+package shared_handlers
+
+import (
+	"strconv"
+	"strings"
+)
+
+const anyETagWildcard = "*"
+
+// VersionETag formats a resource version as a strong entity tag.
+func VersionETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// ParseIfMatchVersion extracts the expected resource version from an If-Match
+// header. It returns nil when the header is absent or "*", i.e. when any
+// version is acceptable, and ErrPreconditionFailed when the tag cannot be one
+// of ours and therefore can never match. If-Match uses strong comparison, so
+// weak tags never match either.
+func ParseIfMatchVersion(header string) (*int, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == anyETagWildcard {
+		return nil, nil
+	}
+
+	if len(header) < 2 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		return nil, ErrPreconditionFailed
+	}
+	version, err := strconv.Atoi(header[1 : len(header)-1])
+	if err != nil {
+		return nil, ErrPreconditionFailed
+	}
+	return &version, nil
+}
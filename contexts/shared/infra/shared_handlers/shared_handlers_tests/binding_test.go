@@ -0,0 +1,71 @@
+package shared_handlers_tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bindingTestRequest struct {
+	Name  string  `json:"name" binding:"required"`
+	Price float64 `json:"price" binding:"min=0"`
+}
+
+func TestBindingError(t *testing.T) {
+	t.Run("should report every field that failed validation", func(t *testing.T) {
+		// Arrange
+		request := bindingTestRequest{Price: -5}
+		validationErr := binding.Validator.ValidateStruct(&request)
+		require.Error(t, validationErr)
+
+		// Act
+		err := shared_handlers.BindingError(validationErr)
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrInvalidPayload)
+		assert.Equal(t, models.FieldViolation{
+			Field:   "name",
+			Rule:    "required",
+			Value:   "",
+			Message: "name is required",
+		}, err.Details["name"])
+		assert.Equal(t, models.FieldViolation{
+			Field:   "price",
+			Rule:    "min",
+			Value:   -5.0,
+			Message: "price must be at least 0",
+		}, err.Details["price"])
+	})
+
+	t.Run("should report a value of the wrong type", func(t *testing.T) {
+		// Arrange
+		var request bindingTestRequest
+		decodeErr := json.Unmarshal([]byte(`{"name":"Mouse","price":"cheap"}`), &request)
+
+		// Act
+		err := shared_handlers.BindingError(decodeErr)
+
+		// Assert
+		assert.Equal(t, models.FieldViolation{
+			Field:   "price",
+			Rule:    "type",
+			Value:   "string",
+			Message: "price must be a number",
+		}, err.Details["price"])
+	})
+
+	t.Run("should report malformed JSON without details", func(t *testing.T) {
+		// Act
+		err := shared_handlers.BindingError(errors.New("unexpected EOF"))
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrInvalidPayload)
+		assert.Nil(t, err.Details)
+	})
+}
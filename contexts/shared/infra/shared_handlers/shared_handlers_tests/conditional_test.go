@@ -0,0 +1,82 @@
+package shared_handlers_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	lastModified := time.Date(2024, 1, 1, 12, 0, 0, 500, time.UTC)
+	etag := `"3"`
+
+	cases := []struct {
+		name        string
+		header      map[string]string
+		notModified bool
+	}{
+		{"send the representation without validators", nil, false},
+		{"answer 304 when the tag matches", map[string]string{"If-None-Match": `"3"`}, true},
+		{"answer 304 when any listed tag matches", map[string]string{"If-None-Match": `"1", W/"3"`}, true},
+		{"answer 304 for the wildcard", map[string]string{"If-None-Match": "*"}, true},
+		{"send the representation when no tag matches", map[string]string{"If-None-Match": `"2"`}, false},
+		{"answer 304 when not modified since", map[string]string{"If-Modified-Since": "Mon, 01 Jan 2024 12:00:00 GMT"}, true},
+		{"send the representation when modified since", map[string]string{"If-Modified-Since": "Mon, 01 Jan 2024 11:59:59 GMT"}, false},
+		{"ignore an invalid date", map[string]string{"If-Modified-Since": "yesterday"}, false},
+		{"prefer If-None-Match over If-Modified-Since", map[string]string{
+			"If-None-Match":     `"2"`,
+			"If-Modified-Since": "Mon, 01 Jan 2024 12:00:00 GMT",
+		}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run("should "+tc.name, func(t *testing.T) {
+			// Arrange
+			router := gin.New()
+			router.GET("/resource", func(c *gin.Context) {
+				if shared_handlers.NotModified(c, etag, lastModified) {
+					return
+				}
+				c.String(http.StatusOK, "body")
+			})
+			req := httptest.NewRequest(http.MethodGet, "/resource", nil)
+			for name, value := range tc.header {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, etag, w.Header().Get("ETag"))
+			assert.Equal(t, "Mon, 01 Jan 2024 12:00:00 GMT", w.Header().Get("Last-Modified"))
+			if tc.notModified {
+				assert.Equal(t, http.StatusNotModified, w.Code)
+				assert.Empty(t, w.Body.String())
+			} else {
+				assert.Equal(t, http.StatusOK, w.Code)
+			}
+		})
+	}
+}
+
+func TestContentETag(t *testing.T) {
+	t.Run("should only change with the content", func(t *testing.T) {
+		// Act
+		first := shared_handlers.ContentETag([]byte(`{"items":[]}`))
+		again := shared_handlers.ContentETag([]byte(`{"items":[]}`))
+		other := shared_handlers.ContentETag([]byte(`{"items":[1]}`))
+
+		// Assert
+		assert.Equal(t, first, again)
+		assert.NotEqual(t, first, other)
+		assert.Regexp(t, `^"[0-9a-f]{32}"$`, first)
+	})
+}
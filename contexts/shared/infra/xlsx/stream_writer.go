@@ -0,0 +1,122 @@
+// Package xlsx writes single-sheet Excel workbooks row by row. The sheet is
+// compressed straight into the output as rows arrive, so a workbook of any
+// size is written in constant memory.
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+const (
+	contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	rootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	workbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+	workbookStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="`
+	workbookEnd = `" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	sheetStart  = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	sheetEnd = `</sheetData></worksheet>`
+)
+
+// Cell is one value of a row. Numbers are stored as numeric cells so that
+// spreadsheets can compute with them; anything else is stored as text.
+type Cell struct {
+	Value  string
+	Number bool
+}
+
+func String(value string) Cell {
+	return Cell{Value: value}
+}
+
+// Number makes a numeric cell. value must be a plain decimal number such as
+// "9.99".
+func Number(value string) Cell {
+	return Cell{Value: value, Number: true}
+}
+
+type StreamWriter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+}
+
+// NewStreamWriter writes the workbook parts that come before the sheet data
+// to w. Close must be called after the last row to complete the file.
+func NewStreamWriter(w io.Writer, sheetName string) (*StreamWriter, error) {
+	archive := zip.NewWriter(w)
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rootRels},
+		{"xl/_rels/workbook.xml.rels", workbookRels},
+		{"xl/workbook.xml", workbookStart + escape(sheetName) + workbookEnd},
+	}
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	writer := &StreamWriter{archive: archive, sheet: bufio.NewWriter(sheet)}
+	if _, err := writer.sheet.WriteString(sheetStart); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (sw *StreamWriter) WriteRow(cells ...Cell) error {
+	if _, err := sw.sheet.WriteString("<row>"); err != nil {
+		return err
+	}
+	for _, cell := range cells {
+		var err error
+		if cell.Number {
+			_, err = sw.sheet.WriteString(`<c><v>` + escape(cell.Value) + `</v></c>`)
+		} else {
+			_, err = sw.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">` + escape(cell.Value) + `</t></is></c>`)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err := sw.sheet.WriteString("</row>")
+	return err
+}
+
+// Close ends the sheet and writes the archive directory. It does not close
+// the underlying writer.
+func (sw *StreamWriter) Close() error {
+	if _, err := sw.sheet.WriteString(sheetEnd); err != nil {
+		return err
+	}
+	if err := sw.sheet.Flush(); err != nil {
+		return err
+	}
+	return sw.archive.Close()
+}
+
+// escape also replaces characters that XML does not allow, such as control
+// characters, so that any text makes a valid sheet.
+func escape(value string) string {
+	var escaped strings.Builder
+	_ = xml.EscapeText(&escaped, []byte(value))
+	return escaped.String()
+}
@@ -0,0 +1,7 @@
+package dto
+
+type RegisterUserRequest struct {
+	Email string `json:"email" binding:"required"`
+	// Password needs 8 characters at least, and at most 72 bytes.
+	Password string `json:"password" binding:"required"`
+}
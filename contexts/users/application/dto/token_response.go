@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// TokenType is how the access token is sent: Authorization: Bearer <token>.
+const TokenType = "Bearer"
+
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	// ExpiresIn is the lifetime of the token in seconds.
+	ExpiresIn int64     `json:"expires_in"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func NewTokenResponse(token string, expiresAt time.Time) TokenResponse {
+	return TokenResponse{
+		AccessToken: token,
+		TokenType:   TokenType,
+		ExpiresIn:   int64(time.Until(expiresAt).Seconds()),
+		ExpiresAt:   expiresAt,
+	}
+}
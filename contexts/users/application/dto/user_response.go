@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+	"github.com/google/uuid"
+)
+
+type UserResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	Roles     []string  `json:"roles"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewUserResponseFromDomainModel(user models.User) UserResponse {
+	return UserResponse{
+		ID:        user.ID(),
+		Email:     user.Email(),
+		Roles:     user.Roles(),
+		CreatedAt: user.CreatedAt(),
+	}
+}
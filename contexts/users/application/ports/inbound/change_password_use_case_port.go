@@ -0,0 +1,7 @@
+package inbound
+
+import "context"
+
+type ChangePasswordUseCasePort interface {
+	Execute(ctx context.Context, currentPassword string, newPassword string) error
+}
@@ -0,0 +1,10 @@
+package inbound
+
+import (
+	"context"
+	"time"
+)
+
+type LoginUseCasePort interface {
+	Execute(ctx context.Context, email string, password string) (string, time.Time, error)
+}
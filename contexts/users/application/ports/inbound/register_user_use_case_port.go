@@ -0,0 +1,11 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+)
+
+type RegisterUserUseCasePort interface {
+	Execute(ctx context.Context, email string, password string) (models.User, error)
+}
@@ -0,0 +1,7 @@
+package outbound
+
+type PasswordHasherPort interface {
+	Hash(password string) (string, error)
+	// Matches reports whether password is the one hash was made from.
+	Matches(hash string, password string) (bool, error)
+}
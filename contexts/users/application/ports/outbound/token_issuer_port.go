@@ -0,0 +1,12 @@
+package outbound
+
+import (
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+)
+
+type TokenIssuerPort interface {
+	// Issue returns a signed access token for user and when it expires.
+	Issue(user models.User) (string, time.Time, error)
+}
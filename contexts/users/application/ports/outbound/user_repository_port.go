@@ -0,0 +1,15 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+	"github.com/google/uuid"
+)
+
+type UserRepositoryPort interface {
+	Create(ctx context.Context, user models.User) error
+	GetByID(ctx context.Context, id uuid.UUID) (models.User, error)
+	GetByEmail(ctx context.Context, email string) (models.User, error)
+	Update(ctx context.Context, user models.User) error
+}
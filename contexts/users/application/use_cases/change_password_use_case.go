@@ -0,0 +1,86 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/Akiles94/go-test-api/contexts/users/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+	"github.com/google/uuid"
+)
+
+type ChangePasswordUseCase struct {
+	repo      outbound.UserRepositoryPort
+	hasher    outbound.PasswordHasherPort
+	txManager interfaces.TransactionManager
+}
+
+func NewChangePasswordUseCase(repo outbound.UserRepositoryPort, hasher outbound.PasswordHasherPort, txManager interfaces.TransactionManager) *ChangePasswordUseCase {
+	return &ChangePasswordUseCase{
+		repo:      repo,
+		hasher:    hasher,
+		txManager: txManager,
+	}
+}
+
+// Execute replaces the password of the caller once currentPassword is
+// checked. A wrong current password fails with ErrIncorrectPassword and counts
+// towards the lockout like a failed login.
+func (uc *ChangePasswordUseCase) Execute(ctx context.Context, currentPassword string, newPassword string) error {
+	if err := models.ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
+	var changeErr error
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := currentUser(ctx, uc.repo)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		matches, err := checkPassword(ctx, uc.repo, uc.hasher, user, currentPassword, now)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			changeErr = refusal(user, now, shared_handlers.ErrIncorrectPassword)
+			return nil
+		}
+
+		passwordHash, err := uc.hasher.Hash(newPassword)
+		if err != nil {
+			return err
+		}
+		user.ChangePassword(passwordHash, now)
+		return uc.repo.Update(ctx, user)
+	})
+	if err != nil {
+		return err
+	}
+	return changeErr
+}
+
+// currentUser returns the user the caller of ctx is logged in as. Callers
+// authenticated otherwise, such as with an API key, fail with
+// ErrNotAUserAccount.
+func currentUser(ctx context.Context, repo outbound.UserRepositoryPort) (models.User, error) {
+	principal, ok := request_context.PrincipalFrom(ctx)
+	if !ok {
+		return nil, shared_handlers.ErrUnauthenticated
+	}
+	id, err := uuid.Parse(principal.Subject)
+	if err != nil {
+		return nil, shared_handlers.ErrNotAUserAccount
+	}
+	user, err := repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, shared_handlers.ErrNotAUserAccount
+	}
+	return user, nil
+}
@@ -0,0 +1,101 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/Akiles94/go-test-api/contexts/users/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+)
+
+type LoginUseCase struct {
+	repo      outbound.UserRepositoryPort
+	hasher    outbound.PasswordHasherPort
+	issuer    outbound.TokenIssuerPort
+	txManager interfaces.TransactionManager
+}
+
+func NewLoginUseCase(repo outbound.UserRepositoryPort, hasher outbound.PasswordHasherPort, issuer outbound.TokenIssuerPort, txManager interfaces.TransactionManager) *LoginUseCase {
+	return &LoginUseCase{
+		repo:      repo,
+		hasher:    hasher,
+		issuer:    issuer,
+		txManager: txManager,
+	}
+}
+
+// Execute checks the password of the user with email in the tenant of the
+// request and returns an access token for them. Unknown emails and wrong
+// passwords both fail with ErrInvalidCredentials; a locked account fails with
+// ErrAccountLocked, even with the right password.
+func (uc *LoginUseCase) Execute(ctx context.Context, email string, password string) (string, time.Time, error) {
+	var user models.User
+	var loginErr error
+	// GetByEmail locks the user until the transaction ends, so concurrent
+	// attempts are counted one at a time and cannot get past the lockout.
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = uc.repo.GetByEmail(ctx, models.NormalizeEmail(email))
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			// Hash anyway, so unknown emails take as long as wrong passwords.
+			if _, err := uc.hasher.Hash(password); err != nil {
+				return err
+			}
+			loginErr = shared_handlers.ErrInvalidCredentials
+			return nil
+		}
+		now := time.Now().UTC()
+		matches, err := checkPassword(ctx, uc.repo, uc.hasher, user, password, now)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			loginErr = refusal(user, now, shared_handlers.ErrInvalidCredentials)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if loginErr != nil {
+		return "", time.Time{}, loginErr
+	}
+	return uc.issuer.Issue(user)
+}
+
+// checkPassword compares password with the one of user and stores the
+// outcome for the lockout. A locked account is refused whatever the password.
+func checkPassword(ctx context.Context, repo outbound.UserRepositoryPort, hasher outbound.PasswordHasherPort, user models.User, password string, now time.Time) (bool, error) {
+	if user.IsLocked(now) {
+		return false, nil
+	}
+	matches, err := hasher.Matches(user.PasswordHash(), password)
+	if err != nil {
+		return false, err
+	}
+	if !matches {
+		user.RecordFailedLogin(now)
+		return false, repo.Update(ctx, user)
+	}
+	if user.FailedLogins() > 0 {
+		user.RecordSuccessfulLogin()
+		return true, repo.Update(ctx, user)
+	}
+	return true, nil
+}
+
+// refusal is the error a refused password fails with: ErrAccountLocked once
+// the account is locked, wrongPassword otherwise.
+func refusal(user models.User, now time.Time, wrongPassword error) error {
+	if !user.IsLocked(now) {
+		return wrongPassword
+	}
+	err := shared_handlers.ErrAccountLocked
+	err.Details = map[string]interface{}{"locked_until": user.LockedUntil()}
+	return err
+}
@@ -0,0 +1,52 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/authorization"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/users/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+	"github.com/google/uuid"
+)
+
+// DefaultRoles are granted to newly registered users.
+var DefaultRoles = []string{string(shared_models.RoleViewer)}
+
+type RegisterUserUseCase struct {
+	repo   outbound.UserRepositoryPort
+	hasher outbound.PasswordHasherPort
+}
+
+func NewRegisterUserUseCase(repo outbound.UserRepositoryPort, hasher outbound.PasswordHasherPort) *RegisterUserUseCase {
+	return &RegisterUserUseCase{
+		repo:   repo,
+		hasher: hasher,
+	}
+}
+
+// Execute creates an account in the tenant of the request with DefaultRoles.
+// Only callers allowed to manage users may create one, so nobody can sign up
+// into a tenant of their choosing. An email already registered in the tenant
+// fails with ErrDuplicateEmail.
+func (uc *RegisterUserUseCase) Execute(ctx context.Context, email string, password string) (models.User, error) {
+	if err := authorization.Authorize(ctx, shared_models.PermissionUsersManage); err != nil {
+		return nil, err
+	}
+	if err := models.ValidatePassword(password); err != nil {
+		return nil, err
+	}
+	passwordHash, err := uc.hasher.Hash(password)
+	if err != nil {
+		return nil, err
+	}
+	user, err := models.RegisterUser(uuid.New(), request_context.TenantFrom(ctx), email, passwordHash, DefaultRoles)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,20 @@
+package use_cases_mocks
+
+// hashPrefix marks the hashes of MockPasswordHasher.
+const hashPrefix = "hashed:"
+
+// MockPasswordHasher hashes passwords by prefixing them, so tests can tell
+// which password a hash was made from.
+type MockPasswordHasher struct{}
+
+func NewMockPasswordHasher() *MockPasswordHasher {
+	return &MockPasswordHasher{}
+}
+
+func (m *MockPasswordHasher) Hash(password string) (string, error) {
+	return hashPrefix + password, nil
+}
+
+func (m *MockPasswordHasher) Matches(hash string, password string) (bool, error) {
+	return hash == hashPrefix+password, nil
+}
@@ -0,0 +1,21 @@
+package use_cases_mocks
+
+import (
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTokenIssuer struct {
+	mock.Mock
+}
+
+func NewMockTokenIssuer() *MockTokenIssuer {
+	return &MockTokenIssuer{}
+}
+
+func (m *MockTokenIssuer) Issue(user models.User) (string, time.Time, error) {
+	args := m.Called(user)
+	return args.String(0), args.Get(1).(time.Time), args.Error(2)
+}
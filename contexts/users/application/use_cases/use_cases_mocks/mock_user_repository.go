@@ -0,0 +1,51 @@
+package use_cases_mocks
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func NewMockUserRepository() *MockUserRepository {
+	return &MockUserRepository{}
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user models.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetByID(ctx context.Context, id uuid.UUID) (models.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (models.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, user models.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SetupGetByIDSuccess(id uuid.UUID, user models.User) *mock.Call {
+	return m.On("GetByID", mock.Anything, id).Return(user, nil)
+}
+
+func (m *MockUserRepository) SetupGetByEmailSuccess(email string, user models.User) *mock.Call {
+	return m.On("GetByEmail", mock.Anything, email).Return(user, nil)
+}
@@ -0,0 +1,82 @@
+package use_cases_tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/Akiles94/go-test-api/contexts/users/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/users/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models/models_mothers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func contextWithSubject(subject string) context.Context {
+	return request_context.WithPrincipal(context.Background(), request_context.Principal{Subject: subject})
+}
+
+func newChangePasswordUseCase(repo *use_cases_mocks.MockUserRepository) *use_cases.ChangePasswordUseCase {
+	return use_cases.NewChangePasswordUseCase(repo, use_cases_mocks.NewMockPasswordHasher(), interfaces_mocks.NewMockTransactionManager())
+}
+
+func TestChangePasswordUseCase(t *testing.T) {
+	t.Run("should store the hash of the new password", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockUserRepository()
+		user := models_mothers.NewUserMother().WithPasswordHash("hashed:" + password).MustBuild()
+		repo.SetupGetByIDSuccess(user.ID(), user)
+		repo.On("Update", mock.Anything, user).Return(nil).Once()
+
+		// Act
+		err := newChangePasswordUseCase(repo).Execute(contextWithSubject(user.ID().String()), password, "battery staple")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "hashed:battery staple", user.PasswordHash())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should refuse a wrong current password and count it", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockUserRepository()
+		user := models_mothers.NewUserMother().WithPasswordHash("hashed:" + password).MustBuild()
+		repo.SetupGetByIDSuccess(user.ID(), user)
+		repo.On("Update", mock.Anything, user).Return(nil).Once()
+
+		// Act
+		err := newChangePasswordUseCase(repo).Execute(contextWithSubject(user.ID().String()), "wrong password", "battery staple")
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrIncorrectPassword)
+		assert.Equal(t, "hashed:"+password, user.PasswordHash())
+		assert.Equal(t, 1, user.FailedLogins())
+	})
+
+	t.Run("should reject a weak new password", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockUserRepository()
+
+		// Act
+		err := newChangePasswordUseCase(repo).Execute(contextWithSubject("user"), password, "short")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrUserPasswordTooShort)
+		repo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should refuse callers that are not user accounts", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockUserRepository()
+
+		// Act
+		err := newChangePasswordUseCase(repo).Execute(contextWithSubject("api-key:7c0e"), password, "battery staple")
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrNotAUserAccount)
+	})
+}
@@ -0,0 +1,125 @@
+package use_cases_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/Akiles94/go-test-api/contexts/users/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/users/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models/models_mothers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const password = "correct horse"
+
+func newLoginUseCase(repo *use_cases_mocks.MockUserRepository, issuer *use_cases_mocks.MockTokenIssuer) *use_cases.LoginUseCase {
+	return use_cases.NewLoginUseCase(repo, use_cases_mocks.NewMockPasswordHasher(), issuer, interfaces_mocks.NewMockTransactionManager())
+}
+
+func TestLoginUseCase(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+
+	t.Run("should issue a token for the right password", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockUserRepository()
+		issuer := use_cases_mocks.NewMockTokenIssuer()
+		user := models_mothers.NewUserMother().WithPasswordHash("hashed:" + password).MustBuild()
+		repo.SetupGetByEmailSuccess("jane@example.com", user)
+		issuer.On("Issue", user).Return("token", expiresAt, nil).Once()
+
+		// Act
+		token, tokenExpiresAt, err := newLoginUseCase(repo, issuer).Execute(context.Background(), " JANE@example.com", password)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "token", token)
+		assert.Equal(t, expiresAt, tokenExpiresAt)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should refuse an unknown email like a wrong password", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockUserRepository()
+		issuer := use_cases_mocks.NewMockTokenIssuer()
+		repo.On("GetByEmail", mock.Anything, "nobody@example.com").Return(nil, nil).Once()
+
+		// Act
+		_, _, err := newLoginUseCase(repo, issuer).Execute(context.Background(), "nobody@example.com", password)
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrInvalidCredentials)
+		issuer.AssertNotCalled(t, "Issue", mock.Anything)
+	})
+
+	t.Run("should count a wrong password", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockUserRepository()
+		issuer := use_cases_mocks.NewMockTokenIssuer()
+		user := models_mothers.NewUserMother().WithPasswordHash("hashed:" + password).MustBuild()
+		repo.SetupGetByEmailSuccess("jane@example.com", user)
+		repo.On("Update", mock.Anything, user).Return(nil).Once()
+
+		// Act
+		_, _, err := newLoginUseCase(repo, issuer).Execute(context.Background(), "jane@example.com", "wrong password")
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrInvalidCredentials)
+		assert.Equal(t, 1, user.FailedLogins())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should lock the account on the last allowed failure", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockUserRepository()
+		issuer := use_cases_mocks.NewMockTokenIssuer()
+		user := models_mothers.NewUserMother().WithPasswordHash("hashed:" + password).WithFailedLogins(models.MaxFailedLogins - 1).MustBuild()
+		repo.SetupGetByEmailSuccess("jane@example.com", user)
+		repo.On("Update", mock.Anything, user).Return(nil).Once()
+
+		// Act
+		_, _, err := newLoginUseCase(repo, issuer).Execute(context.Background(), "jane@example.com", "wrong password")
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrAccountLocked)
+		assert.True(t, user.IsLocked(time.Now()))
+	})
+
+	t.Run("should refuse a locked account even with the right password", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockUserRepository()
+		issuer := use_cases_mocks.NewMockTokenIssuer()
+		user := models_mothers.NewUserMother().WithPasswordHash("hashed:" + password).WithLockedUntil(time.Now().Add(time.Minute)).MustBuild()
+		repo.SetupGetByEmailSuccess("jane@example.com", user)
+
+		// Act
+		_, _, err := newLoginUseCase(repo, issuer).Execute(context.Background(), "jane@example.com", password)
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrAccountLocked)
+		issuer.AssertNotCalled(t, "Issue", mock.Anything)
+	})
+
+	t.Run("should forget failed logins once the right password is given", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockUserRepository()
+		issuer := use_cases_mocks.NewMockTokenIssuer()
+		user := models_mothers.NewUserMother().WithPasswordHash("hashed:" + password).WithFailedLogins(2).MustBuild()
+		repo.SetupGetByEmailSuccess("jane@example.com", user)
+		repo.On("Update", mock.Anything, user).Return(nil).Once()
+		issuer.On("Issue", user).Return("token", expiresAt, nil).Once()
+
+		// Act
+		_, _, err := newLoginUseCase(repo, issuer).Execute(context.Background(), "jane@example.com", password)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Zero(t, user.FailedLogins())
+		repo.AssertExpectations(t)
+	})
+}
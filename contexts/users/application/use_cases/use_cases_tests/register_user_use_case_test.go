@@ -0,0 +1,87 @@
+package use_cases_tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/Akiles94/go-test-api/contexts/users/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/users/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterUserUseCase(t *testing.T) {
+	admin := func(ctx context.Context) context.Context {
+		return request_context.WithPrincipal(ctx, request_context.Principal{Subject: "admin", Roles: []string{"admin"}})
+	}
+
+	t.Run("should store a viewer with the hash of the password in the tenant of the request", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockUserRepository()
+		repo.On("Create", mock.Anything, mock.MatchedBy(func(user models.User) bool {
+			return user.Email() == "jane@example.com" && user.Tenant() == "acme"
+		})).Return(nil).Once()
+		useCase := use_cases.NewRegisterUserUseCase(repo, use_cases_mocks.NewMockPasswordHasher())
+		ctx := admin(request_context.WithTenant(context.Background(), "acme"))
+
+		// Act
+		user, err := useCase.Execute(ctx, "Jane@example.com", "correct horse")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "hashed:correct horse", user.PasswordHash())
+		assert.Equal(t, []string{"viewer"}, user.Roles())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("should reject a weak password before hashing it", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockUserRepository()
+		useCase := use_cases.NewRegisterUserUseCase(repo, use_cases_mocks.NewMockPasswordHasher())
+
+		// Act
+		_, err := useCase.Execute(admin(context.Background()), "jane@example.com", "secret")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrUserPasswordTooShort)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should report an email already registered", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockUserRepository()
+		repo.On("Create", mock.Anything, mock.Anything).Return(shared_handlers.ErrDuplicateEmail).Once()
+		useCase := use_cases.NewRegisterUserUseCase(repo, use_cases_mocks.NewMockPasswordHasher())
+
+		// Act
+		_, err := useCase.Execute(admin(context.Background()), "jane@example.com", "correct horse")
+
+		// Assert
+		assert.ErrorIs(t, err, shared_handlers.ErrDuplicateEmail)
+	})
+
+	t.Run("should refuse callers that may not manage users", func(t *testing.T) {
+		// Arrange
+		repo := use_cases_mocks.NewMockUserRepository()
+		useCase := use_cases.NewRegisterUserUseCase(repo, use_cases_mocks.NewMockPasswordHasher())
+		cases := map[string]context.Context{
+			"anonymous": context.Background(),
+			"viewer":    request_context.WithPrincipal(context.Background(), request_context.Principal{Subject: "jane", Roles: []string{"viewer"}}),
+		}
+
+		for name, ctx := range cases {
+			t.Run(name, func(t *testing.T) {
+				// Act
+				_, err := useCase.Execute(ctx, "jane@example.com", "correct horse")
+
+				// Assert
+				assert.Error(t, err)
+				repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			})
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package models_mothers
+
+import (
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+	"github.com/google/uuid"
+)
+
+type UserMother struct {
+	Id                uuid.UUID
+	Tenant            string
+	Email             string
+	PasswordHash      string
+	Roles             []string
+	FailedLogins      int
+	LockedUntil       *time.Time
+	PasswordChangedAt time.Time
+	CreatedAt         time.Time
+}
+
+func NewUserMother() *UserMother {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &UserMother{
+		Id:                uuid.New(),
+		Tenant:            "default",
+		Email:             "jane@example.com",
+		PasswordHash:      "hashed-password",
+		Roles:             []string{"viewer"},
+		PasswordChangedAt: createdAt,
+		CreatedAt:         createdAt,
+	}
+}
+
+func (um *UserMother) WithID(id uuid.UUID) *UserMother {
+	um.Id = id
+	return um
+}
+
+func (um *UserMother) WithTenant(tenant string) *UserMother {
+	um.Tenant = tenant
+	return um
+}
+
+func (um *UserMother) WithEmail(email string) *UserMother {
+	um.Email = email
+	return um
+}
+
+func (um *UserMother) WithPasswordHash(passwordHash string) *UserMother {
+	um.PasswordHash = passwordHash
+	return um
+}
+
+func (um *UserMother) WithRoles(roles ...string) *UserMother {
+	um.Roles = roles
+	return um
+}
+
+func (um *UserMother) WithFailedLogins(failedLogins int) *UserMother {
+	um.FailedLogins = failedLogins
+	return um
+}
+
+func (um *UserMother) WithLockedUntil(lockedUntil time.Time) *UserMother {
+	um.LockedUntil = &lockedUntil
+	return um
+}
+
+func (um *UserMother) Build() (models.User, error) {
+	return models.ReconstituteUser(um.Id, um.Email, um.PasswordHash, um.Roles, models.UserMetadata{
+		Tenant:            um.Tenant,
+		FailedLogins:      um.FailedLogins,
+		LockedUntil:       um.LockedUntil,
+		PasswordChangedAt: um.PasswordChangedAt,
+		CreatedAt:         um.CreatedAt,
+	})
+}
+
+func (um *UserMother) MustBuild() models.User {
+	user, err := um.Build()
+	if err != nil {
+		panic("UserMother.MustBuild failed: " + err.Error())
+	}
+	return user
+}
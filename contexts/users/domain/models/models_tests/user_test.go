@@ -0,0 +1,133 @@
+package models_tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models/models_mothers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterUser(t *testing.T) {
+	t.Run("should register a user with a normalized email", func(t *testing.T) {
+		// Arrange
+		id := uuid.New()
+
+		// Act
+		user, err := models.RegisterUser(id, "acme", "  Jane@Example.COM ", "hashed-password", []string{"viewer"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, id, user.ID())
+		assert.Equal(t, "acme", user.Tenant())
+		assert.Equal(t, "jane@example.com", user.Email())
+		assert.Equal(t, "hashed-password", user.PasswordHash())
+		assert.Equal(t, []string{"viewer"}, user.Roles())
+		assert.False(t, user.IsLocked(time.Now()))
+	})
+
+	t.Run("should reject invalid data", func(t *testing.T) {
+		cases := map[string]struct {
+			id    uuid.UUID
+			email string
+			roles []string
+			err   error
+		}{
+			"nil id":        {uuid.Nil, "jane@example.com", []string{"viewer"}, models.ErrUserIdNil},
+			"invalid email": {uuid.New(), "jane", []string{"viewer"}, models.ErrUserEmailInvalid},
+			"named email":   {uuid.New(), "Jane <jane@example.com>", []string{"viewer"}, models.ErrUserEmailInvalid},
+			"no roles":      {uuid.New(), "jane@example.com", nil, models.ErrUserRolesInvalid},
+			"unknown role":  {uuid.New(), "jane@example.com", []string{"owner"}, models.ErrUserRolesInvalid},
+		}
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				// Act
+				user, err := models.RegisterUser(tc.id, "acme", tc.email, "hashed-password", tc.roles)
+
+				// Assert
+				assert.Nil(t, user)
+				assert.ErrorIs(t, err, tc.err)
+			})
+		}
+	})
+}
+
+func TestValidatePassword(t *testing.T) {
+	cases := map[string]struct {
+		password string
+		err      error
+	}{
+		"long enough":           {"correct horse", nil},
+		"too short":             {"secret", models.ErrUserPasswordTooShort},
+		"too long for bcrypt":   {strings.Repeat("a", models.MaxPasswordLength+1), models.ErrUserPasswordTooLong},
+		"multi-byte characters": {"пароль12", nil},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			err := models.ValidatePassword(tc.password)
+
+			// Assert
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestUserLockout(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should lock the account after too many failed logins", func(t *testing.T) {
+		// Arrange
+		user := models_mothers.NewUserMother().WithFailedLogins(models.MaxFailedLogins - 2).MustBuild()
+
+		// Act
+		user.RecordFailedLogin(now)
+
+		// Assert
+		assert.False(t, user.IsLocked(now))
+
+		// Act
+		user.RecordFailedLogin(now)
+
+		// Assert
+		assert.True(t, user.IsLocked(now))
+		assert.False(t, user.IsLocked(now.Add(models.LockoutDuration)))
+		assert.Zero(t, user.FailedLogins())
+	})
+
+	t.Run("should forget failed logins after a successful one", func(t *testing.T) {
+		// Arrange
+		user := models_mothers.NewUserMother().WithFailedLogins(models.MaxFailedLogins - 1).MustBuild()
+
+		// Act
+		user.RecordSuccessfulLogin()
+		user.RecordFailedLogin(now)
+
+		// Assert
+		assert.Equal(t, 1, user.FailedLogins())
+		assert.False(t, user.IsLocked(now))
+	})
+}
+
+func TestUserChangePassword(t *testing.T) {
+	t.Run("should replace the password hash", func(t *testing.T) {
+		// Arrange
+		user := models_mothers.NewUserMother().MustBuild()
+		now := time.Now().UTC()
+
+		// Act
+		user.ChangePassword("new-hash", now)
+
+		// Assert
+		assert.Equal(t, "new-hash", user.PasswordHash())
+		assert.Equal(t, now, user.PasswordChangedAt())
+	})
+}
@@ -0,0 +1,256 @@
+package models
+
+import (
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/google/uuid"
+)
+
+const (
+	MinPasswordLength = 8
+	// MaxPasswordLength is in bytes, the most bcrypt takes into account.
+	MaxPasswordLength = 72
+)
+
+// MaxFailedLogins is how many wrong passwords in a row lock an account, for
+// LockoutDuration.
+const (
+	MaxFailedLogins = 5
+	LockoutDuration = 15 * time.Minute
+)
+
+var (
+	ErrUserIdNil = shared_models.DomainError{
+		Code:    "USER_ID_NIL",
+		Message: "User ID cannot be nil",
+	}
+
+	ErrUserEmailInvalid = shared_models.DomainError{
+		Code:    "USER_EMAIL_INVALID",
+		Message: "Email must be a valid address",
+	}
+
+	ErrUserPasswordTooShort = shared_models.DomainError{
+		Code:    "USER_PASSWORD_TOO_SHORT",
+		Message: "Password must be at least 8 characters",
+	}
+
+	ErrUserPasswordTooLong = shared_models.DomainError{
+		Code:    "USER_PASSWORD_TOO_LONG",
+		Message: "Password must be at most 72 bytes",
+	}
+
+	ErrUserRolesInvalid = shared_models.DomainError{
+		Code:    "USER_ROLES_INVALID",
+		Message: "User needs at least one role, each a known role",
+	}
+)
+
+// User is an account that logs in with an email and a password. Only a hash
+// of the password is kept.
+type User interface {
+	ID() uuid.UUID
+	Tenant() string
+	Email() string
+	PasswordHash() string
+	Roles() []string
+	FailedLogins() int
+	LockedUntil() *time.Time
+	PasswordChangedAt() time.Time
+	CreatedAt() time.Time
+	IsLocked(now time.Time) bool
+	RecordFailedLogin(now time.Time)
+	RecordSuccessfulLogin()
+	ChangePassword(passwordHash string, now time.Time)
+}
+
+type user struct {
+	id                uuid.UUID
+	tenant            string
+	email             string
+	passwordHash      string
+	roles             []string
+	failedLogins      int
+	lockedUntil       *time.Time
+	passwordChangedAt time.Time
+	createdAt         time.Time
+}
+
+// UserMetadata holds the fields a user only gets once it is stored.
+type UserMetadata struct {
+	Tenant            string
+	FailedLogins      int
+	LockedUntil       *time.Time
+	PasswordChangedAt time.Time
+	CreatedAt         time.Time
+}
+
+// RegisterUser creates an account in tenant. The password must already be
+// hashed; check it with ValidatePassword first.
+func RegisterUser(id uuid.UUID, tenant, email, passwordHash string, roles []string) (User, error) {
+	now := time.Now().UTC()
+	if id == uuid.Nil {
+		return nil, ErrUserIdNil
+	}
+	email = NormalizeEmail(email)
+	if err := validateUser(email, roles); err != nil {
+		return nil, err
+	}
+
+	return &user{
+		id:                id,
+		tenant:            tenant,
+		email:             email,
+		passwordHash:      passwordHash,
+		roles:             slices.Clone(roles),
+		passwordChangedAt: now,
+		createdAt:         now,
+	}, nil
+}
+
+// ReconstituteUser rebuilds a stored user.
+func ReconstituteUser(id uuid.UUID, email, passwordHash string, roles []string, metadata UserMetadata) (User, error) {
+	if id == uuid.Nil {
+		return nil, ErrUserIdNil
+	}
+	if err := validateUser(email, roles); err != nil {
+		return nil, err
+	}
+
+	return &user{
+		id:                id,
+		tenant:            metadata.Tenant,
+		email:             email,
+		passwordHash:      passwordHash,
+		roles:             slices.Clone(roles),
+		failedLogins:      metadata.FailedLogins,
+		lockedUntil:       metadata.LockedUntil,
+		passwordChangedAt: metadata.PasswordChangedAt,
+		createdAt:         metadata.CreatedAt,
+	}, nil
+}
+
+// NormalizeEmail is how emails are stored and looked up, so that the case
+// they are typed in does not matter.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ValidatePassword checks a new password before it is hashed.
+func ValidatePassword(password string) error {
+	var err shared_models.DomainError
+	switch {
+	case len([]rune(password)) < MinPasswordLength:
+		err = ErrUserPasswordTooShort
+	case len(password) > MaxPasswordLength:
+		err = ErrUserPasswordTooLong
+	default:
+		return nil
+	}
+	// The password itself is never echoed back.
+	return err.WithFieldViolations(shared_models.FieldViolation{
+		Field:   "password",
+		Rule:    "length",
+		Message: err.Message,
+	})
+}
+
+// validateUser checks every field and returns the error of the first invalid
+// one, with all the invalid fields in its details.
+func validateUser(email string, roles []string) error {
+	var first *shared_models.DomainError
+	var violations []shared_models.FieldViolation
+	reject := func(err shared_models.DomainError, field, rule string, value interface{}) {
+		if first == nil {
+			first = &err
+		}
+		violations = append(violations, shared_models.FieldViolation{
+			Field:   field,
+			Rule:    rule,
+			Value:   value,
+			Message: err.Message,
+		})
+	}
+
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		reject(ErrUserEmailInvalid, "email", "email", email)
+	}
+	if len(roles) == 0 || slices.ContainsFunc(roles, func(role string) bool {
+		return !shared_models.Role(role).IsKnown()
+	}) {
+		reject(ErrUserRolesInvalid, "roles", "oneof", roles)
+	}
+	if first == nil {
+		return nil
+	}
+	return first.WithFieldViolations(violations...)
+}
+
+func (u *user) ID() uuid.UUID {
+	return u.id
+}
+
+// Tenant is the tenant the account belongs to, and the only one its tokens
+// give access to.
+func (u *user) Tenant() string {
+	return u.tenant
+}
+
+func (u *user) Email() string {
+	return u.email
+}
+
+func (u *user) PasswordHash() string {
+	return u.passwordHash
+}
+
+func (u *user) Roles() []string {
+	return slices.Clone(u.roles)
+}
+
+func (u *user) FailedLogins() int {
+	return u.failedLogins
+}
+
+func (u *user) LockedUntil() *time.Time {
+	return u.lockedUntil
+}
+
+func (u *user) PasswordChangedAt() time.Time {
+	return u.passwordChangedAt
+}
+
+func (u *user) CreatedAt() time.Time {
+	return u.createdAt
+}
+
+// IsLocked reports whether logins are refused at now, whatever the password.
+func (u *user) IsLocked(now time.Time) bool {
+	return u.lockedUntil != nil && now.Before(*u.lockedUntil)
+}
+
+// RecordFailedLogin counts a wrong password, and locks the account once
+// MaxFailedLogins were given in a row. The count starts again after the lock.
+func (u *user) RecordFailedLogin(now time.Time) {
+	u.failedLogins++
+	if u.failedLogins >= MaxFailedLogins {
+		lockedUntil := now.Add(LockoutDuration)
+		u.lockedUntil = &lockedUntil
+		u.failedLogins = 0
+	}
+}
+
+// RecordSuccessfulLogin forgets the wrong passwords given so far.
+func (u *user) RecordSuccessfulLogin() {
+	u.failedLogins = 0
+	u.lockedUntil = nil
+}
+
+func (u *user) ChangePassword(passwordHash string, now time.Time) {
+	u.passwordHash = passwordHash
+	u.passwordChangedAt = now
+}
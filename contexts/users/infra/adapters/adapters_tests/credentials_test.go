@@ -0,0 +1,56 @@
+package adapters_tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/jwt"
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/users/infra/adapters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestBcryptPasswordHasher(t *testing.T) {
+	hasher := adapters.NewBcryptPasswordHasher(bcrypt.MinCost)
+
+	t.Run("should only match the password a hash was made from", func(t *testing.T) {
+		// Arrange
+		hash, err := hasher.Hash("correct horse")
+		require.NoError(t, err)
+
+		// Act
+		right, rightErr := hasher.Matches(hash, "correct horse")
+		wrong, wrongErr := hasher.Matches(hash, "wrong password")
+
+		// Assert
+		assert.NotContains(t, hash, "correct horse")
+		assert.NoError(t, rightErr)
+		assert.True(t, right)
+		assert.NoError(t, wrongErr)
+		assert.False(t, wrong)
+	})
+}
+
+func TestJWTTokenIssuer(t *testing.T) {
+	t.Run("should issue tokens the API accepts, bound to the tenant of the user", func(t *testing.T) {
+		// Arrange
+		secret := []byte("test-secret")
+		issuer := adapters.NewJWTTokenIssuer(jwt.NewSigner(secret), "go-test-api", "go-test-api", time.Hour)
+		user := models_mothers.NewUserMother().WithTenant("acme").WithRoles("editor").MustBuild()
+		verifier := jwt.NewVerifier([]jwt.Key{jwt.NewHMACKey("", secret)}, jwt.Config{Issuer: "go-test-api", Audience: "go-test-api"})
+
+		// Act
+		token, expiresAt, err := issuer.Issue(user)
+
+		// Assert
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Second)
+		principal, err := verifier.Verify(token)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID().String(), principal.Subject)
+		assert.Equal(t, []string{"editor"}, principal.Roles)
+		assert.Equal(t, "acme", principal.Tenant())
+	})
+}
@@ -0,0 +1,154 @@
+package adapters_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/users/infra/adapters"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgres_driver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type UserRepositoryTestSuite struct {
+	suite.Suite
+	container *postgres.PostgresContainer
+	db        *gorm.DB
+	repo      *adapters.UserRepository
+	ctx       context.Context
+}
+
+func (suite *UserRepositoryTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	container, err := postgres.Run(suite.ctx,
+		"postgres:15-alpine",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	connStr, err := container.ConnectionString(suite.ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	db, err := gorm.Open(postgres_driver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = db.AutoMigrate(&adapters.UserEntity{})
+	suite.Require().NoError(err)
+
+	suite.repo = adapters.NewUserRepository(db)
+	suite.db = db
+}
+
+func (suite *UserRepositoryTestSuite) TearDownSuite() {
+	if suite.container != nil {
+		suite.container.Terminate(suite.ctx)
+	}
+}
+
+func (suite *UserRepositoryTestSuite) TearDownTest() {
+	if suite.db != nil {
+		suite.db.Exec("TRUNCATE TABLE user_entities")
+	}
+}
+
+func (suite *UserRepositoryTestSuite) TestCreateAndGetByEmail() {
+	suite.Run("should find a registered user by email", func() {
+		// Arrange
+		user := models_mothers.NewUserMother().WithRoles("editor").MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, user))
+
+		// Act
+		found, err := suite.repo.GetByEmail(suite.ctx, user.Email())
+
+		// Assert
+		suite.Require().NoError(err)
+		suite.Require().NotNil(found)
+		suite.Equal(user.ID(), found.ID())
+		suite.Equal(user.PasswordHash(), found.PasswordHash())
+		suite.Equal([]string{"editor"}, found.Roles())
+	})
+
+	suite.Run("should refuse an email already registered in the tenant", func() {
+		// Arrange
+		suite.Require().NoError(suite.repo.Create(suite.ctx, models_mothers.NewUserMother().WithEmail("john@example.com").MustBuild()))
+
+		// Act
+		err := suite.repo.Create(suite.ctx, models_mothers.NewUserMother().WithEmail("john@example.com").MustBuild())
+
+		// Assert
+		suite.ErrorIs(err, shared_handlers.ErrDuplicateEmail)
+	})
+}
+
+func (suite *UserRepositoryTestSuite) TestTenantIsolation() {
+	suite.Run("should keep users and their emails per tenant", func() {
+		// Arrange
+		acme := request_context.WithTenant(suite.ctx, "acme")
+		globex := request_context.WithTenant(suite.ctx, "globex")
+		user := models_mothers.NewUserMother().WithTenant("acme").MustBuild()
+		suite.Require().NoError(suite.repo.Create(acme, user))
+
+		// Act
+		byID, getErr := suite.repo.GetByID(globex, user.ID())
+		byEmail, emailErr := suite.repo.GetByEmail(globex, user.Email())
+		createErr := suite.repo.Create(globex, models_mothers.NewUserMother().WithTenant("globex").MustBuild())
+
+		// Assert
+		suite.NoError(getErr)
+		suite.Nil(byID)
+		suite.NoError(emailErr)
+		suite.Nil(byEmail)
+		suite.NoError(createErr)
+	})
+}
+
+func (suite *UserRepositoryTestSuite) TestUpdate() {
+	suite.Run("should store the lockout and the new password", func() {
+		// Arrange
+		user := models_mothers.NewUserMother().MustBuild()
+		suite.Require().NoError(suite.repo.Create(suite.ctx, user))
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		for range models.MaxFailedLogins {
+			user.RecordFailedLogin(now)
+		}
+		user.ChangePassword("new-hash", now)
+
+		// Act
+		err := suite.repo.Update(suite.ctx, user)
+
+		// Assert
+		suite.Require().NoError(err)
+		stored, err := suite.repo.GetByID(suite.ctx, user.ID())
+		suite.Require().NoError(err)
+		suite.True(stored.IsLocked(now))
+		suite.Equal("new-hash", stored.PasswordHash())
+	})
+
+	suite.Run("should fail with not found for an unknown user", func() {
+		// Act
+		err := suite.repo.Update(suite.ctx, models_mothers.NewUserMother().MustBuild())
+
+		// Assert
+		suite.ErrorIs(err, shared_handlers.ErrNotFound)
+	})
+}
+
+func TestUserRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(UserRepositoryTestSuite))
+}
@@ -0,0 +1,34 @@
+package adapters
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptPasswordHasher hashes passwords with bcrypt, which salts each hash and
+// is slow on purpose. A higher cost makes hashes harder to crack and logins
+// slower.
+type BcryptPasswordHasher struct {
+	cost int
+}
+
+func NewBcryptPasswordHasher(cost int) *BcryptPasswordHasher {
+	return &BcryptPasswordHasher{cost: cost}
+}
+
+func (bh *BcryptPasswordHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bh.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (bh *BcryptPasswordHasher) Matches(hash string, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,53 @@
+package adapters
+
+import (
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/jwt"
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+)
+
+// JWTTokenIssuer issues the tokens users log in with. They carry the roles and
+// tenant of the user, and the issuer and audience the API expects, so the
+// API accepts them like tokens of any other issuer.
+type JWTTokenIssuer struct {
+	signer   *jwt.Signer
+	issuer   string
+	audience string
+	ttl      time.Duration
+}
+
+func NewJWTTokenIssuer(signer *jwt.Signer, issuer string, audience string, ttl time.Duration) *JWTTokenIssuer {
+	return &JWTTokenIssuer{
+		signer:   signer,
+		issuer:   issuer,
+		audience: audience,
+		ttl:      ttl,
+	}
+}
+
+func (ti *JWTTokenIssuer) Issue(user models.User) (string, time.Time, error) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(ti.ttl)
+	claims := map[string]interface{}{
+		"sub":                       user.ID().String(),
+		"iat":                       now.Unix(),
+		"exp":                       expiresAt.Unix(),
+		"email":                     user.Email(),
+		"roles":                     user.Roles(),
+		request_context.TenantClaim: user.Tenant(),
+	}
+	if ti.issuer != "" {
+		claims["iss"] = ti.issuer
+	}
+	if ti.audience != "" {
+		claims["aud"] = ti.audience
+	}
+
+	token, err := ti.signer.Sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, time.Unix(expiresAt.Unix(), 0).UTC(), nil
+}
@@ -0,0 +1,61 @@
+package adapters
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+	"github.com/google/uuid"
+)
+
+// userEmailIndex keeps emails unique within a tenant.
+const userEmailIndex = "idx_user_entities_tenant_email"
+
+type UserEntity struct {
+	ID                uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TenantID          string    `gorm:"not null;default:default;uniqueIndex:idx_user_entities_tenant_email,priority:1"`
+	Email             string    `gorm:"not null;uniqueIndex:idx_user_entities_tenant_email,priority:2"`
+	PasswordHash      string    `gorm:"not null"`
+	Roles             []byte    `gorm:"type:jsonb;not null"`
+	FailedLogins      int       `gorm:"not null"`
+	LockedUntil       *time.Time
+	PasswordChangedAt time.Time `gorm:"not null"`
+	CreatedAt         time.Time `gorm:"not null"`
+}
+
+func NewUserEntity(user models.User) (UserEntity, error) {
+	roles, err := json.Marshal(user.Roles())
+	if err != nil {
+		return UserEntity{}, err
+	}
+	return UserEntity{
+		ID:                user.ID(),
+		TenantID:          user.Tenant(),
+		Email:             user.Email(),
+		PasswordHash:      user.PasswordHash(),
+		Roles:             roles,
+		FailedLogins:      user.FailedLogins(),
+		LockedUntil:       user.LockedUntil(),
+		PasswordChangedAt: user.PasswordChangedAt(),
+		CreatedAt:         user.CreatedAt(),
+	}, nil
+}
+
+func (e *UserEntity) ToDomainModel() (models.User, error) {
+	var roles []string
+	if err := json.Unmarshal(e.Roles, &roles); err != nil {
+		return nil, err
+	}
+	var lockedUntil *time.Time
+	if e.LockedUntil != nil {
+		value := e.LockedUntil.UTC()
+		lockedUntil = &value
+	}
+	return models.ReconstituteUser(e.ID, e.Email, e.PasswordHash, roles, models.UserMetadata{
+		Tenant:            e.TenantID,
+		FailedLogins:      e.FailedLogins,
+		LockedUntil:       lockedUntil,
+		PasswordChangedAt: e.PasswordChangedAt.UTC(),
+		CreatedAt:         e.CreatedAt.UTC(),
+	})
+}
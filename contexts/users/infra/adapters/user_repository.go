@@ -0,0 +1,85 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_adapters"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserRepository manages the users of the tenant of the request context.
+type UserRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (ur *UserRepository) scoped(ctx context.Context) *gorm.DB {
+	return shared_adapters.DBFromContext(ctx, ur.db).Where("tenant_id = ?", request_context.TenantFrom(ctx))
+}
+
+func (ur *UserRepository) Create(ctx context.Context, user models.User) error {
+	entity, err := NewUserEntity(user)
+	if err != nil {
+		return err
+	}
+	err = shared_adapters.DBFromContext(ctx, ur.db).Create(&entity).Error
+	if shared_adapters.IsUniqueViolation(err, userEmailIndex) {
+		return shared_handlers.ErrDuplicateEmail.WithFieldViolations(shared_models.FieldViolation{
+			Field:   "email",
+			Rule:    "unique",
+			Value:   user.Email(),
+			Message: "email already exists",
+		})
+	}
+	return shared_adapters.TranslateError(err)
+}
+
+func (ur *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (models.User, error) {
+	return ur.first(ur.scoped(ctx).Where("id = ?", id))
+}
+
+// GetByEmail locks the user it finds until the transaction of ctx ends, so
+// logins that count failures for the same user run one after the other.
+func (ur *UserRepository) GetByEmail(ctx context.Context, email string) (models.User, error) {
+	return ur.first(ur.scoped(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("email = ?", email))
+}
+
+func (ur *UserRepository) first(query *gorm.DB) (models.User, error) {
+	var entity UserEntity
+	if err := query.First(&entity).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return entity.ToDomainModel()
+}
+
+// Update saves what logging in and changing the password change.
+func (ur *UserRepository) Update(ctx context.Context, user models.User) error {
+	result := ur.scoped(ctx).
+		Model(&UserEntity{}).
+		Where("id = ?", user.ID()).
+		Updates(map[string]interface{}{
+			"password_hash":       user.PasswordHash(),
+			"failed_logins":       user.FailedLogins(),
+			"locked_until":        user.LockedUntil(),
+			"password_changed_at": user.PasswordChangedAt(),
+		})
+	if result.Error != nil {
+		return shared_adapters.TranslateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return shared_handlers.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package handlers_mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRegisterUserUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRegisterUserUseCase) Execute(ctx context.Context, email string, password string) (models.User, error) {
+	args := m.Called(ctx, email, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(models.User), args.Error(1)
+}
+
+type MockLoginUseCase struct {
+	mock.Mock
+}
+
+func (m *MockLoginUseCase) Execute(ctx context.Context, email string, password string) (string, time.Time, error) {
+	args := m.Called(ctx, email, password)
+	return args.String(0), args.Get(1).(time.Time), args.Error(2)
+}
+
+type MockChangePasswordUseCase struct {
+	mock.Mock
+}
+
+func (m *MockChangePasswordUseCase) Execute(ctx context.Context, currentPassword string, newPassword string) error {
+	args := m.Called(ctx, currentPassword, newPassword)
+	return args.Error(0)
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/shared_dto"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/middlewares"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/Akiles94/go-test-api/contexts/users/application/dto"
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/users/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/users/infra/handlers"
+	"github.com/Akiles94/go-test-api/contexts/users/infra/handlers/handlers_mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type UserHandlerTestSuite struct {
+	suite.Suite
+	handler *handlers.UserHandler
+	router  *gin.Engine
+
+	mockRegisterUseCase       *handlers_mocks.MockRegisterUserUseCase
+	mockLoginUseCase          *handlers_mocks.MockLoginUseCase
+	mockChangePasswordUseCase *handlers_mocks.MockChangePasswordUseCase
+}
+
+func (suite *UserHandlerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *UserHandlerTestSuite) SetupTest() {
+	suite.mockRegisterUseCase = new(handlers_mocks.MockRegisterUserUseCase)
+	suite.mockLoginUseCase = new(handlers_mocks.MockLoginUseCase)
+	suite.mockChangePasswordUseCase = new(handlers_mocks.MockChangePasswordUseCase)
+
+	suite.handler = handlers.NewUserHandler(
+		suite.mockRegisterUseCase,
+		suite.mockLoginUseCase,
+		suite.mockChangePasswordUseCase,
+	)
+
+	suite.router = gin.New()
+	suite.router.Use(middlewares.ErrorHandlerMiddleware())
+
+	suite.router.POST("/auth/register", suite.handler.Register)
+	suite.router.POST("/auth/login", suite.handler.Login)
+	suite.router.PUT("/auth/password", suite.handler.ChangePassword)
+}
+
+func (suite *UserHandlerTestSuite) request(method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *UserHandlerTestSuite) TestRegister() {
+	suite.Run("should return the new user without its password", func() {
+		// Arrange
+		user := models_mothers.NewUserMother().MustBuild()
+		suite.mockRegisterUseCase.On("Execute", mock.Anything, "jane@example.com", "correct horse").Return(user, nil).Once()
+
+		// Act
+		w := suite.request(http.MethodPost, "/auth/register", `{"email":"jane@example.com","password":"correct horse"}`)
+
+		// Assert
+		suite.Equal(http.StatusCreated, w.Code)
+		suite.NotContains(w.Body.String(), user.PasswordHash())
+		var response dto.UserResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(user.ID(), response.ID)
+		suite.Equal([]string{"viewer"}, response.Roles)
+	})
+
+	suite.Run("should return domain validation errors", func() {
+		// Arrange
+		suite.mockRegisterUseCase.On("Execute", mock.Anything, "jane@example.com", "short").Return(nil, models.ErrUserPasswordTooShort).Once()
+
+		// Act
+		w := suite.request(http.MethodPost, "/auth/register", `{"email":"jane@example.com","password":"short"}`)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+		var response shared_dto.ErrorResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(models.ErrUserPasswordTooShort.Code, response.Error)
+	})
+
+	suite.Run("should return 409 for an email already registered", func() {
+		// Arrange
+		suite.mockRegisterUseCase.On("Execute", mock.Anything, "taken@example.com", "correct horse").Return(nil, shared_handlers.ErrDuplicateEmail).Once()
+
+		// Act
+		w := suite.request(http.MethodPost, "/auth/register", `{"email":"taken@example.com","password":"correct horse"}`)
+
+		// Assert
+		suite.Equal(http.StatusConflict, w.Code)
+	})
+}
+
+func (suite *UserHandlerTestSuite) TestLogin() {
+	suite.Run("should return a bearer token", func() {
+		// Arrange
+		expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		suite.mockLoginUseCase.On("Execute", mock.Anything, "jane@example.com", "correct horse").Return("token", expiresAt, nil).Once()
+
+		// Act
+		w := suite.request(http.MethodPost, "/auth/login", `{"email":"jane@example.com","password":"correct horse"}`)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Equal("no-store", w.Header().Get("Cache-Control"))
+		var response dto.TokenResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal("token", response.AccessToken)
+		suite.Equal("Bearer", response.TokenType)
+		suite.InDelta(3600, response.ExpiresIn, 2)
+	})
+
+	suite.Run("should return 401 for wrong credentials and 429 for a locked account", func() {
+		// Arrange
+		suite.mockLoginUseCase.On("Execute", mock.Anything, "jane@example.com", "wrong password").Return("", time.Time{}, shared_handlers.ErrInvalidCredentials).Once()
+		suite.mockLoginUseCase.On("Execute", mock.Anything, "locked@example.com", "correct horse").Return("", time.Time{}, shared_handlers.ErrAccountLocked).Once()
+
+		// Act
+		wrong := suite.request(http.MethodPost, "/auth/login", `{"email":"jane@example.com","password":"wrong password"}`)
+		locked := suite.request(http.MethodPost, "/auth/login", `{"email":"locked@example.com","password":"correct horse"}`)
+
+		// Assert
+		suite.Equal(http.StatusUnauthorized, wrong.Code)
+		suite.Equal(http.StatusTooManyRequests, locked.Code)
+	})
+
+	suite.Run("should reject a request without a password", func() {
+		// Act
+		w := suite.request(http.MethodPost, "/auth/login", `{"email":"jane@example.com"}`)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+}
+
+func (suite *UserHandlerTestSuite) TestChangePassword() {
+	suite.Run("should return no content", func() {
+		// Arrange
+		suite.mockChangePasswordUseCase.On("Execute", mock.Anything, "correct horse", "battery staple").Return(nil).Once()
+
+		// Act
+		w := suite.request(http.MethodPut, "/auth/password", `{"current_password":"correct horse","new_password":"battery staple"}`)
+
+		// Assert
+		suite.Equal(http.StatusNoContent, w.Code)
+	})
+
+	suite.Run("should return 400 for a wrong current password", func() {
+		// Arrange
+		suite.mockChangePasswordUseCase.On("Execute", mock.Anything, "wrong password", "battery staple").Return(shared_handlers.ErrIncorrectPassword).Once()
+
+		// Act
+		w := suite.request(http.MethodPut, "/auth/password", `{"current_password":"wrong password","new_password":"battery staple"}`)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestUserHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(UserHandlerTestSuite))
+}
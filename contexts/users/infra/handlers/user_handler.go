@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/Akiles94/go-test-api/contexts/users/application/dto"
+	"github.com/Akiles94/go-test-api/contexts/users/application/ports/inbound"
+	"github.com/gin-gonic/gin"
+)
+
+// UserHandler handles HTTP requests for user accounts
+type UserHandler struct {
+	registerUserUseCase   inbound.RegisterUserUseCasePort
+	loginUseCase          inbound.LoginUseCasePort
+	changePasswordUseCase inbound.ChangePasswordUseCasePort
+}
+
+// NewUserHandler creates a new UserHandler
+func NewUserHandler(registerUserUseCase inbound.RegisterUserUseCasePort, loginUseCase inbound.LoginUseCasePort, changePasswordUseCase inbound.ChangePasswordUseCasePort) *UserHandler {
+	return &UserHandler{
+		registerUserUseCase:   registerUserUseCase,
+		loginUseCase:          loginUseCase,
+		changePasswordUseCase: changePasswordUseCase,
+	}
+}
+
+// Register godoc
+// @Summary Register a user
+// @Description Create an account with an email and a password in the tenant of the caller. New users get the viewer role. Needs users:manage
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param user body dto.RegisterUserRequest true "Account details"
+// @Success 201 {object} dto.UserResponse
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 409 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /auth/register [post]
+func (uh *UserHandler) Register(c *gin.Context) {
+	var request dto.RegisterUserRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(shared_handlers.BindingError(err))
+		return
+	}
+
+	user, err := uh.registerUserUseCase.Execute(c.Request.Context(), request.Email, request.Password)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewUserResponseFromDomainModel(user))
+}
+
+// Login godoc
+// @Summary Log in
+// @Description Exchange an email and a password for a bearer token. After 5 wrong passwords in a row the account is locked for 15 minutes
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body dto.LoginRequest true "Email and password"
+// @Param X-Tenant-ID header string false "Tenant of the account (default: default)"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 429 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Router /auth/login [post]
+func (uh *UserHandler) Login(c *gin.Context) {
+	var request dto.LoginRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(shared_handlers.BindingError(err))
+		return
+	}
+
+	token, expiresAt, err := uh.loginUseCase.Execute(c.Request.Context(), request.Email, request.Password)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, dto.NewTokenResponse(token, expiresAt))
+}
+
+// ChangePassword godoc
+// @Summary Change password
+// @Description Replace the password of the logged-in user. A wrong current password counts towards the lockout like a failed login. Tokens issued before stay valid until they expire
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param passwords body dto.ChangePasswordRequest true "Current and new password"
+// @Success 204
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 429 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Router /auth/password [put]
+func (uh *UserHandler) ChangePassword(c *gin.Context) {
+	var request dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(shared_handlers.BindingError(err))
+		return
+	}
+
+	if err := uh.changePasswordUseCase.Execute(c.Request.Context(), request.CurrentPassword, request.NewPassword); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,49 @@
+package modules
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/jwt"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/middlewares"
+	"github.com/Akiles94/go-test-api/contexts/users/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/users/infra/adapters"
+	"github.com/Akiles94/go-test-api/contexts/users/infra/handlers"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// passwordHashCost is the bcrypt cost passwords are hashed with.
+const passwordHashCost = 12
+
+type UsersModule struct {
+	handler *handlers.UserHandler
+}
+
+// NewUsersModule wires the users context. Tokens are signed by signer and
+// state issuer and audience, so that the API accepts them.
+func NewUsersModule(db *gorm.DB, txManager interfaces.TransactionManager, signer *jwt.Signer, issuer string, audience string, tokenTTL time.Duration) *UsersModule {
+	repo := adapters.NewUserRepository(db)
+	hasher := adapters.NewBcryptPasswordHasher(passwordHashCost)
+	tokenIssuer := adapters.NewJWTTokenIssuer(signer, issuer, audience, tokenTTL)
+
+	handler := handlers.NewUserHandler(
+		use_cases.NewRegisterUserUseCase(repo, hasher),
+		use_cases.NewLoginUseCase(repo, hasher, tokenIssuer, txManager),
+		use_cases.NewChangePasswordUseCase(repo, hasher, txManager))
+
+	return &UsersModule{handler: handler}
+}
+
+// RegisterRoutes adds the auth routes to router, which must not require
+// authentication: logging in is how callers get a token. Accounts are created
+// by callers allowed to manage users.
+func (um *UsersModule) RegisterRoutes(router *gin.RouterGroup) {
+	middlewares.RegisterRoutes(router, []middlewares.Route{
+		{Method: http.MethodPost, Path: "/register", Permission: shared_models.PermissionUsersManage, Handler: um.handler.Register},
+	})
+	router.POST("/login", um.handler.Login)
+	router.Handle(http.MethodPut, "/password", middlewares.RequireAuthentication(), um.handler.ChangePassword)
+}
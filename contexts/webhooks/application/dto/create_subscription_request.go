@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/google/uuid"
+)
+
+type CreateSubscriptionRequest struct {
+	URL        string   `json:"url" binding:"required"`
+	EventTypes []string `json:"event_types" binding:"required,min=1"`
+	// Secret is generated when omitted.
+	Secret *string `json:"secret,omitempty"`
+}
+
+func (c *CreateSubscriptionRequest) ToDomainModel() (models.Subscription, error) {
+	secret := ""
+	if c.Secret != nil {
+		secret = *c.Secret
+	} else {
+		generated, err := models.GenerateSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+	return models.NewSubscription(uuid.New(), c.URL, c.EventTypes, secret)
+}
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/pagination"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/google/uuid"
+)
+
+const (
+	cursorKeyCreatedAt   = "at"
+	cursorKeyID          = "id"
+	filterSubscriptionID = "subscription_id"
+)
+
+// NewDeliveryPaginationCursor ties the position to the subscription so a
+// cursor cannot be replayed against another subscription's log.
+func NewDeliveryPaginationCursor(subscriptionID uuid.UUID, position models.DeliveryCursor) pagination.Cursor {
+	return pagination.Cursor{
+		Direction: pagination.DirectionNext,
+		Key: map[string]string{
+			cursorKeyCreatedAt: position.CreatedAt.Format(time.RFC3339Nano),
+			cursorKeyID:        position.ID.String(),
+		},
+		Filters: map[string]string{
+			filterSubscriptionID: subscriptionID.String(),
+		},
+	}
+}
+
+func DeliveryCursorFromPaginationCursor(subscriptionID uuid.UUID, cursor pagination.Cursor) (models.DeliveryCursor, error) {
+	if cursor.Direction != pagination.DirectionNext || cursor.Filters[filterSubscriptionID] != subscriptionID.String() {
+		return models.DeliveryCursor{}, pagination.ErrInvalidCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, cursor.Key[cursorKeyCreatedAt])
+	if err != nil {
+		return models.DeliveryCursor{}, pagination.ErrInvalidCursor
+	}
+	id, err := uuid.Parse(cursor.Key[cursorKeyID])
+	if err != nil {
+		return models.DeliveryCursor{}, pagination.ErrInvalidCursor
+	}
+	return models.DeliveryCursor{CreatedAt: createdAt, ID: id}, nil
+}
@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/google/uuid"
+)
+
+type DeliveryAttemptResponse struct {
+	Number      int       `json:"number"`
+	AttemptedAt time.Time `json:"attempted_at"`
+	StatusCode  int       `json:"status_code,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	DurationMs  int64     `json:"duration_ms"`
+}
+
+type DeliveryResponse struct {
+	ID             uuid.UUID                 `json:"id"`
+	SubscriptionID uuid.UUID                 `json:"subscription_id"`
+	MessageID      uuid.UUID                 `json:"message_id"`
+	EventName      string                    `json:"event"`
+	Status         string                    `json:"status"`
+	Attempts       int                       `json:"attempts"`
+	NextAttemptAt  *time.Time                `json:"next_attempt_at,omitempty"`
+	CreatedAt      time.Time                 `json:"created_at"`
+	Payload        json.RawMessage           `json:"payload" swaggertype:"object"`
+	AttemptLog     []DeliveryAttemptResponse `json:"attempt_log"`
+}
+
+func NewDeliveryResponseFromDomainModel(delivery models.Delivery) DeliveryResponse {
+	attempts := make([]DeliveryAttemptResponse, 0, len(delivery.AttemptLog))
+	for _, attempt := range delivery.AttemptLog {
+		attempts = append(attempts, DeliveryAttemptResponse{
+			Number:      attempt.Number,
+			AttemptedAt: attempt.AttemptedAt,
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			DurationMs:  attempt.Duration.Milliseconds(),
+		})
+	}
+
+	var nextAttemptAt *time.Time
+	if delivery.Status == models.DeliveryPending {
+		nextAttemptAt = &delivery.NextAttemptAt
+	}
+
+	return DeliveryResponse{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		MessageID:      delivery.MessageID,
+		EventName:      delivery.EventName,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  nextAttemptAt,
+		CreatedAt:      delivery.CreatedAt,
+		Payload:        delivery.Body,
+		AttemptLog:     attempts,
+	}
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/google/uuid"
+)
+
+type SubscriptionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+	// Secret is only returned when the subscription is created.
+	Secret string `json:"secret,omitempty"`
+}
+
+func NewSubscriptionResponseFromDomainModel(subscription models.Subscription) SubscriptionResponse {
+	return SubscriptionResponse{
+		ID:         subscription.ID(),
+		URL:        subscription.URL(),
+		EventTypes: subscription.EventTypes(),
+		CreatedAt:  subscription.CreatedAt(),
+	}
+}
+
+func NewCreatedSubscriptionResponseFromDomainModel(subscription models.Subscription) SubscriptionResponse {
+	response := NewSubscriptionResponseFromDomainModel(subscription)
+	response.Secret = subscription.Secret()
+	return response
+}
@@ -0,0 +1,11 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+)
+
+type CreateSubscriptionUseCasePort interface {
+	Execute(ctx context.Context, subscription models.Subscription) error
+}
@@ -0,0 +1,9 @@
+package inbound
+
+import "context"
+
+type DeliverDueWebhooksUseCasePort interface {
+	// Execute attempts the deliveries that are due and returns how many were
+	// attempted.
+	Execute(ctx context.Context) (int, error)
+}
@@ -0,0 +1,11 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/events"
+)
+
+type EnqueueDeliveriesUseCasePort interface {
+	Execute(ctx context.Context, message events.Message) error
+}
@@ -0,0 +1,11 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+)
+
+type GetAllSubscriptionsUseCasePort interface {
+	Execute(ctx context.Context) ([]models.Subscription, error)
+}
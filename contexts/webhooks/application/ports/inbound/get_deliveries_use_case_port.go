@@ -0,0 +1,11 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+)
+
+type GetDeliveriesUseCasePort interface {
+	Execute(ctx context.Context, query models.DeliveryQuery) (models.DeliveryPage, error)
+}
@@ -0,0 +1,12 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/google/uuid"
+)
+
+type GetOneSubscriptionUseCasePort interface {
+	Execute(ctx context.Context, id uuid.UUID) (models.Subscription, error)
+}
@@ -0,0 +1,22 @@
+package outbound
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+)
+
+type DeliveryRepositoryPort interface {
+	// Enqueue ignores deliveries that already exist for the same subscription
+	// and message, so an event relayed twice is delivered once.
+	Enqueue(ctx context.Context, deliveries []models.Delivery) error
+	// ClaimDue returns pending deliveries whose next attempt is due and leaves
+	// them to the caller until now+lease: other workers skip them until then,
+	// or until their attempt is recorded. Call it inside a transaction.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.Delivery, error)
+	// RecordAttempt stores the outcome of an attempt and releases the claim on
+	// the delivery.
+	RecordAttempt(ctx context.Context, delivery models.Delivery, attempt models.DeliveryAttempt) error
+	GetBySubscriptionID(ctx context.Context, query models.DeliveryQuery) (models.DeliveryPage, error)
+}
@@ -0,0 +1,16 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/google/uuid"
+)
+
+type SubscriptionRepositoryPort interface {
+	Create(ctx context.Context, subscription models.Subscription) error
+	GetByID(ctx context.Context, id uuid.UUID) (models.Subscription, error)
+	GetAll(ctx context.Context) ([]models.Subscription, error)
+	GetMatching(ctx context.Context, eventName string) ([]models.Subscription, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,12 @@
+package outbound
+
+import "context"
+
+type WebhookSenderPort interface {
+	// CheckURL resolves the host of url and fails when it cannot be reached
+	// or resolves to an address webhooks may not be sent to.
+	CheckURL(ctx context.Context, url string) error
+	// Send POSTs body to url and returns the response status code, or an
+	// error when no response was received.
+	Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error)
+}
@@ -0,0 +1,29 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+)
+
+type CreateSubscriptionUseCase struct {
+	repo   outbound.SubscriptionRepositoryPort
+	sender outbound.WebhookSenderPort
+}
+
+func NewCreateSubscriptionUseCase(repo outbound.SubscriptionRepositoryPort, sender outbound.WebhookSenderPort) *CreateSubscriptionUseCase {
+	return &CreateSubscriptionUseCase{
+		repo:   repo,
+		sender: sender,
+	}
+}
+
+// Execute stores the subscription once its URL is known to resolve to public
+// addresses only.
+func (uc *CreateSubscriptionUseCase) Execute(ctx context.Context, subscription models.Subscription) error {
+	if err := uc.sender.CheckURL(ctx, subscription.URL()); err != nil {
+		return err
+	}
+	return uc.repo.Create(ctx, subscription)
+}
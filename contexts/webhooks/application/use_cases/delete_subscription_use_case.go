@@ -0,0 +1,24 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/ports/outbound"
+	"github.com/google/uuid"
+)
+
+type DeleteSubscriptionUseCase struct {
+	repo outbound.SubscriptionRepositoryPort
+}
+
+func NewDeleteSubscriptionUseCase(repo outbound.SubscriptionRepositoryPort) *DeleteSubscriptionUseCase {
+	return &DeleteSubscriptionUseCase{
+		repo: repo,
+	}
+}
+
+// Execute removes the subscription. Its delivery log is kept, and deliveries
+// still pending for it are abandoned by the delivery worker.
+func (uc *DeleteSubscriptionUseCase) Execute(ctx context.Context, id uuid.UUID) error {
+	return uc.repo.Delete(ctx, id)
+}
@@ -0,0 +1,96 @@
+package use_cases
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+)
+
+const (
+	HeaderDeliveryID = "X-Webhook-Id"
+	HeaderEvent      = "X-Webhook-Event"
+	HeaderSignature  = "X-Webhook-Signature"
+
+	subscriptionDeletedReason = "subscription deleted"
+)
+
+type DeliverDueWebhooksUseCase struct {
+	subscriptionRepo outbound.SubscriptionRepositoryPort
+	deliveryRepo     outbound.DeliveryRepositoryPort
+	sender           outbound.WebhookSenderPort
+	txManager        interfaces.TransactionManager
+	policy           models.RetryPolicy
+	batchSize        int
+	lease            time.Duration
+}
+
+// NewDeliverDueWebhooksUseCase returns a use case that claims up to batchSize
+// deliveries at a time for lease, which must outlast sending all of them.
+func NewDeliverDueWebhooksUseCase(subscriptionRepo outbound.SubscriptionRepositoryPort, deliveryRepo outbound.DeliveryRepositoryPort, sender outbound.WebhookSenderPort, txManager interfaces.TransactionManager, policy models.RetryPolicy, batchSize int, lease time.Duration) *DeliverDueWebhooksUseCase {
+	return &DeliverDueWebhooksUseCase{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		sender:           sender,
+		txManager:        txManager,
+		policy:           policy,
+		batchSize:        batchSize,
+		lease:            lease,
+	}
+}
+
+// Execute sends every due delivery once and stores the outcome. Deliveries
+// are claimed in a short transaction and sent outside of it, so no row stays
+// locked while a receiver answers; each outcome is then recorded in a
+// transaction of its own. Claimed deliveries are skipped by concurrent
+// workers until recorded, or until the lease runs out if this one stops.
+func (uc *DeliverDueWebhooksUseCase) Execute(ctx context.Context) (int, error) {
+	var deliveries []models.Delivery
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		deliveries, err = uc.deliveryRepo.ClaimDue(ctx, time.Now().UTC(), uc.lease, uc.batchSize)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	attempted := 0
+	for _, delivery := range deliveries {
+		attempt, err := uc.attempt(ctx, &delivery)
+		if err != nil {
+			return attempted, err
+		}
+		err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			return uc.deliveryRepo.RecordAttempt(ctx, delivery, attempt)
+		})
+		if err != nil {
+			return attempted, err
+		}
+		attempted++
+	}
+	return attempted, nil
+}
+
+func (uc *DeliverDueWebhooksUseCase) attempt(ctx context.Context, delivery *models.Delivery) (models.DeliveryAttempt, error) {
+	subscription, err := uc.subscriptionRepo.GetByID(request_context.WithTenant(ctx, delivery.TenantID), delivery.SubscriptionID)
+	if err != nil {
+		return models.DeliveryAttempt{}, err
+	}
+	if subscription == nil {
+		return delivery.Abandon(subscriptionDeletedReason, time.Now().UTC()), nil
+	}
+
+	startedAt := time.Now().UTC()
+	headers := map[string]string{
+		"Content-Type":   "application/json",
+		HeaderDeliveryID: delivery.ID.String(),
+		HeaderEvent:      delivery.EventName,
+		HeaderSignature:  models.Sign(subscription.Secret(), startedAt, delivery.Body),
+	}
+	statusCode, sendErr := uc.sender.Send(ctx, subscription.URL(), headers, delivery.Body)
+	return delivery.RecordAttempt(statusCode, sendErr, startedAt, time.Since(startedAt), uc.policy), nil
+}
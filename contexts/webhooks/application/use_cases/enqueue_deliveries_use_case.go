@@ -0,0 +1,46 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/events"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+)
+
+// EnqueueDeliveriesUseCase turns a relayed event into one pending delivery per
+// matching subscription of the event's tenant. It is subscribed to the event
+// dispatcher.
+type EnqueueDeliveriesUseCase struct {
+	subscriptionRepo outbound.SubscriptionRepositoryPort
+	deliveryRepo     outbound.DeliveryRepositoryPort
+}
+
+func NewEnqueueDeliveriesUseCase(subscriptionRepo outbound.SubscriptionRepositoryPort, deliveryRepo outbound.DeliveryRepositoryPort) *EnqueueDeliveriesUseCase {
+	return &EnqueueDeliveriesUseCase{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+	}
+}
+
+func (uc *EnqueueDeliveriesUseCase) Execute(ctx context.Context, message events.Message) error {
+	ctx = request_context.WithTenant(ctx, message.TenantID)
+	subscriptions, err := uc.subscriptionRepo.GetMatching(ctx, message.EventName)
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	deliveries := make([]models.Delivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		delivery, err := models.NewDelivery(subscription.ID(), message.ID, message.EventName, message.Payload, message.OccurredAt)
+		if err != nil {
+			return err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return uc.deliveryRepo.Enqueue(ctx, deliveries)
+}
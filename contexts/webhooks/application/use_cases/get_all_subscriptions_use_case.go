@@ -0,0 +1,22 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+)
+
+type GetAllSubscriptionsUseCase struct {
+	repo outbound.SubscriptionRepositoryPort
+}
+
+func NewGetAllSubscriptionsUseCase(repo outbound.SubscriptionRepositoryPort) *GetAllSubscriptionsUseCase {
+	return &GetAllSubscriptionsUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *GetAllSubscriptionsUseCase) Execute(ctx context.Context) ([]models.Subscription, error) {
+	return uc.repo.GetAll(ctx)
+}
@@ -0,0 +1,22 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+)
+
+type GetDeliveriesUseCase struct {
+	repo outbound.DeliveryRepositoryPort
+}
+
+func NewGetDeliveriesUseCase(repo outbound.DeliveryRepositoryPort) *GetDeliveriesUseCase {
+	return &GetDeliveriesUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *GetDeliveriesUseCase) Execute(ctx context.Context, query models.DeliveryQuery) (models.DeliveryPage, error) {
+	return uc.repo.GetBySubscriptionID(ctx, query)
+}
@@ -0,0 +1,23 @@
+package use_cases
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/ports/outbound"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/google/uuid"
+)
+
+type GetOneSubscriptionUseCase struct {
+	repo outbound.SubscriptionRepositoryPort
+}
+
+func NewGetOneSubscriptionUseCase(repo outbound.SubscriptionRepositoryPort) *GetOneSubscriptionUseCase {
+	return &GetOneSubscriptionUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *GetOneSubscriptionUseCase) Execute(ctx context.Context, id uuid.UUID) (models.Subscription, error) {
+	return uc.repo.GetByID(ctx, id)
+}
@@ -0,0 +1,52 @@
+package use_cases_mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDeliveryRepository struct {
+	mock.Mock
+}
+
+func NewMockDeliveryRepository() *MockDeliveryRepository {
+	return &MockDeliveryRepository{}
+}
+
+func (m *MockDeliveryRepository) Enqueue(ctx context.Context, deliveries []models.Delivery) error {
+	args := m.Called(ctx, deliveries)
+	return args.Error(0)
+}
+
+func (m *MockDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.Delivery, error) {
+	args := m.Called(ctx, now, lease, limit)
+	return args.Get(0).([]models.Delivery), args.Error(1)
+}
+
+func (m *MockDeliveryRepository) RecordAttempt(ctx context.Context, delivery models.Delivery, attempt models.DeliveryAttempt) error {
+	args := m.Called(ctx, delivery, attempt)
+	return args.Error(0)
+}
+
+func (m *MockDeliveryRepository) GetBySubscriptionID(ctx context.Context, query models.DeliveryQuery) (models.DeliveryPage, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(models.DeliveryPage), args.Error(1)
+}
+
+func (m *MockDeliveryRepository) SetupClaimDue(deliveries ...models.Delivery) *mock.Call {
+	return m.On("ClaimDue", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Duration"), mock.AnythingOfType("int")).Return(deliveries, nil)
+}
+
+// SetupEnqueueMatching expects a batch that satisfies matcher.
+func (m *MockDeliveryRepository) SetupEnqueueMatching(matcher func(deliveries []models.Delivery) bool) *mock.Call {
+	return m.On("Enqueue", mock.Anything, mock.MatchedBy(matcher)).Return(nil)
+}
+
+// SetupRecordAttemptMatching expects a delivery and attempt that satisfy the
+// matchers.
+func (m *MockDeliveryRepository) SetupRecordAttemptMatching(deliveryMatcher func(delivery models.Delivery) bool, attemptMatcher func(attempt models.DeliveryAttempt) bool) *mock.Call {
+	return m.On("RecordAttempt", mock.Anything, mock.MatchedBy(deliveryMatcher), mock.MatchedBy(attemptMatcher)).Return(nil)
+}
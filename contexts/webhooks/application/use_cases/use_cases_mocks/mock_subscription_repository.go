@@ -0,0 +1,57 @@
+package use_cases_mocks
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSubscriptionRepository struct {
+	mock.Mock
+}
+
+func NewMockSubscriptionRepository() *MockSubscriptionRepository {
+	return &MockSubscriptionRepository{}
+}
+
+func (m *MockSubscriptionRepository) Create(ctx context.Context, subscription models.Subscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (models.Subscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(models.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) GetAll(ctx context.Context) ([]models.Subscription, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) GetMatching(ctx context.Context, eventName string) ([]models.Subscription, error) {
+	args := m.Called(ctx, eventName)
+	return args.Get(0).([]models.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) SetupGetByIDSuccess(id uuid.UUID, subscription models.Subscription) *mock.Call {
+	return m.On("GetByID", mock.Anything, id).Return(subscription, nil)
+}
+
+func (m *MockSubscriptionRepository) SetupGetByIDNotFound(id uuid.UUID) *mock.Call {
+	return m.On("GetByID", mock.Anything, id).Return(nil, nil)
+}
+
+func (m *MockSubscriptionRepository) SetupGetMatching(eventName string, subscriptions ...models.Subscription) *mock.Call {
+	return m.On("GetMatching", mock.Anything, eventName).Return(subscriptions, nil)
+}
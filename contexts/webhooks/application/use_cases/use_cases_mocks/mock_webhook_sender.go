@@ -0,0 +1,33 @@
+package use_cases_mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookSender struct {
+	mock.Mock
+}
+
+func NewMockWebhookSender() *MockWebhookSender {
+	return &MockWebhookSender{}
+}
+
+func (m *MockWebhookSender) CheckURL(ctx context.Context, url string) error {
+	args := m.Called(ctx, url)
+	return args.Error(0)
+}
+
+func (m *MockWebhookSender) Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	args := m.Called(ctx, url, headers, body)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockWebhookSender) SetupCheckURL(url string, err error) *mock.Call {
+	return m.On("CheckURL", mock.Anything, url).Return(err)
+}
+
+func (m *MockWebhookSender) SetupSend(url string, statusCode int, err error) *mock.Call {
+	return m.On("Send", mock.Anything, url, mock.Anything, mock.Anything).Return(statusCode, err)
+}
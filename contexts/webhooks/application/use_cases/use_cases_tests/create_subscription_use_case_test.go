@@ -0,0 +1,53 @@
+package use_cases_tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models/models_mothers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateSubscriptionUseCase_Execute(t *testing.T) {
+	t.Run("should store a subscription whose URL resolves to public addresses", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockSubscriptionRepo := use_cases_mocks.NewMockSubscriptionRepository()
+		mockSender := use_cases_mocks.NewMockWebhookSender()
+		useCase := use_cases.NewCreateSubscriptionUseCase(mockSubscriptionRepo, mockSender)
+
+		subscription := models_mothers.NewSubscriptionMother().MustBuild()
+		mockSender.SetupCheckURL(subscription.URL(), nil)
+		mockSubscriptionRepo.On("Create", mock.Anything, subscription).Return(nil)
+
+		// Act
+		err := useCase.Execute(ctx, subscription)
+
+		// Assert
+		assert.NoError(t, err)
+		mockSender.AssertExpectations(t)
+		mockSubscriptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not store a subscription whose URL resolves to a private address", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockSubscriptionRepo := use_cases_mocks.NewMockSubscriptionRepository()
+		mockSender := use_cases_mocks.NewMockWebhookSender()
+		useCase := use_cases.NewCreateSubscriptionUseCase(mockSubscriptionRepo, mockSender)
+
+		subscription := models_mothers.NewSubscriptionMother().MustBuild()
+		mockSender.SetupCheckURL(subscription.URL(), models.ErrSubscriptionURLForbidden)
+
+		// Act
+		err := useCase.Execute(ctx, subscription)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSubscriptionURLForbidden)
+		mockSubscriptionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
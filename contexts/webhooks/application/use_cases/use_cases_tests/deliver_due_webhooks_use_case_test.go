@@ -0,0 +1,160 @@
+package use_cases_tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces/interfaces_mocks"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models/models_mothers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeliverDueWebhooksUseCase_Execute(t *testing.T) {
+	policy := models.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour}
+
+	newDelivery := func(t *testing.T, subscriptionID uuid.UUID) models.Delivery {
+		delivery, err := models.NewDelivery(subscriptionID, uuid.New(), "product.created", []byte(`{}`), time.Now().UTC())
+		assert.NoError(t, err)
+		return delivery
+	}
+
+	t.Run("should send a signed delivery and record its success", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockSubscriptionRepo := use_cases_mocks.NewMockSubscriptionRepository()
+		mockDeliveryRepo := use_cases_mocks.NewMockDeliveryRepository()
+		mockSender := use_cases_mocks.NewMockWebhookSender()
+		useCase := use_cases.NewDeliverDueWebhooksUseCase(mockSubscriptionRepo, mockDeliveryRepo, mockSender, interfaces_mocks.NewMockTransactionManager(), policy, 10, time.Minute)
+
+		subscription := models_mothers.NewSubscriptionMother().MustBuild()
+		delivery := newDelivery(t, subscription.ID())
+		mockDeliveryRepo.SetupClaimDue(delivery)
+		mockSubscriptionRepo.SetupGetByIDSuccess(subscription.ID(), subscription)
+		mockSender.On("Send", mock.Anything, subscription.URL(), mock.MatchedBy(func(headers map[string]string) bool {
+			return headers[use_cases.HeaderDeliveryID] == delivery.ID.String() &&
+				headers[use_cases.HeaderEvent] == "product.created" &&
+				strings.HasPrefix(headers[use_cases.HeaderSignature], "t=")
+		}), delivery.Body).Return(http.StatusOK, nil)
+		mockDeliveryRepo.SetupRecordAttemptMatching(
+			func(d models.Delivery) bool { return d.Status == models.DeliverySucceeded },
+			func(a models.DeliveryAttempt) bool { return a.Number == 1 && a.StatusCode == http.StatusOK },
+		)
+
+		// Act
+		attempted, err := useCase.Execute(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, attempted)
+		mockSender.AssertExpectations(t)
+		mockDeliveryRepo.AssertExpectations(t)
+	})
+
+	t.Run("should schedule a retry when the receiver fails", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockSubscriptionRepo := use_cases_mocks.NewMockSubscriptionRepository()
+		mockDeliveryRepo := use_cases_mocks.NewMockDeliveryRepository()
+		mockSender := use_cases_mocks.NewMockWebhookSender()
+		useCase := use_cases.NewDeliverDueWebhooksUseCase(mockSubscriptionRepo, mockDeliveryRepo, mockSender, interfaces_mocks.NewMockTransactionManager(), policy, 10, time.Minute)
+
+		subscription := models_mothers.NewSubscriptionMother().MustBuild()
+		delivery := newDelivery(t, subscription.ID())
+		mockDeliveryRepo.SetupClaimDue(delivery)
+		mockSubscriptionRepo.SetupGetByIDSuccess(subscription.ID(), subscription)
+		mockSender.SetupSend(subscription.URL(), 0, errors.New("connection refused"))
+		mockDeliveryRepo.SetupRecordAttemptMatching(
+			func(d models.Delivery) bool {
+				return d.Status == models.DeliveryPending && d.NextAttemptAt.After(time.Now().Add(50*time.Second))
+			},
+			func(a models.DeliveryAttempt) bool { return a.Error == "connection refused" },
+		)
+
+		// Act
+		attempted, err := useCase.Execute(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, attempted)
+		mockDeliveryRepo.AssertExpectations(t)
+	})
+
+	t.Run("should abandon deliveries of deleted subscriptions", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockSubscriptionRepo := use_cases_mocks.NewMockSubscriptionRepository()
+		mockDeliveryRepo := use_cases_mocks.NewMockDeliveryRepository()
+		mockSender := use_cases_mocks.NewMockWebhookSender()
+		useCase := use_cases.NewDeliverDueWebhooksUseCase(mockSubscriptionRepo, mockDeliveryRepo, mockSender, interfaces_mocks.NewMockTransactionManager(), policy, 10, time.Minute)
+
+		subscriptionID := uuid.New()
+		mockDeliveryRepo.SetupClaimDue(newDelivery(t, subscriptionID))
+		mockSubscriptionRepo.SetupGetByIDNotFound(subscriptionID)
+		mockDeliveryRepo.SetupRecordAttemptMatching(
+			func(d models.Delivery) bool { return d.Status == models.DeliveryFailed },
+			func(a models.DeliveryAttempt) bool { return a.Error == "subscription deleted" },
+		)
+
+		// Act
+		attempted, err := useCase.Execute(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, attempted)
+		mockSender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockDeliveryRepo.AssertExpectations(t)
+	})
+	t.Run("should send deliveries outside of any transaction", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockSubscriptionRepo := use_cases_mocks.NewMockSubscriptionRepository()
+		mockDeliveryRepo := use_cases_mocks.NewMockDeliveryRepository()
+		mockSender := use_cases_mocks.NewMockWebhookSender()
+		txManager := &trackingTransactionManager{}
+		useCase := use_cases.NewDeliverDueWebhooksUseCase(mockSubscriptionRepo, mockDeliveryRepo, mockSender, txManager, policy, 10, time.Minute)
+
+		subscription := models_mothers.NewSubscriptionMother().MustBuild()
+		mockDeliveryRepo.SetupClaimDue(newDelivery(t, subscription.ID()), newDelivery(t, subscription.ID()))
+		mockSubscriptionRepo.SetupGetByIDSuccess(subscription.ID(), subscription)
+		var sentInTransaction []bool
+		mockSender.SetupSend(subscription.URL(), http.StatusOK, nil).Run(func(mock.Arguments) {
+			sentInTransaction = append(sentInTransaction, txManager.active)
+		})
+		mockDeliveryRepo.SetupRecordAttemptMatching(
+			func(models.Delivery) bool { return true },
+			func(models.DeliveryAttempt) bool { return true },
+		)
+
+		// Act
+		attempted, err := useCase.Execute(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2, attempted)
+		assert.Equal(t, []bool{false, false}, sentInTransaction)
+		assert.Equal(t, 3, txManager.transactions)
+	})
+}
+
+// trackingTransactionManager runs fn directly and tells whether it is inside
+// a transaction.
+type trackingTransactionManager struct {
+	active       bool
+	transactions int
+}
+
+func (tm *trackingTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tm.active = true
+	tm.transactions++
+	defer func() { tm.active = false }()
+	return fn(ctx)
+}
@@ -0,0 +1,109 @@
+package use_cases_tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/events"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/use_cases/use_cases_mocks"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models/models_mothers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEnqueueDeliveriesUseCase_Execute(t *testing.T) {
+	message := events.Message{
+		ID:          uuid.New(),
+		EventName:   "product.created",
+		AggregateID: uuid.New(),
+		Payload:     []byte(`{"name":"Laptop"}`),
+		OccurredAt:  time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("should enqueue one delivery per matching subscription", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockSubscriptionRepo := use_cases_mocks.NewMockSubscriptionRepository()
+		mockDeliveryRepo := use_cases_mocks.NewMockDeliveryRepository()
+		useCase := use_cases.NewEnqueueDeliveriesUseCase(mockSubscriptionRepo, mockDeliveryRepo)
+
+		first := models_mothers.NewSubscriptionMother().MustBuild()
+		second := models_mothers.NewSubscriptionMother().WithEventTypes(models.AllEvents).MustBuild()
+		mockSubscriptionRepo.SetupGetMatching(message.EventName, first, second)
+		mockDeliveryRepo.SetupEnqueueMatching(func(deliveries []models.Delivery) bool {
+			if len(deliveries) != 2 {
+				return false
+			}
+			var body struct {
+				ID    uuid.UUID       `json:"id"`
+				Event string          `json:"event"`
+				Data  json.RawMessage `json:"data"`
+			}
+			if err := json.Unmarshal(deliveries[0].Body, &body); err != nil {
+				return false
+			}
+			return deliveries[0].SubscriptionID == first.ID() &&
+				deliveries[1].SubscriptionID == second.ID() &&
+				deliveries[0].MessageID == message.ID &&
+				body.ID == message.ID &&
+				body.Event == message.EventName &&
+				string(body.Data) == string(message.Payload)
+		})
+
+		// Act
+		err := useCase.Execute(ctx, message)
+
+		// Assert
+		assert.NoError(t, err)
+		mockSubscriptionRepo.AssertExpectations(t)
+		mockDeliveryRepo.AssertExpectations(t)
+	})
+
+	t.Run("should only look at the subscriptions of the event's tenant", func(t *testing.T) {
+		// Arrange
+		ctx := request_context.WithTenant(context.Background(), "globex")
+		mockSubscriptionRepo := use_cases_mocks.NewMockSubscriptionRepository()
+		mockDeliveryRepo := use_cases_mocks.NewMockDeliveryRepository()
+		useCase := use_cases.NewEnqueueDeliveriesUseCase(mockSubscriptionRepo, mockDeliveryRepo)
+
+		tenantMessage := message
+		tenantMessage.TenantID = "acme"
+		subscription := models_mothers.NewSubscriptionMother().MustBuild()
+		inTenant := mock.MatchedBy(func(ctx context.Context) bool {
+			return request_context.TenantFrom(ctx) == "acme"
+		})
+		mockSubscriptionRepo.On("GetMatching", inTenant, message.EventName).Return([]models.Subscription{subscription}, nil)
+		mockDeliveryRepo.On("Enqueue", inTenant, mock.Anything).Return(nil)
+
+		// Act
+		err := useCase.Execute(ctx, tenantMessage)
+
+		// Assert
+		assert.NoError(t, err)
+		mockSubscriptionRepo.AssertExpectations(t)
+		mockDeliveryRepo.AssertExpectations(t)
+	})
+
+	t.Run("should enqueue nothing when no subscription matches", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		mockSubscriptionRepo := use_cases_mocks.NewMockSubscriptionRepository()
+		mockDeliveryRepo := use_cases_mocks.NewMockDeliveryRepository()
+		useCase := use_cases.NewEnqueueDeliveriesUseCase(mockSubscriptionRepo, mockDeliveryRepo)
+
+		mockSubscriptionRepo.SetupGetMatching(message.EventName)
+
+		// Act
+		err := useCase.Execute(ctx, message)
+
+		// Assert
+		assert.NoError(t, err)
+		mockDeliveryRepo.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything)
+	})
+}
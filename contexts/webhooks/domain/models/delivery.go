@@ -0,0 +1,161 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// Delivery is one event to be sent to one subscription. Body is exactly what
+// is POSTed, so every attempt sends and signs the same bytes. TenantID is the
+// tenant of the subscription, set once the delivery is stored.
+type Delivery struct {
+	ID             uuid.UUID
+	TenantID       string
+	SubscriptionID uuid.UUID
+	MessageID      uuid.UUID
+	EventName      string
+	Body           []byte
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	AttemptLog     []DeliveryAttempt
+}
+
+// DeliveryAttempt is the outcome of a single POST. StatusCode is 0 when no
+// response was received.
+type DeliveryAttempt struct {
+	ID          uuid.UUID
+	DeliveryID  uuid.UUID
+	Number      int
+	AttemptedAt time.Time
+	StatusCode  int
+	Error       string
+	Duration    time.Duration
+}
+
+type deliveryBody struct {
+	ID         uuid.UUID       `json:"id"`
+	Event      string          `json:"event"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// NewDelivery wraps an event payload in the envelope receivers get. The
+// message ID lets them discard duplicates.
+func NewDelivery(subscriptionID, messageID uuid.UUID, eventName string, payload []byte, occurredAt time.Time) (Delivery, error) {
+	body, err := json.Marshal(deliveryBody{
+		ID:         messageID,
+		Event:      eventName,
+		OccurredAt: occurredAt,
+		Data:       payload,
+	})
+	if err != nil {
+		return Delivery{}, err
+	}
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return Delivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscriptionID,
+		MessageID:      messageID,
+		EventName:      eventName,
+		Body:           body,
+		Status:         DeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}, nil
+}
+
+// RecordAttempt updates the delivery with the outcome of an attempt and
+// schedules the next one, if the policy allows it. 2xx responses succeed.
+func (d *Delivery) RecordAttempt(statusCode int, sendErr error, attemptedAt time.Time, duration time.Duration, policy RetryPolicy) DeliveryAttempt {
+	d.Attempts++
+	attempt := DeliveryAttempt{
+		ID:          uuid.New(),
+		DeliveryID:  d.ID,
+		Number:      d.Attempts,
+		AttemptedAt: attemptedAt,
+		StatusCode:  statusCode,
+		Duration:    duration,
+	}
+	if sendErr != nil {
+		attempt.Error = sendErr.Error()
+	}
+
+	switch {
+	case sendErr == nil && statusCode >= 200 && statusCode < 300:
+		d.Status = DeliverySucceeded
+	case d.Attempts >= policy.MaxAttempts:
+		d.Status = DeliveryFailed
+	default:
+		d.NextAttemptAt = attemptedAt.Add(policy.Backoff(d.Attempts))
+	}
+	return attempt
+}
+
+// Abandon fails the delivery without another attempt, e.g. because its
+// subscription no longer exists.
+func (d *Delivery) Abandon(reason string, at time.Time) DeliveryAttempt {
+	d.Attempts++
+	d.Status = DeliveryFailed
+	return DeliveryAttempt{
+		ID:          uuid.New(),
+		DeliveryID:  d.ID,
+		Number:      d.Attempts,
+		AttemptedAt: at,
+		Error:       reason,
+	}
+}
+
+// RetryPolicy bounds how often and how soon a failed delivery is retried.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 8,
+		BaseBackoff: 10 * time.Second,
+		MaxBackoff:  time.Hour,
+	}
+}
+
+// Backoff is the delay after the given number of failed attempts: it starts
+// at BaseBackoff and doubles every time, up to MaxBackoff.
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	delay := p.BaseBackoff
+	for i := 1; i < attempts && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxBackoff)
+}
+
+// DeliveryCursor is the position of the last delivery of a page. Deliveries
+// are listed newest first.
+type DeliveryCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+type DeliveryQuery struct {
+	SubscriptionID uuid.UUID
+	Cursor         *DeliveryCursor
+	Limit          *int
+}
+
+type DeliveryPage struct {
+	Items []Delivery
+	Next  *DeliveryCursor
+}
@@ -0,0 +1,58 @@
+package models_mothers
+
+import (
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/google/uuid"
+)
+
+type SubscriptionMother struct {
+	Id         uuid.UUID
+	URL        string
+	EventTypes []string
+	Secret     string
+	CreatedAt  time.Time
+}
+
+func NewSubscriptionMother() *SubscriptionMother {
+	return &SubscriptionMother{
+		Id:         uuid.New(),
+		URL:        "https://example.com/webhooks",
+		EventTypes: []string{"product.created"},
+		Secret:     "default-secret-0123456789",
+		CreatedAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (sm *SubscriptionMother) WithID(id uuid.UUID) *SubscriptionMother {
+	sm.Id = id
+	return sm
+}
+
+func (sm *SubscriptionMother) WithURL(url string) *SubscriptionMother {
+	sm.URL = url
+	return sm
+}
+
+func (sm *SubscriptionMother) WithEventTypes(eventTypes ...string) *SubscriptionMother {
+	sm.EventTypes = eventTypes
+	return sm
+}
+
+func (sm *SubscriptionMother) WithSecret(secret string) *SubscriptionMother {
+	sm.Secret = secret
+	return sm
+}
+
+func (sm *SubscriptionMother) Build() (models.Subscription, error) {
+	return models.ReconstituteSubscription(sm.Id, sm.URL, sm.EventTypes, sm.Secret, sm.CreatedAt)
+}
+
+func (sm *SubscriptionMother) MustBuild() models.Subscription {
+	subscription, err := sm.Build()
+	if err != nil {
+		panic("SubscriptionMother.MustBuild failed: " + err.Error())
+	}
+	return subscription
+}
@@ -0,0 +1,112 @@
+package models_tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDelivery(t *testing.T) models.Delivery {
+	delivery, err := models.NewDelivery(uuid.New(), uuid.New(), "product.created", []byte(`{"sku":"SKU"}`), time.Now())
+	require.NoError(t, err)
+	return delivery
+}
+
+func TestNewDelivery(t *testing.T) {
+	t.Run("should wrap the payload in an envelope", func(t *testing.T) {
+		// Arrange
+		messageID := uuid.New()
+
+		// Act
+		delivery, err := models.NewDelivery(uuid.New(), messageID, "product.created", []byte(`{"sku":"SKU"}`), time.Now())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, models.DeliveryPending, delivery.Status)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(delivery.Body, &body))
+		assert.Equal(t, messageID.String(), body["id"])
+		assert.Equal(t, "product.created", body["event"])
+		assert.Equal(t, map[string]interface{}{"sku": "SKU"}, body["data"])
+	})
+}
+
+func TestDeliveryRecordAttempt(t *testing.T) {
+	policy := models.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Second, MaxBackoff: time.Minute}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should succeed on a 2xx response", func(t *testing.T) {
+		// Arrange
+		delivery := newTestDelivery(t)
+
+		// Act
+		attempt := delivery.RecordAttempt(204, nil, now, time.Millisecond, policy)
+
+		// Assert
+		assert.Equal(t, models.DeliverySucceeded, delivery.Status)
+		assert.Equal(t, 1, attempt.Number)
+		assert.Equal(t, 204, attempt.StatusCode)
+	})
+
+	t.Run("should schedule a retry with exponential backoff", func(t *testing.T) {
+		// Arrange
+		delivery := newTestDelivery(t)
+
+		// Act
+		delivery.RecordAttempt(500, nil, now, time.Millisecond, policy)
+		firstRetry := delivery.NextAttemptAt
+		attempt := delivery.RecordAttempt(0, errors.New("connection refused"), now, time.Millisecond, policy)
+
+		// Assert
+		assert.Equal(t, models.DeliveryPending, delivery.Status)
+		assert.Equal(t, now.Add(time.Second), firstRetry)
+		assert.Equal(t, now.Add(2*time.Second), delivery.NextAttemptAt)
+		assert.Equal(t, "connection refused", attempt.Error)
+	})
+
+	t.Run("should fail after the last allowed attempt", func(t *testing.T) {
+		// Arrange
+		delivery := newTestDelivery(t)
+
+		// Act
+		for i := 0; i < policy.MaxAttempts; i++ {
+			delivery.RecordAttempt(500, nil, now, time.Millisecond, policy)
+		}
+
+		// Assert
+		assert.Equal(t, models.DeliveryFailed, delivery.Status)
+		assert.Equal(t, policy.MaxAttempts, delivery.Attempts)
+	})
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	t.Run("should double up to the maximum", func(t *testing.T) {
+		// Arrange
+		policy := models.RetryPolicy{BaseBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+		// Act & Assert
+		assert.Equal(t, time.Second, policy.Backoff(1))
+		assert.Equal(t, 2*time.Second, policy.Backoff(2))
+		assert.Equal(t, 4*time.Second, policy.Backoff(3))
+		assert.Equal(t, 5*time.Second, policy.Backoff(4))
+	})
+}
+
+func TestSign(t *testing.T) {
+	t.Run("should sign timestamp and body with the secret", func(t *testing.T) {
+		// Arrange
+		timestamp := time.Unix(1700000000, 0)
+
+		// Act
+		signature := models.Sign("secret", timestamp, []byte("body"))
+
+		// Assert
+		assert.Equal(t, "t=1700000000,v1=42ac6f0448c1d9c3e1e82b9726248f58fef84afffcbad5188246e96070e0ea46", signature)
+	})
+}
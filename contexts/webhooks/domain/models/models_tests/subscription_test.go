@@ -0,0 +1,150 @@
+package models_tests
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models/models_mothers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSubscription(t *testing.T) {
+	t.Run("should create subscription with valid data", func(t *testing.T) {
+		// Arrange
+		id := uuid.New()
+
+		// Act
+		subscription, err := models.NewSubscription(id, "https://example.com/hook", []string{"product.created"}, "0123456789abcdef")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, id, subscription.ID())
+		assert.Equal(t, "https://example.com/hook", subscription.URL())
+		assert.False(t, subscription.CreatedAt().IsZero())
+	})
+
+	t.Run("should reject invalid data", func(t *testing.T) {
+		cases := map[string]struct {
+			mother *models_mothers.SubscriptionMother
+			err    error
+		}{
+			"nil id":           {models_mothers.NewSubscriptionMother().WithID(uuid.Nil), models.ErrSubscriptionIdNil},
+			"relative url":     {models_mothers.NewSubscriptionMother().WithURL("/hook"), models.ErrSubscriptionURLInvalid},
+			"ftp url":          {models_mothers.NewSubscriptionMother().WithURL("ftp://example.com"), models.ErrSubscriptionURLInvalid},
+			"no event types":   {models_mothers.NewSubscriptionMother().WithEventTypes(), models.ErrSubscriptionEventTypesEmpty},
+			"empty event type": {models_mothers.NewSubscriptionMother().WithEventTypes(""), models.ErrSubscriptionEventTypesEmpty},
+			"short secret":     {models_mothers.NewSubscriptionMother().WithSecret("short"), models.ErrSubscriptionSecretTooShort},
+		}
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				// Act
+				subscription, err := tc.mother.Build()
+
+				// Assert
+				assert.Nil(t, subscription)
+				assert.ErrorIs(t, err, tc.err)
+			})
+		}
+	})
+}
+
+func TestNewSubscriptionURLHost(t *testing.T) {
+	t.Run("should refuse hosts that are not public", func(t *testing.T) {
+		urls := map[string]string{
+			"loopback":       "http://127.0.0.1:8080/hook",
+			"private":        "https://10.0.0.5/hook",
+			"link-local":     "http://169.254.169.254/latest/meta-data",
+			"unspecified":    "http://0.0.0.0/hook",
+			"ipv6 loopback":  "http://[::1]/hook",
+			"mapped private": "http://[::ffff:192.168.1.1]/hook",
+			"localhost":      "http://localhost:3000/hook",
+			"localhost name": "http://api.localhost/hook",
+		}
+		for name, rawURL := range urls {
+			t.Run(name, func(t *testing.T) {
+				// Act
+				subscription, err := models.NewSubscription(uuid.New(), rawURL, []string{"product.created"}, "0123456789abcdef")
+
+				// Assert
+				assert.Nil(t, subscription)
+				assert.ErrorIs(t, err, models.ErrSubscriptionURLForbidden)
+			})
+		}
+	})
+
+	t.Run("should accept public addresses and names", func(t *testing.T) {
+		for _, rawURL := range []string{"https://93.184.216.34/hook", "https://hooks.example.com/hook"} {
+			// Act
+			_, err := models.NewSubscription(uuid.New(), rawURL, []string{"product.created"}, "0123456789abcdef")
+
+			// Assert
+			assert.NoError(t, err, rawURL)
+		}
+	})
+}
+
+func TestCheckResolvedURL(t *testing.T) {
+	t.Run("should refuse a name with any address that is not public", func(t *testing.T) {
+		// Arrange
+		addrs := []netip.Addr{netip.MustParseAddr("93.184.216.34"), netip.MustParseAddr("192.168.0.10")}
+
+		// Act
+		err := models.CheckResolvedURL("https://hooks.example.com", addrs)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSubscriptionURLForbidden)
+	})
+
+	t.Run("should refuse a name without addresses", func(t *testing.T) {
+		// Act
+		err := models.CheckResolvedURL("https://hooks.example.com", nil)
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSubscriptionURLInvalid)
+	})
+
+	t.Run("should accept a name with public addresses only", func(t *testing.T) {
+		// Act
+		err := models.CheckResolvedURL("https://hooks.example.com", []netip.Addr{netip.MustParseAddr("2606:2800:220:1::1")})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+}
+
+func TestSubscriptionMatches(t *testing.T) {
+	t.Run("should match subscribed event types only", func(t *testing.T) {
+		// Arrange
+		subscription := models_mothers.NewSubscriptionMother().WithEventTypes("product.created").MustBuild()
+
+		// Act & Assert
+		assert.True(t, subscription.Matches("product.created"))
+		assert.False(t, subscription.Matches("product.deleted"))
+	})
+
+	t.Run("should match every event with the wildcard", func(t *testing.T) {
+		// Arrange
+		subscription := models_mothers.NewSubscriptionMother().WithEventTypes(models.AllEvents).MustBuild()
+
+		// Act & Assert
+		assert.True(t, subscription.Matches("product.deleted"))
+	})
+}
+
+func TestGenerateSecret(t *testing.T) {
+	t.Run("should generate distinct secrets long enough to be accepted", func(t *testing.T) {
+		// Act
+		first, err := models.GenerateSecret()
+		require.NoError(t, err)
+		second, err := models.GenerateSecret()
+		require.NoError(t, err)
+
+		// Assert
+		assert.NotEqual(t, first, second)
+		_, err = models_mothers.NewSubscriptionMother().WithSecret(first).Build()
+		assert.NoError(t, err)
+	})
+}
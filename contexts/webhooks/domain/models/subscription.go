@@ -0,0 +1,184 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/netip"
+	"net/url"
+	"slices"
+	"time"
+
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/google/uuid"
+)
+
+// AllEvents subscribes to every event type.
+const AllEvents = "*"
+
+const minSecretLength = 16
+
+var (
+	ErrSubscriptionIdNil = shared_models.DomainError{
+		Code:    "WEBHOOK_SUBSCRIPTION_ID_NIL",
+		Message: "Webhook subscription ID cannot be nil",
+	}
+
+	ErrSubscriptionURLInvalid = shared_models.DomainError{
+		Code:    "WEBHOOK_URL_INVALID",
+		Message: "Webhook URL must be an absolute http or https URL",
+	}
+
+	ErrSubscriptionURLForbidden = shared_models.DomainError{
+		Code:    "WEBHOOK_URL_FORBIDDEN",
+		Message: "Webhook URL must not point to a loopback, private or link-local address",
+	}
+
+	ErrSubscriptionEventTypesEmpty = shared_models.DomainError{
+		Code:    "WEBHOOK_EVENT_TYPES_EMPTY",
+		Message: "Webhook subscription needs at least one event type",
+	}
+
+	ErrSubscriptionSecretTooShort = shared_models.DomainError{
+		Code:    "WEBHOOK_SECRET_TOO_SHORT",
+		Message: "Webhook secret must be at least 16 characters long",
+	}
+)
+
+type Subscription interface {
+	ID() uuid.UUID
+	URL() string
+	EventTypes() []string
+	Secret() string
+	CreatedAt() time.Time
+	Matches(eventName string) bool
+}
+
+type subscription struct {
+	id         uuid.UUID
+	url        string
+	eventTypes []string
+	secret     string
+	createdAt  time.Time
+}
+
+// NewSubscription also refuses URLs whose host is a loopback, private or
+// link-local address. Stored subscriptions are not checked again when
+// reconstituted, as the sender refuses to connect to such addresses anyway.
+func NewSubscription(id uuid.UUID, rawURL string, eventTypes []string, secret string) (Subscription, error) {
+	subscription, err := ReconstituteSubscription(id, rawURL, eventTypes, secret, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if parsed, _ := url.Parse(rawURL); !isPublicHost(parsed.Hostname()) {
+		return nil, urlViolation(ErrSubscriptionURLForbidden, "public_host", rawURL)
+	}
+	return subscription, nil
+}
+
+// CheckResolvedURL checks the addresses the host of rawURL resolved to: there
+// must be some, and all of them public.
+func CheckResolvedURL(rawURL string, addrs []netip.Addr) error {
+	if len(addrs) == 0 {
+		return urlViolation(ErrSubscriptionURLInvalid, "resolvable", rawURL)
+	}
+	for _, addr := range addrs {
+		if !IsPublicAddress(addr) {
+			return urlViolation(ErrSubscriptionURLForbidden, "public_host", rawURL)
+		}
+	}
+	return nil
+}
+
+func urlViolation(err shared_models.DomainError, rule, rawURL string) error {
+	return err.WithFieldViolations(shared_models.FieldViolation{
+		Field:   "url",
+		Rule:    rule,
+		Value:   rawURL,
+		Message: err.Message,
+	})
+}
+
+func ReconstituteSubscription(id uuid.UUID, rawURL string, eventTypes []string, secret string, createdAt time.Time) (Subscription, error) {
+	if id == uuid.Nil {
+		return nil, ErrSubscriptionIdNil
+	}
+	if err := validateSubscription(rawURL, eventTypes, secret); err != nil {
+		return nil, err
+	}
+
+	return &subscription{
+		id:         id,
+		url:        rawURL,
+		eventTypes: slices.Clone(eventTypes),
+		secret:     secret,
+		createdAt:  createdAt,
+	}, nil
+}
+
+// validateSubscription checks every field and returns the error of the first
+// invalid one, with all the invalid fields in its details.
+func validateSubscription(rawURL string, eventTypes []string, secret string) error {
+	var first *shared_models.DomainError
+	var violations []shared_models.FieldViolation
+	reject := func(err shared_models.DomainError, field, rule string, value interface{}) {
+		if first == nil {
+			first = &err
+		}
+		violations = append(violations, shared_models.FieldViolation{
+			Field:   field,
+			Rule:    rule,
+			Value:   value,
+			Message: err.Message,
+		})
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		reject(ErrSubscriptionURLInvalid, "url", "url", rawURL)
+	}
+	if len(eventTypes) == 0 || slices.Contains(eventTypes, "") {
+		reject(ErrSubscriptionEventTypesEmpty, "event_types", "required", eventTypes)
+	}
+	if len(secret) < minSecretLength {
+		// The secret itself is never echoed back.
+		reject(ErrSubscriptionSecretTooShort, "secret", "min", nil)
+	}
+	if first == nil {
+		return nil
+	}
+	return first.WithFieldViolations(violations...)
+}
+
+// GenerateSecret returns a random secret for subscriptions created without
+// one.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func (s *subscription) ID() uuid.UUID {
+	return s.id
+}
+
+func (s *subscription) URL() string {
+	return s.url
+}
+
+func (s *subscription) EventTypes() []string {
+	return slices.Clone(s.eventTypes)
+}
+
+func (s *subscription) Secret() string {
+	return s.secret
+}
+
+func (s *subscription) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *subscription) Matches(eventName string) bool {
+	return slices.Contains(s.eventTypes, AllEvents) || slices.Contains(s.eventTypes, eventName)
+}
@@ -0,0 +1,117 @@
+package adapters_tests
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/infra/adapters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPWebhookSender_Send(t *testing.T) {
+	t.Run("should POST the body with the given headers", func(t *testing.T) {
+		// Arrange
+		var gotMethod, gotSignature string
+		var gotBody []byte
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotMethod = r.Method
+			gotSignature = r.Header.Get("X-Webhook-Signature")
+			gotBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer receiver.Close()
+		sender := adapters.NewHTTPWebhookSender(time.Second, true)
+
+		// Act
+		statusCode, err := sender.Send(context.Background(), receiver.URL, map[string]string{
+			"X-Webhook-Signature": "t=1,v1=abc",
+		}, []byte(`{"id":"1"}`))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, statusCode)
+		assert.Equal(t, http.MethodPost, gotMethod)
+		assert.Equal(t, "t=1,v1=abc", gotSignature)
+		assert.JSONEq(t, `{"id":"1"}`, string(gotBody))
+	})
+
+	t.Run("should return the status code of failed responses without error", func(t *testing.T) {
+		// Arrange
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer receiver.Close()
+		sender := adapters.NewHTTPWebhookSender(time.Second, true)
+
+		// Act
+		statusCode, err := sender.Send(context.Background(), receiver.URL, nil, []byte(`{}`))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+	})
+
+	t.Run("should return an error when the receiver times out", func(t *testing.T) {
+		// Arrange
+		release := make(chan struct{})
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer receiver.Close()
+		defer close(release)
+		sender := adapters.NewHTTPWebhookSender(50*time.Millisecond, true)
+
+		// Act
+		statusCode, err := sender.Send(context.Background(), receiver.URL, nil, []byte(`{}`))
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, 0, statusCode)
+	})
+	t.Run("should refuse to connect to a loopback receiver", func(t *testing.T) {
+		// Arrange
+		called := false
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		defer receiver.Close()
+		sender := adapters.NewHTTPWebhookSender(time.Second, false)
+
+		// Act
+		statusCode, err := sender.Send(context.Background(), receiver.URL, nil, []byte(`{}`))
+
+		// Assert
+		assert.Error(t, err)
+		assert.Equal(t, 0, statusCode)
+		assert.False(t, called)
+	})
+}
+
+func TestHTTPWebhookSender_CheckURL(t *testing.T) {
+	t.Run("should refuse hosts that resolve to loopback addresses", func(t *testing.T) {
+		// Arrange
+		sender := adapters.NewHTTPWebhookSender(time.Second, false)
+
+		// Act
+		err := sender.CheckURL(context.Background(), "http://localhost:8080/hook")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSubscriptionURLForbidden)
+	})
+
+	t.Run("should refuse hosts that do not resolve", func(t *testing.T) {
+		// Arrange
+		sender := adapters.NewHTTPWebhookSender(time.Second, false)
+
+		// Act
+		err := sender.CheckURL(context.Background(), "https://receiver.invalid/hook")
+
+		// Assert
+		assert.ErrorIs(t, err, models.ErrSubscriptionURLInvalid)
+	})
+}
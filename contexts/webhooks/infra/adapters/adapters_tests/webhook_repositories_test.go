@@ -0,0 +1,290 @@
+package adapters_tests
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/events"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_adapters"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/infra/adapters"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	postgres_driver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type WebhookRepositoriesTestSuite struct {
+	suite.Suite
+	container        *postgres.PostgresContainer
+	db               *gorm.DB
+	subscriptionRepo *adapters.SubscriptionRepository
+	deliveryRepo     *adapters.DeliveryRepository
+	txManager        *shared_adapters.GormTransactionManager
+	ctx              context.Context
+}
+
+func (suite *WebhookRepositoriesTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	container, err := postgres.Run(suite.ctx,
+		"postgres:15-alpine",
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2),
+		),
+	)
+	suite.Require().NoError(err)
+	suite.container = container
+
+	connStr, err := container.ConnectionString(suite.ctx, "sslmode=disable")
+	suite.Require().NoError(err)
+
+	db, err := gorm.Open(postgres_driver.Open(connStr), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	err = db.AutoMigrate(&adapters.SubscriptionEntity{}, &adapters.DeliveryEntity{}, &adapters.DeliveryAttemptEntity{})
+	suite.Require().NoError(err)
+
+	suite.subscriptionRepo = adapters.NewSubscriptionRepository(db)
+	suite.deliveryRepo = adapters.NewDeliveryRepository(db)
+	suite.txManager = shared_adapters.NewGormTransactionManager(db)
+	suite.db = db
+}
+
+func (suite *WebhookRepositoriesTestSuite) TearDownSuite() {
+	if suite.container != nil {
+		suite.container.Terminate(suite.ctx)
+	}
+}
+
+func (suite *WebhookRepositoriesTestSuite) TearDownTest() {
+	if suite.db != nil {
+		suite.db.Exec("TRUNCATE TABLE subscription_entities, delivery_entities, delivery_attempt_entities")
+	}
+}
+
+func (suite *WebhookRepositoriesTestSuite) TestGetMatching() {
+	suite.Run("should match subscriptions by event name and wildcard", func() {
+		// Arrange
+		created := models_mothers.NewSubscriptionMother().WithEventTypes("product.created").MustBuild()
+		deleted := models_mothers.NewSubscriptionMother().WithEventTypes("product.deleted").MustBuild()
+		all := models_mothers.NewSubscriptionMother().WithEventTypes(models.AllEvents).MustBuild()
+		for _, subscription := range []models.Subscription{created, deleted, all} {
+			suite.Require().NoError(suite.subscriptionRepo.Create(suite.ctx, subscription))
+		}
+
+		// Act
+		matching, err := suite.subscriptionRepo.GetMatching(suite.ctx, "product.created")
+
+		// Assert
+		suite.Require().NoError(err)
+		ids := make([]uuid.UUID, 0, len(matching))
+		for _, subscription := range matching {
+			ids = append(ids, subscription.ID())
+		}
+		suite.ElementsMatch([]uuid.UUID{created.ID(), all.ID()}, ids)
+	})
+}
+
+func (suite *WebhookRepositoriesTestSuite) TestTenantIsolation() {
+	suite.Run("should keep subscriptions and deliveries within their tenant", func() {
+		// Arrange
+		acme := request_context.WithTenant(suite.ctx, "acme")
+		globex := request_context.WithTenant(suite.ctx, "globex")
+		subscription := models_mothers.NewSubscriptionMother().WithEventTypes(models.AllEvents).MustBuild()
+		suite.Require().NoError(suite.subscriptionRepo.Create(acme, subscription))
+		enqueue := use_cases.NewEnqueueDeliveriesUseCase(suite.subscriptionRepo, suite.deliveryRepo)
+
+		// Act
+		suite.Require().NoError(enqueue.Execute(suite.ctx, events.Message{
+			ID:         uuid.New(),
+			TenantID:   "globex",
+			EventName:  "product.created",
+			Payload:    []byte(`{}`),
+			OccurredAt: time.Now().UTC(),
+		}))
+		suite.Require().NoError(enqueue.Execute(suite.ctx, events.Message{
+			ID:         uuid.New(),
+			TenantID:   "acme",
+			EventName:  "product.created",
+			Payload:    []byte(`{}`),
+			OccurredAt: time.Now().UTC(),
+		}))
+
+		// Assert
+		found, err := suite.subscriptionRepo.GetByID(globex, subscription.ID())
+		suite.Require().NoError(err)
+		suite.Nil(found)
+		all, err := suite.subscriptionRepo.GetAll(globex)
+		suite.Require().NoError(err)
+		suite.Empty(all)
+		suite.ErrorIs(suite.subscriptionRepo.Delete(globex, subscription.ID()), shared_handlers.ErrNotFound)
+
+		foreign, err := suite.deliveryRepo.GetBySubscriptionID(globex, models.DeliveryQuery{SubscriptionID: subscription.ID()})
+		suite.Require().NoError(err)
+		suite.Empty(foreign.Items)
+		own, err := suite.deliveryRepo.GetBySubscriptionID(acme, models.DeliveryQuery{SubscriptionID: subscription.ID()})
+		suite.Require().NoError(err)
+		suite.Require().Len(own.Items, 1)
+		suite.Equal("acme", own.Items[0].TenantID)
+	})
+}
+
+func (suite *WebhookRepositoriesTestSuite) TestDelete() {
+	suite.Run("should return not found for unknown subscriptions", func() {
+		// Act
+		err := suite.subscriptionRepo.Delete(suite.ctx, uuid.New())
+
+		// Assert
+		suite.ErrorIs(err, shared_handlers.ErrNotFound)
+	})
+}
+
+func (suite *WebhookRepositoriesTestSuite) TestEnqueue() {
+	suite.Run("should enqueue a message once per subscription", func() {
+		// Arrange
+		subscription := models_mothers.NewSubscriptionMother().MustBuild()
+		suite.Require().NoError(suite.subscriptionRepo.Create(suite.ctx, subscription))
+		messageID := uuid.New()
+		first, err := models.NewDelivery(subscription.ID(), messageID, "product.created", []byte(`{}`), time.Now().UTC())
+		suite.Require().NoError(err)
+		replayed, err := models.NewDelivery(subscription.ID(), messageID, "product.created", []byte(`{}`), time.Now().UTC())
+		suite.Require().NoError(err)
+
+		// Act
+		suite.Require().NoError(suite.deliveryRepo.Enqueue(suite.ctx, []models.Delivery{first}))
+		suite.Require().NoError(suite.deliveryRepo.Enqueue(suite.ctx, []models.Delivery{replayed}))
+
+		// Assert
+		page, err := suite.deliveryRepo.GetBySubscriptionID(suite.ctx, models.DeliveryQuery{SubscriptionID: subscription.ID()})
+		suite.Require().NoError(err)
+		suite.Require().Len(page.Items, 1)
+		suite.Equal(first.ID, page.Items[0].ID)
+	})
+}
+
+func (suite *WebhookRepositoriesTestSuite) TestGetBySubscriptionID() {
+	suite.Run("should page through deliveries newest first", func() {
+		// Arrange
+		subscription := models_mothers.NewSubscriptionMother().MustBuild()
+		suite.Require().NoError(suite.subscriptionRepo.Create(suite.ctx, subscription))
+		for range 3 {
+			delivery, err := models.NewDelivery(subscription.ID(), uuid.New(), "product.created", []byte(`{}`), time.Now().UTC())
+			suite.Require().NoError(err)
+			suite.Require().NoError(suite.deliveryRepo.Enqueue(suite.ctx, []models.Delivery{delivery}))
+		}
+		limit := 2
+
+		// Act
+		first, err := suite.deliveryRepo.GetBySubscriptionID(suite.ctx, models.DeliveryQuery{SubscriptionID: subscription.ID(), Limit: &limit})
+		suite.Require().NoError(err)
+		second, err := suite.deliveryRepo.GetBySubscriptionID(suite.ctx, models.DeliveryQuery{SubscriptionID: subscription.ID(), Limit: &limit, Cursor: first.Next})
+		suite.Require().NoError(err)
+
+		// Assert
+		suite.Len(first.Items, 2)
+		suite.NotNil(first.Next)
+		suite.Len(second.Items, 1)
+		suite.Nil(second.Next)
+		suite.False(second.Items[0].CreatedAt.After(first.Items[1].CreatedAt))
+	})
+}
+
+func (suite *WebhookRepositoriesTestSuite) TestClaimDue() {
+	suite.Run("should skip claimed deliveries until their lease runs out", func() {
+		// Arrange
+		subscription := models_mothers.NewSubscriptionMother().MustBuild()
+		suite.Require().NoError(suite.subscriptionRepo.Create(suite.ctx, subscription))
+		delivery, err := models.NewDelivery(subscription.ID(), uuid.New(), "product.created", []byte(`{}`), time.Now().UTC())
+		suite.Require().NoError(err)
+		suite.Require().NoError(suite.deliveryRepo.Enqueue(suite.ctx, []models.Delivery{delivery}))
+		now := time.Now().UTC()
+
+		// Act
+		claimed, err := suite.deliveryRepo.ClaimDue(suite.ctx, now, time.Minute, 10)
+		suite.Require().NoError(err)
+		again, err := suite.deliveryRepo.ClaimDue(suite.ctx, now, time.Minute, 10)
+		suite.Require().NoError(err)
+		expired, err := suite.deliveryRepo.ClaimDue(suite.ctx, now.Add(2*time.Minute), time.Minute, 10)
+		suite.Require().NoError(err)
+
+		// Assert
+		suite.Require().Len(claimed, 1)
+		suite.Equal(delivery.ID, claimed[0].ID)
+		suite.Empty(again)
+		suite.Len(expired, 1)
+	})
+}
+
+func (suite *WebhookRepositoriesTestSuite) TestDeliverDueWebhooks() {
+	suite.Run("should deliver signed events to a receiver and log every attempt", func() {
+		// Arrange
+		const secret = "receiver-secret-0123456789"
+		responses := []int{http.StatusInternalServerError, http.StatusOK}
+		var received [][]byte
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			signature := r.Header.Get(use_cases.HeaderSignature)
+			timestamp, _ := strconv.ParseInt(strings.TrimPrefix(strings.Split(signature, ",")[0], "t="), 10, 64)
+			if signature != models.Sign(secret, time.Unix(timestamp, 0), body) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			received = append(received, body)
+			w.WriteHeader(responses[len(received)-1])
+		}))
+		defer receiver.Close()
+
+		subscription := models_mothers.NewSubscriptionMother().WithURL(receiver.URL).WithSecret(secret).MustBuild()
+		suite.Require().NoError(suite.subscriptionRepo.Create(suite.ctx, subscription))
+
+		enqueue := use_cases.NewEnqueueDeliveriesUseCase(suite.subscriptionRepo, suite.deliveryRepo)
+		deliver := use_cases.NewDeliverDueWebhooksUseCase(suite.subscriptionRepo, suite.deliveryRepo, adapters.NewHTTPWebhookSender(time.Second, true), suite.txManager,
+			models.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond}, 10, time.Minute)
+		suite.Require().NoError(enqueue.Execute(suite.ctx, events.Message{
+			ID:         uuid.New(),
+			EventName:  "product.created",
+			Payload:    []byte(`{"name":"Laptop"}`),
+			OccurredAt: time.Now().UTC(),
+		}))
+
+		// Act
+		_, err := deliver.Execute(suite.ctx)
+		suite.Require().NoError(err)
+		time.Sleep(5 * time.Millisecond)
+		_, err = deliver.Execute(suite.ctx)
+		suite.Require().NoError(err)
+
+		// Assert
+		suite.Require().Len(received, 2)
+		suite.Equal(received[0], received[1])
+		page, err := suite.deliveryRepo.GetBySubscriptionID(suite.ctx, models.DeliveryQuery{SubscriptionID: subscription.ID()})
+		suite.Require().NoError(err)
+		suite.Require().Len(page.Items, 1)
+		suite.Equal(models.DeliverySucceeded, page.Items[0].Status)
+		suite.Require().Len(page.Items[0].AttemptLog, 2)
+		suite.Equal(http.StatusInternalServerError, page.Items[0].AttemptLog[0].StatusCode)
+		suite.Equal(http.StatusOK, page.Items[0].AttemptLog[1].StatusCode)
+	})
+}
+
+func TestWebhookRepositoriesTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookRepositoriesTestSuite))
+}
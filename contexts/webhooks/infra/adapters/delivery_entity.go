@@ -0,0 +1,97 @@
+package adapters
+
+import (
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/google/uuid"
+)
+
+// DeliveryEntity keeps Body as bytea rather than jsonb so the exact bytes
+// that were signed are sent on every attempt. A message is delivered at most
+// once per subscription, which the unique index enforces. LockedUntil is set
+// while a worker has claimed the delivery to attempt it.
+type DeliveryEntity struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TenantID       string    `gorm:"not null;default:default"`
+	SubscriptionID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_delivery_message,priority:1;index:idx_webhook_delivery_log,priority:1"`
+	MessageID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_delivery_message,priority:2"`
+	EventName      string    `gorm:"not null"`
+	Body           []byte    `gorm:"type:bytea;not null"`
+	Status         string    `gorm:"not null;index:idx_webhook_delivery_due,priority:1"`
+	Attempts       int       `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time `gorm:"not null;index:idx_webhook_delivery_due,priority:2"`
+	LockedUntil    *time.Time
+	CreatedAt      time.Time               `gorm:"not null;index:idx_webhook_delivery_log,priority:2"`
+	AttemptLog     []DeliveryAttemptEntity `gorm:"foreignKey:DeliveryID"`
+}
+
+// DeliveryAttemptEntity is append-only: one row per POST.
+type DeliveryAttemptEntity struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	DeliveryID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	Number      int       `gorm:"not null"`
+	AttemptedAt time.Time `gorm:"not null"`
+	StatusCode  int
+	Error       string
+	DurationMs  int64 `gorm:"not null"`
+}
+
+func NewDeliveryEntity(delivery models.Delivery) DeliveryEntity {
+	return DeliveryEntity{
+		ID:             delivery.ID,
+		TenantID:       delivery.TenantID,
+		SubscriptionID: delivery.SubscriptionID,
+		MessageID:      delivery.MessageID,
+		EventName:      delivery.EventName,
+		Body:           delivery.Body,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
+
+func NewDeliveryAttemptEntity(attempt models.DeliveryAttempt) DeliveryAttemptEntity {
+	return DeliveryAttemptEntity{
+		ID:          attempt.ID,
+		DeliveryID:  attempt.DeliveryID,
+		Number:      attempt.Number,
+		AttemptedAt: attempt.AttemptedAt,
+		StatusCode:  attempt.StatusCode,
+		Error:       attempt.Error,
+		DurationMs:  attempt.Duration.Milliseconds(),
+	}
+}
+
+func (e *DeliveryEntity) ToDomainModel() models.Delivery {
+	attempts := make([]models.DeliveryAttempt, 0, len(e.AttemptLog))
+	for _, attempt := range e.AttemptLog {
+		attempts = append(attempts, attempt.ToDomainModel())
+	}
+	return models.Delivery{
+		ID:             e.ID,
+		TenantID:       e.TenantID,
+		SubscriptionID: e.SubscriptionID,
+		MessageID:      e.MessageID,
+		EventName:      e.EventName,
+		Body:           e.Body,
+		Status:         models.DeliveryStatus(e.Status),
+		Attempts:       e.Attempts,
+		NextAttemptAt:  e.NextAttemptAt.UTC(),
+		CreatedAt:      e.CreatedAt.UTC(),
+		AttemptLog:     attempts,
+	}
+}
+
+func (e *DeliveryAttemptEntity) ToDomainModel() models.DeliveryAttempt {
+	return models.DeliveryAttempt{
+		ID:          e.ID,
+		DeliveryID:  e.DeliveryID,
+		Number:      e.Number,
+		AttemptedAt: e.AttemptedAt.UTC(),
+		StatusCode:  e.StatusCode,
+		Error:       e.Error,
+		Duration:    time.Duration(e.DurationMs) * time.Millisecond,
+	}
+}
@@ -0,0 +1,133 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_adapters"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const oneMore = 1
+const defaultLimit = 10
+
+// DeliveryRepository stores deliveries for the tenant of the request context
+// and lists only theirs. ClaimDue and RecordAttempt serve the delivery worker,
+// which works for every tenant.
+type DeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewDeliveryRepository(db *gorm.DB) *DeliveryRepository {
+	return &DeliveryRepository{db: db}
+}
+
+func (dr *DeliveryRepository) Enqueue(ctx context.Context, deliveries []models.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	entities := make([]DeliveryEntity, 0, len(deliveries))
+	tenant := request_context.TenantFrom(ctx)
+	for _, delivery := range deliveries {
+		entity := NewDeliveryEntity(delivery)
+		entity.TenantID = tenant
+		entities = append(entities, entity)
+	}
+	return shared_adapters.DBFromContext(ctx, dr.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "message_id"}},
+			DoNothing: true,
+		}).
+		Create(&entities).Error
+}
+
+func (dr *DeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.Delivery, error) {
+	db := shared_adapters.DBFromContext(ctx, dr.db)
+	var entities []DeliveryEntity
+	err := db.
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_attempt_at <= ?", string(models.DeliveryPending), now).
+		Where("locked_until IS NULL OR locked_until <= ?", now).
+		Order("next_attempt_at").
+		Limit(limit).
+		Find(&entities).Error
+	if err != nil || len(entities) == 0 {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(entities))
+	for _, entity := range entities {
+		ids = append(ids, entity.ID)
+	}
+	if err := db.Model(&DeliveryEntity{}).Where("id IN ?", ids).Update("locked_until", now.Add(lease)).Error; err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]models.Delivery, 0, len(entities))
+	for _, entity := range entities {
+		deliveries = append(deliveries, entity.ToDomainModel())
+	}
+	return deliveries, nil
+}
+
+func (dr *DeliveryRepository) RecordAttempt(ctx context.Context, delivery models.Delivery, attempt models.DeliveryAttempt) error {
+	db := shared_adapters.DBFromContext(ctx, dr.db)
+	err := db.Model(&DeliveryEntity{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]interface{}{
+			"status":          string(delivery.Status),
+			"attempts":        delivery.Attempts,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"locked_until":    nil,
+		}).Error
+	if err != nil {
+		return err
+	}
+	attemptEntity := NewDeliveryAttemptEntity(attempt)
+	return db.Create(&attemptEntity).Error
+}
+
+// GetBySubscriptionID pages through the deliveries of a subscription, newest
+// first, each with its attempts in order.
+func (dr *DeliveryRepository) GetBySubscriptionID(ctx context.Context, query models.DeliveryQuery) (models.DeliveryPage, error) {
+	handledLimit := defaultLimit
+	if query.Limit != nil {
+		handledLimit = *query.Limit
+	}
+
+	db := shared_adapters.DBFromContext(ctx, dr.db).
+		Preload("AttemptLog", func(db *gorm.DB) *gorm.DB {
+			return db.Order("number")
+		}).
+		Where("tenant_id = ? AND subscription_id = ?", request_context.TenantFrom(ctx), query.SubscriptionID).
+		Order("created_at DESC").
+		Order("id DESC").
+		Limit(handledLimit + oneMore)
+	if query.Cursor != nil {
+		db = db.Where("(created_at, id) < (?, ?)", query.Cursor.CreatedAt, query.Cursor.ID)
+	}
+
+	var entities []DeliveryEntity
+	if err := db.Find(&entities).Error; err != nil {
+		return models.DeliveryPage{}, err
+	}
+
+	hasMore := len(entities) > handledLimit
+	if hasMore {
+		entities = entities[:handledLimit]
+	}
+
+	page := models.DeliveryPage{Items: make([]models.Delivery, 0, len(entities))}
+	for _, entity := range entities {
+		page.Items = append(page.Items, entity.ToDomainModel())
+	}
+	if hasMore {
+		last := page.Items[len(page.Items)-1]
+		page.Next = &models.DeliveryCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return page, nil
+}
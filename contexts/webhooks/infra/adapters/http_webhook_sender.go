@@ -0,0 +1,102 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+)
+
+const defaultSendTimeout = 10 * time.Second
+
+// maxDrainedResponseBytes bounds how much of a receiver's response is read
+// before closing it, so the connection can be reused.
+const maxDrainedResponseBytes = 64 << 10
+
+var errAddressNotPublic = errors.New("webhook receiver address is not public")
+
+// HTTPWebhookSender refuses to connect to addresses that are not public, which
+// it checks on the address actually dialled, after DNS resolution and on every
+// redirect. It never goes through a proxy, which would connect on its behalf.
+type HTTPWebhookSender struct {
+	client               *http.Client
+	allowPrivateNetworks bool
+}
+
+// NewHTTPWebhookSender returns a sender whose requests time out after timeout.
+// allowPrivateNetworks lifts the address check, for receivers running next to
+// the API in tests.
+func NewHTTPWebhookSender(timeout time.Duration, allowPrivateNetworks bool) *HTTPWebhookSender {
+	if timeout <= 0 {
+		timeout = defaultSendTimeout
+	}
+	dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}
+	if !allowPrivateNetworks {
+		dialer.Control = refuseNonPublicAddress
+	}
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+	return &HTTPWebhookSender{
+		client:               &http.Client{Timeout: timeout, Transport: transport},
+		allowPrivateNetworks: allowPrivateNetworks,
+	}
+}
+
+func (s *HTTPWebhookSender) CheckURL(ctx context.Context, rawURL string) error {
+	if s.allowPrivateNetworks {
+		return nil
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return models.ErrSubscriptionURLInvalid
+	}
+	addrs, _ := net.DefaultResolver.LookupNetIP(ctx, "ip", parsed.Hostname())
+	return models.CheckResolvedURL(rawURL, addrs)
+}
+
+func (s *HTTPWebhookSender) Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedResponseBytes))
+
+	return resp.StatusCode, nil
+}
+
+// refuseNonPublicAddress runs right before every connection, with the
+// resolved address about to be dialled.
+func refuseNonPublicAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !models.IsPublicAddress(addr) {
+		return errAddressNotPublic
+	}
+	return nil
+}
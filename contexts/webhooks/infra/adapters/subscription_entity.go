@@ -0,0 +1,43 @@
+package adapters
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/google/uuid"
+)
+
+// SubscriptionEntity stores event types as a jsonb array so subscriptions can
+// be matched against an event with a containment query. A subscription only
+// receives the events of its tenant.
+type SubscriptionEntity struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TenantID   string    `gorm:"not null;default:default;index"`
+	URL        string    `gorm:"not null"`
+	EventTypes []byte    `gorm:"type:jsonb;not null"`
+	Secret     string    `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"not null"`
+}
+
+func NewSubscriptionEntity(subscription models.Subscription) (SubscriptionEntity, error) {
+	eventTypes, err := json.Marshal(subscription.EventTypes())
+	if err != nil {
+		return SubscriptionEntity{}, err
+	}
+	return SubscriptionEntity{
+		ID:         subscription.ID(),
+		URL:        subscription.URL(),
+		EventTypes: eventTypes,
+		Secret:     subscription.Secret(),
+		CreatedAt:  subscription.CreatedAt(),
+	}, nil
+}
+
+func (e *SubscriptionEntity) ToDomainModel() (models.Subscription, error) {
+	var eventTypes []string
+	if err := json.Unmarshal(e.EventTypes, &eventTypes); err != nil {
+		return nil, err
+	}
+	return models.ReconstituteSubscription(e.ID, e.URL, eventTypes, e.Secret, e.CreatedAt.UTC())
+}
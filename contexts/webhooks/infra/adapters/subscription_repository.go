@@ -0,0 +1,104 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/request_context"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_adapters"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SubscriptionRepository only ever sees the subscriptions of the tenant of the
+// request context: every query starts from scoped.
+type SubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionRepository(db *gorm.DB) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db}
+}
+
+// scoped returns the database handle for ctx, limited to the subscriptions of
+// its tenant.
+func (sr *SubscriptionRepository) scoped(ctx context.Context) *gorm.DB {
+	return shared_adapters.DBFromContext(ctx, sr.db).Where("subscription_entities.tenant_id = ?", request_context.TenantFrom(ctx))
+}
+
+func (sr *SubscriptionRepository) Create(ctx context.Context, subscription models.Subscription) error {
+	entity, err := NewSubscriptionEntity(subscription)
+	if err != nil {
+		return err
+	}
+	entity.TenantID = request_context.TenantFrom(ctx)
+	return shared_adapters.TranslateError(shared_adapters.DBFromContext(ctx, sr.db).Create(&entity).Error)
+}
+
+func (sr *SubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (models.Subscription, error) {
+	var entity SubscriptionEntity
+	if err := sr.scoped(ctx).First(&entity, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return entity.ToDomainModel()
+}
+
+func (sr *SubscriptionRepository) GetAll(ctx context.Context) ([]models.Subscription, error) {
+	var entities []SubscriptionEntity
+	if err := sr.scoped(ctx).Order("created_at").Order("id").Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	return toSubscriptions(entities)
+}
+
+// GetMatching returns the subscriptions listening to eventName, either by
+// name or through the wildcard.
+func (sr *SubscriptionRepository) GetMatching(ctx context.Context, eventName string) ([]models.Subscription, error) {
+	byName, err := json.Marshal([]string{eventName})
+	if err != nil {
+		return nil, err
+	}
+	wildcard, err := json.Marshal([]string{models.AllEvents})
+	if err != nil {
+		return nil, err
+	}
+
+	var entities []SubscriptionEntity
+	err = sr.scoped(ctx).
+		Where("event_types @> ?::jsonb OR event_types @> ?::jsonb", string(byName), string(wildcard)).
+		Order("created_at").
+		Order("id").
+		Find(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+	return toSubscriptions(entities)
+}
+
+func (sr *SubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := sr.scoped(ctx).Delete(&SubscriptionEntity{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return shared_handlers.ErrNotFound
+	}
+	return nil
+}
+
+func toSubscriptions(entities []SubscriptionEntity) ([]models.Subscription, error) {
+	subscriptions := make([]models.Subscription, 0, len(entities))
+	for _, entity := range entities {
+		subscription, err := entity.ToDomainModel()
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
@@ -0,0 +1,57 @@
+package handlers_mocks
+
+import (
+	"context"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCreateSubscriptionUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCreateSubscriptionUseCase) Execute(ctx context.Context, subscription models.Subscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+type MockGetAllSubscriptionsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetAllSubscriptionsUseCase) Execute(ctx context.Context) ([]models.Subscription, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.Subscription), args.Error(1)
+}
+
+type MockGetOneSubscriptionUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetOneSubscriptionUseCase) Execute(ctx context.Context, id uuid.UUID) (models.Subscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(models.Subscription), args.Error(1)
+}
+
+type MockDeleteSubscriptionUseCase struct {
+	mock.Mock
+}
+
+func (m *MockDeleteSubscriptionUseCase) Execute(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockGetDeliveriesUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetDeliveriesUseCase) Execute(ctx context.Context, query models.DeliveryQuery) (models.DeliveryPage, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(models.DeliveryPage), args.Error(1)
+}
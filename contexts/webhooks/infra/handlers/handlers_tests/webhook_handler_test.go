@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/pagination"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/shared_dto"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/middlewares"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/dto"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models/models_mothers"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/infra/handlers"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/infra/handlers/handlers_mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookHandlerTestSuite struct {
+	suite.Suite
+	handler *handlers.WebhookHandler
+	router  *gin.Engine
+	codec   *pagination.CursorCodec
+
+	mockCreateUseCase     *handlers_mocks.MockCreateSubscriptionUseCase
+	mockGetAllUseCase     *handlers_mocks.MockGetAllSubscriptionsUseCase
+	mockGetOneUseCase     *handlers_mocks.MockGetOneSubscriptionUseCase
+	mockDeleteUseCase     *handlers_mocks.MockDeleteSubscriptionUseCase
+	mockDeliveriesUseCase *handlers_mocks.MockGetDeliveriesUseCase
+}
+
+func (suite *WebhookHandlerTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+}
+
+func (suite *WebhookHandlerTestSuite) SetupTest() {
+	suite.mockCreateUseCase = new(handlers_mocks.MockCreateSubscriptionUseCase)
+	suite.mockGetAllUseCase = new(handlers_mocks.MockGetAllSubscriptionsUseCase)
+	suite.mockGetOneUseCase = new(handlers_mocks.MockGetOneSubscriptionUseCase)
+	suite.mockDeleteUseCase = new(handlers_mocks.MockDeleteSubscriptionUseCase)
+	suite.mockDeliveriesUseCase = new(handlers_mocks.MockGetDeliveriesUseCase)
+
+	suite.codec = pagination.NewCursorCodec([]byte("test-secret"))
+
+	suite.handler = handlers.NewWebhookHandler(
+		suite.mockCreateUseCase,
+		suite.mockGetAllUseCase,
+		suite.mockGetOneUseCase,
+		suite.mockDeleteUseCase,
+		suite.mockDeliveriesUseCase,
+		suite.codec,
+	)
+
+	suite.router = gin.New()
+	suite.router.Use(middlewares.ErrorHandlerMiddleware())
+
+	suite.router.POST("/webhooks/subscriptions", suite.handler.CreateSubscription)
+	suite.router.GET("/webhooks/subscriptions", suite.handler.GetAllSubscriptions)
+	suite.router.GET("/webhooks/subscriptions/:id", suite.handler.GetSubscription)
+	suite.router.DELETE("/webhooks/subscriptions/:id", suite.handler.DeleteSubscription)
+	suite.router.GET("/webhooks/subscriptions/:id/deliveries", suite.handler.GetDeliveries)
+}
+
+func (suite *WebhookHandlerTestSuite) TestCreateSubscription() {
+	suite.Run("should create a subscription and return its generated secret", func() {
+		// Arrange
+		suite.mockCreateUseCase.On("Execute", mock.Anything, mock.MatchedBy(func(subscription models.Subscription) bool {
+			return subscription.URL() == "https://example.com/hook" && len(subscription.Secret()) >= 16
+		})).Return(nil).Once()
+		body := `{"url":"https://example.com/hook","event_types":["product.created"]}`
+
+		// Act
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/subscriptions", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusCreated, w.Code)
+		var response dto.SubscriptionResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal([]string{"product.created"}, response.EventTypes)
+		suite.NotEmpty(response.Secret)
+		suite.mockCreateUseCase.AssertExpectations(suite.T())
+	})
+
+	suite.Run("should reject an invalid URL", func() {
+		// Arrange
+		body := `{"url":"ftp://example.com","event_types":["product.created"]}`
+
+		// Act
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/subscriptions", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+		var response shared_dto.ErrorResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Equal(models.ErrSubscriptionURLInvalid.Code, response.Error)
+	})
+
+	suite.Run("should reject a request without event types", func() {
+		// Act
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/subscriptions", bytes.NewBufferString(`{"url":"https://example.com/hook"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+}
+
+func (suite *WebhookHandlerTestSuite) TestGetAllSubscriptions() {
+	suite.Run("should list subscriptions without their secrets", func() {
+		// Arrange
+		subscription := models_mothers.NewSubscriptionMother().MustBuild()
+		suite.mockGetAllUseCase.On("Execute", mock.Anything).Return([]models.Subscription{subscription}, nil).Once()
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, "/webhooks/subscriptions", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		var response []dto.SubscriptionResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Require().Len(response, 1)
+		suite.Equal(subscription.ID(), response[0].ID)
+		suite.Empty(response[0].Secret)
+	})
+}
+
+func (suite *WebhookHandlerTestSuite) TestGetSubscription() {
+	suite.Run("should return 404 for an unknown subscription", func() {
+		// Arrange
+		id := uuid.New()
+		suite.mockGetOneUseCase.On("Execute", mock.Anything, id).Return(nil, nil).Once()
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/webhooks/subscriptions/%s", id), nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusNotFound, w.Code)
+	})
+}
+
+func (suite *WebhookHandlerTestSuite) TestDeleteSubscription() {
+	suite.Run("should delete a subscription", func() {
+		// Arrange
+		id := uuid.New()
+		suite.mockDeleteUseCase.On("Execute", mock.Anything, id).Return(nil).Once()
+
+		// Act
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/webhooks/subscriptions/%s", id), nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusNoContent, w.Code)
+	})
+
+	suite.Run("should return 404 for an unknown subscription", func() {
+		// Arrange
+		id := uuid.New()
+		suite.mockDeleteUseCase.On("Execute", mock.Anything, id).Return(shared_handlers.ErrNotFound).Once()
+
+		// Act
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/webhooks/subscriptions/%s", id), nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusNotFound, w.Code)
+	})
+}
+
+func (suite *WebhookHandlerTestSuite) TestGetDeliveries() {
+	suite.Run("should return the delivery log with a cursor to the next page", func() {
+		// Arrange
+		subscriptionID := uuid.New()
+		delivery, err := models.NewDelivery(subscriptionID, uuid.New(), "product.created", []byte(`{"name":"Laptop"}`), time.Now().UTC())
+		suite.Require().NoError(err)
+		attempt := delivery.RecordAttempt(http.StatusBadGateway, nil, time.Now().UTC(), 120*time.Millisecond, models.DefaultRetryPolicy())
+		delivery.AttemptLog = []models.DeliveryAttempt{attempt}
+		next := models.DeliveryCursor{CreatedAt: delivery.CreatedAt, ID: delivery.ID}
+		suite.mockDeliveriesUseCase.On("Execute", mock.Anything, models.DeliveryQuery{SubscriptionID: subscriptionID}).
+			Return(models.DeliveryPage{Items: []models.Delivery{delivery}, Next: &next}, nil).Once()
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/webhooks/subscriptions/%s/deliveries", subscriptionID), nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusOK, w.Code)
+		var response shared_dto.PaginatedResult[dto.DeliveryResponse]
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Require().Len(response.Items, 1)
+		suite.Equal("pending", response.Items[0].Status)
+		suite.NotNil(response.Items[0].NextAttemptAt)
+		suite.Require().Len(response.Items[0].AttemptLog, 1)
+		suite.Equal(int64(120), response.Items[0].AttemptLog[0].DurationMs)
+		var payload struct {
+			Data json.RawMessage `json:"data"`
+		}
+		suite.Require().NoError(json.Unmarshal(response.Items[0].Payload, &payload))
+		suite.JSONEq(`{"name":"Laptop"}`, string(payload.Data))
+		suite.Require().NotNil(response.NextCursor)
+
+		cursor, err := suite.codec.Decode(*response.NextCursor)
+		suite.Require().NoError(err)
+		position, err := dto.DeliveryCursorFromPaginationCursor(subscriptionID, cursor)
+		suite.Require().NoError(err)
+		suite.Equal(next.ID, position.ID)
+	})
+
+	suite.Run("should reject a cursor issued for another subscription", func() {
+		// Arrange
+		cursor, err := suite.codec.Encode(dto.NewDeliveryPaginationCursor(uuid.New(), models.DeliveryCursor{CreatedAt: time.Now(), ID: uuid.New()}))
+		suite.Require().NoError(err)
+
+		// Act
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/webhooks/subscriptions/%s/deliveries?cursor=%s", uuid.New(), cursor), nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+
+		// Assert
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestWebhookHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookHandlerTestSuite))
+}
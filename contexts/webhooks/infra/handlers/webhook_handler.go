@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/pagination"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/shared_dto"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/shared_handlers"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/dto"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/ports/inbound"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const topLimitValue = 100
+const bottomLimitValue = 1
+
+// WebhookHandler handles HTTP requests for webhook subscriptions and their
+// delivery log
+type WebhookHandler struct {
+	createSubscriptionUseCase  inbound.CreateSubscriptionUseCasePort
+	getAllSubscriptionsUseCase inbound.GetAllSubscriptionsUseCasePort
+	getOneSubscriptionUseCase  inbound.GetOneSubscriptionUseCasePort
+	deleteSubscriptionUseCase  inbound.DeleteSubscriptionUseCasePort
+	getDeliveriesUseCase       inbound.GetDeliveriesUseCasePort
+	cursorCodec                *pagination.CursorCodec
+}
+
+// NewWebhookHandler creates a new WebhookHandler
+func NewWebhookHandler(createSubscriptionUseCase inbound.CreateSubscriptionUseCasePort, getAllSubscriptionsUseCase inbound.GetAllSubscriptionsUseCasePort, getOneSubscriptionUseCase inbound.GetOneSubscriptionUseCasePort, deleteSubscriptionUseCase inbound.DeleteSubscriptionUseCasePort, getDeliveriesUseCase inbound.GetDeliveriesUseCasePort, cursorCodec *pagination.CursorCodec) *WebhookHandler {
+	return &WebhookHandler{
+		createSubscriptionUseCase:  createSubscriptionUseCase,
+		getAllSubscriptionsUseCase: getAllSubscriptionsUseCase,
+		getOneSubscriptionUseCase:  getOneSubscriptionUseCase,
+		deleteSubscriptionUseCase:  deleteSubscriptionUseCase,
+		getDeliveriesUseCase:       getDeliveriesUseCase,
+		cursorCodec:                cursorCodec,
+	}
+}
+
+// CreateSubscription godoc
+// @Summary Register a webhook endpoint
+// @Description Subscribe a URL to event types ("*" for all). Deliveries are signed with the secret in the X-Webhook-Signature header; the secret is generated when omitted and only returned here
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param subscription body dto.CreateSubscriptionRequest true "Subscription details"
+// @Param Idempotency-Key header string false "Unique key that makes retrying the request safe: a repeat gets the original response replayed"
+// @Success 201 {object} dto.SubscriptionResponse
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 409 {object} shared_dto.ErrorResponse
+// @Failure 422 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /webhooks/subscriptions [post]
+func (wh *WebhookHandler) CreateSubscription(c *gin.Context) {
+	var subscriptionDto dto.CreateSubscriptionRequest
+	if err := c.ShouldBindJSON(&subscriptionDto); err != nil {
+		c.Error(shared_handlers.BindingError(err))
+		return
+	}
+	subscription, err := subscriptionDto.ToDomainModel()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := wh.createSubscriptionUseCase.Execute(c.Request.Context(), subscription); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewCreatedSubscriptionResponseFromDomainModel(subscription))
+}
+
+// GetAllSubscriptions godoc
+// @Summary List webhook subscriptions
+// @Description Get every registered webhook subscription, oldest first
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.SubscriptionResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /webhooks/subscriptions [get]
+func (wh *WebhookHandler) GetAllSubscriptions(c *gin.Context) {
+	subscriptions, err := wh.getAllSubscriptionsUseCase.Execute(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	responses := make([]dto.SubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		responses = append(responses, dto.NewSubscriptionResponseFromDomainModel(subscription))
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// GetSubscription godoc
+// @Summary Get webhook subscription by ID
+// @Description Get a single webhook subscription by its ID
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Subscription ID (UUID)" format(uuid)
+// @Success 200 {object} dto.SubscriptionResponse
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 404 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /webhooks/subscriptions/{id} [get]
+func (wh *WebhookHandler) GetSubscription(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(shared_handlers.ErrInvalidUUID)
+		return
+	}
+	subscription, err := wh.getOneSubscriptionUseCase.Execute(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if subscription == nil {
+		c.Error(shared_handlers.ErrNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSubscriptionResponseFromDomainModel(subscription))
+}
+
+// DeleteSubscription godoc
+// @Summary Delete a webhook subscription
+// @Description Stop delivering events to a subscription. Pending deliveries are abandoned; the delivery log is kept
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Subscription ID (UUID)" format(uuid)
+// @Success 204
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 404 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /webhooks/subscriptions/{id} [delete]
+func (wh *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(shared_handlers.ErrInvalidUUID)
+		return
+	}
+
+	if err := wh.deleteSubscriptionUseCase.Execute(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetDeliveries godoc
+// @Summary Get the delivery log of a subscription
+// @Description Get the deliveries of a subscription, newest first, each with the outcome of every attempt
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Subscription ID (UUID)" format(uuid)
+// @Param cursor query string false "Opaque cursor, as returned in next_cursor"
+// @Param limit query int false "Limit of deliveries per page (1-100)" minimum(1) maximum(100)
+// @Success 200 {object} shared_dto.PaginatedResult[dto.DeliveryResponse]
+// @Failure 400 {object} shared_dto.ErrorResponse
+// @Failure 401 {object} shared_dto.ErrorResponse
+// @Failure 403 {object} shared_dto.ErrorResponse
+// @Failure 500 {object} shared_dto.ErrorResponse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /webhooks/subscriptions/{id}/deliveries [get]
+func (wh *WebhookHandler) GetDeliveries(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(shared_handlers.ErrInvalidUUID)
+		return
+	}
+	query, err := wh.parseDeliveryQuery(c, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	page, err := wh.getDeliveriesUseCase.Execute(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	deliveries := make([]dto.DeliveryResponse, 0, len(page.Items))
+	for _, delivery := range page.Items {
+		deliveries = append(deliveries, dto.NewDeliveryResponseFromDomainModel(delivery))
+	}
+	nextCursor, err := wh.encodeDeliveryCursor(id, page.Next)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, shared_dto.NewPaginatedResult(deliveries, nextCursor, nil))
+}
+
+// parseDeliveryQuery reads cursor and limit for the delivery log of the
+// subscription with the given id.
+func (wh *WebhookHandler) parseDeliveryQuery(c *gin.Context, subscriptionID uuid.UUID) (models.DeliveryQuery, error) {
+	query := models.DeliveryQuery{SubscriptionID: subscriptionID}
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		cursor, err := wh.cursorCodec.Decode(cursorStr)
+		if err != nil {
+			return models.DeliveryQuery{}, shared_handlers.ErrInvalidCursor
+		}
+		position, err := dto.DeliveryCursorFromPaginationCursor(subscriptionID, cursor)
+		if err != nil {
+			return models.DeliveryQuery{}, shared_handlers.ErrInvalidCursor
+		}
+		query.Cursor = &position
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limitValue, err := strconv.Atoi(limitStr)
+		if err != nil || limitValue < bottomLimitValue || limitValue > topLimitValue {
+			return models.DeliveryQuery{}, shared_handlers.ErrInvalidLimit
+		}
+		query.Limit = &limitValue
+	}
+
+	return query, nil
+}
+
+func (wh *WebhookHandler) encodeDeliveryCursor(subscriptionID uuid.UUID, position *models.DeliveryCursor) (*string, error) {
+	if position == nil {
+		return nil, nil
+	}
+	token, err := wh.cursorCodec.Encode(dto.NewDeliveryPaginationCursor(subscriptionID, *position))
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
@@ -0,0 +1,82 @@
+package modules
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/shared/application/events"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/interfaces"
+	"github.com/Akiles94/go-test-api/contexts/shared/application/pagination"
+	shared_models "github.com/Akiles94/go-test-api/contexts/shared/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/shared/infra/middlewares"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/use_cases"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/domain/models"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/infra/adapters"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/infra/handlers"
+	"github.com/Akiles94/go-test-api/contexts/webhooks/infra/workers"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	deliveryBatchSize    = 50
+	deliveryPollInterval = time.Second
+	deliverySendTimeout  = 10 * time.Second
+	// deliveryLease lets a worker send a whole batch, one receiver at a time,
+	// before other workers may claim its deliveries again.
+	deliveryLease = deliveryBatchSize*deliverySendTimeout + time.Minute
+)
+
+type WebhooksModule struct {
+	handler *handlers.WebhookHandler
+	worker  *workers.DeliveryWorker
+}
+
+// NewWebhooksModule wires the webhooks context and subscribes it to every
+// event relayed through dispatcher.
+func NewWebhooksModule(db *gorm.DB, txManager interfaces.TransactionManager, dispatcher *events.Dispatcher, cursorCodec *pagination.CursorCodec) *WebhooksModule {
+	subscriptionRepo := adapters.NewSubscriptionRepository(db)
+	deliveryRepo := adapters.NewDeliveryRepository(db)
+	sender := adapters.NewHTTPWebhookSender(deliverySendTimeout, false)
+
+	createSubscriptionUseCase := use_cases.NewCreateSubscriptionUseCase(subscriptionRepo, sender)
+	getAllSubscriptionsUseCase := use_cases.NewGetAllSubscriptionsUseCase(subscriptionRepo)
+	getOneSubscriptionUseCase := use_cases.NewGetOneSubscriptionUseCase(subscriptionRepo)
+	deleteSubscriptionUseCase := use_cases.NewDeleteSubscriptionUseCase(subscriptionRepo)
+	getDeliveriesUseCase := use_cases.NewGetDeliveriesUseCase(deliveryRepo)
+	enqueueDeliveriesUseCase := use_cases.NewEnqueueDeliveriesUseCase(subscriptionRepo, deliveryRepo)
+	deliverDueWebhooksUseCase := use_cases.NewDeliverDueWebhooksUseCase(subscriptionRepo, deliveryRepo, sender, txManager, models.DefaultRetryPolicy(), deliveryBatchSize, deliveryLease)
+
+	dispatcher.SubscribeAll(enqueueDeliveriesUseCase.Execute)
+
+	handler := handlers.NewWebhookHandler(
+		createSubscriptionUseCase,
+		getAllSubscriptionsUseCase,
+		getOneSubscriptionUseCase,
+		deleteSubscriptionUseCase,
+		getDeliveriesUseCase,
+		cursorCodec)
+
+	return &WebhooksModule{
+		handler: handler,
+		worker:  workers.NewDeliveryWorker(deliverDueWebhooksUseCase, deliveryPollInterval),
+	}
+}
+
+func (wm *WebhooksModule) RegisterRoutes(router *gin.RouterGroup) {
+	manage := shared_models.PermissionWebhooksManage
+
+	middlewares.RegisterRoutes(router, []middlewares.Route{
+		{Method: http.MethodPost, Path: "/subscriptions", Permission: manage, Handler: wm.handler.CreateSubscription},
+		{Method: http.MethodGet, Path: "/subscriptions", Permission: manage, Handler: wm.handler.GetAllSubscriptions},
+		{Method: http.MethodGet, Path: "/subscriptions/:id", Permission: manage, Handler: wm.handler.GetSubscription},
+		{Method: http.MethodDelete, Path: "/subscriptions/:id", Permission: manage, Handler: wm.handler.DeleteSubscription},
+		{Method: http.MethodGet, Path: "/subscriptions/:id/deliveries", Permission: manage, Handler: wm.handler.GetDeliveries},
+	})
+}
+
+// RunWorker sends due deliveries until ctx is cancelled.
+func (wm *WebhooksModule) RunWorker(ctx context.Context) {
+	wm.worker.Run(ctx)
+}
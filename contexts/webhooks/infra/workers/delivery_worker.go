@@ -0,0 +1,47 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Akiles94/go-test-api/contexts/webhooks/application/ports/inbound"
+)
+
+const defaultPollInterval = time.Second
+
+// DeliveryWorker periodically sends the webhook deliveries that are due.
+// Several workers can run side by side.
+type DeliveryWorker struct {
+	deliverUseCase inbound.DeliverDueWebhooksUseCasePort
+	pollInterval   time.Duration
+}
+
+func NewDeliveryWorker(deliverUseCase inbound.DeliverDueWebhooksUseCasePort, pollInterval time.Duration) *DeliveryWorker {
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+	return &DeliveryWorker{deliverUseCase: deliverUseCase, pollInterval: pollInterval}
+}
+
+// Run polls for due deliveries until ctx is cancelled. After a round that
+// attempted deliveries it checks again straight away instead of waiting.
+func (w *DeliveryWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		attempted, err := w.deliverUseCase.Execute(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("⚠️  Webhook delivery failed: %v", err)
+		}
+		if err == nil && attempted > 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}